@@ -299,6 +299,15 @@ func (f *File) adjustFormula(sheet, sheetN string, cell *xlsxC, dir adjustDirect
 	return nil
 }
 
+// unquoteSheetName remove the single quotation marks which enclosed the
+// worksheet name in the reference.
+func unquoteSheetName(name string) string {
+	if strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") && len(name) > 1 {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
 // escapeSheetName enclose sheet name in single quotation marks if the giving
 // worksheet name includes spaces or non-alphabetical characters.
 func escapeSheetName(name string) string {
@@ -410,6 +419,15 @@ func (f *File) adjustFormulaOperand(sheet, sheetN string, keepRelative bool, tok
 // adjustFormulaRef returns adjusted formula by giving adjusting direction and
 // the base number of column or row, and offset.
 func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool, dir adjustDirection, num, offset int) (string, error) {
+	return f.replaceFormulaOperands(sheet, formula, func(token efp.Token) (string, error) {
+		return f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
+	})
+}
+
+// replaceFormulaOperands returns the formula which range operand tokens were
+// replaced by given function, the defined names and the external references
+// in the formula will be kept.
+func (f *File) replaceFormulaOperands(sheet, formula string, fn func(token efp.Token) (string, error)) (string, error) {
 	var (
 		val          string
 		definedNames []string
@@ -434,7 +452,7 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 				val += token.TValue
 				continue
 			}
-			operand, err := f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
+			operand, err := fn(token)
 			if err != nil {
 				return val, err
 			}
@@ -458,6 +476,32 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 	return val, nil
 }
 
+// shiftFormulaRelativeRows returns the formula which relative row references
+// were shifted by given offset, and the absolute row references will be kept.
+// The reference will be replaced with #REF! error if the shifted row number
+// out of the worksheet.
+func (f *File) shiftFormulaRelativeRows(sheet, formula string, offset int) (string, error) {
+	return f.replaceFormulaOperands(sheet, formula, func(token efp.Token) (string, error) {
+		ref, operand := token.TValue, ""
+		if i := strings.LastIndex(ref, "!"); i != -1 {
+			operand, ref = ref[:i+1], ref[i+1:]
+		}
+		parts := strings.Split(ref, ":")
+		for i, part := range parts {
+			idx := strings.LastIndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+			if idx == len(part)-1 || (idx != -1 && part[idx] == '$') {
+				continue
+			}
+			row, _ := strconv.Atoi(part[idx+1:])
+			if row += offset; row < 1 || row > TotalRows {
+				return formulaErrorREF, nil
+			}
+			parts[i] = part[:idx+1] + strconv.Itoa(row)
+		}
+		return operand + strings.Join(parts, ":"), nil
+	})
+}
+
 // adjustRangeSheetName returns replaced range reference by given source and
 // target sheet name.
 func adjustRangeSheetName(rng, source, target string) string {
@@ -1051,7 +1095,7 @@ func (a *xdrCellAnchor) adjustDrawings(dir adjustDirection, num, offset int) err
 	if err != nil {
 		return err
 	}
-	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "")
+	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "" || editAs == "twoCell")
 }
 
 // adjustDrawings updates the existing two cell anchor pictures and charts
@@ -1064,7 +1108,7 @@ func (a *xlsxCellAnchorPos) adjustDrawings(dir adjustDirection, num, offset int,
 	if err != nil {
 		return err
 	}
-	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "")
+	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "" || editAs == "twoCell")
 }
 
 // adjustDrawings updates the pictures and charts object when inserting or
@@ -1002,6 +1002,8 @@ func TestAdjustConditionalFormats(t *testing.T) {
 	opts, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, format, 1)
+	format[0].Style, err = f.GetConditionalStyle(formatID)
+	assert.NoError(t, err)
 	assert.Equal(t, format, opts["C1:D1"])
 
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
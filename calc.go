@@ -21,6 +21,7 @@ import (
 	"math/cmplx"
 	"math/rand"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
@@ -86,6 +87,14 @@ const (
 
 	maxFinancialIterations = 128
 	financialPrecision     = 1.0e-08
+	// maxArrayCells defined the maximum number of cells in the array which
+	// generated by the dynamic array functions, the #NUM! error will be
+	// returned if the array exceeds this limit
+	maxArrayCells = TotalRows
+	// maxLambdaCallDepth defined the maximum nesting depth of the LAMBDA
+	// function calls, the #NUM! error will be returned if the recursive LAMBDA
+	// function calls exceeds this limit
+	maxLambdaCallDepth = 1024
 	// Date and time format regular expressions
 	monthRe    = `((jan|january)|(feb|february)|(mar|march)|(apr|april)|(may)|(jun|june)|(jul|july)|(aug|august)|(sep|september)|(oct|october)|(nov|november)|(dec|december))`
 	df1        = `(([0-9])+)/(([0-9])+)/(([0-9])+)`
@@ -211,15 +220,25 @@ var (
 		criteriaL,
 		criteriaG,
 	}
+	// volatileFunctions defined the formula functions which will be
+	// recalculated on every calculation of the workbook.
+	volatileFunctions = map[string]bool{
+		"CELL": true, "INDIRECT": true, "INFO": true, "NOW": true, "OFFSET": true,
+		"RAND": true, "RANDARRAY": true, "RANDBETWEEN": true, "TODAY": true,
+	}
 )
 
 // calcContext defines the formula execution context.
 type calcContext struct {
-	mu                sync.Mutex
-	entry             string
-	maxCalcIterations uint
-	iterations        map[string]uint
-	iterationsCache   map[string]formulaArg
+	mu                 sync.Mutex
+	entry              string
+	maxCalcIterations  uint
+	iterations         map[string]uint
+	iterationsCache    map[string]formulaArg
+	calculating        []string
+	lambdaCallDepth    int
+	lambdaCallOverflow bool
+	rand               *rand.Rand
 }
 
 // cellRef defines the structure of a cell reference.
@@ -266,6 +285,14 @@ type formulaArg struct {
 	Error                string
 	Type                 ArgType
 	cellRefs, cellRanges *list.List
+	lambda               *formulaLambda
+}
+
+// formulaLambda defined the parameters and calculation tokens of the LAMBDA
+// function which be passed as the argument of the lambda helper functions.
+type formulaLambda struct {
+	params []string
+	body   []efp.Token
 }
 
 // Value returns a string data type of the formula argument.
@@ -354,9 +381,14 @@ type formulaFuncs struct {
 }
 
 // CalcCellValue provides a function to get calculated cell value. This feature
-// is currently in working processing. Iterative calculation, implicit
-// intersection, explicit intersection, array formula, table formula and some
-// other formulas are not supported currently.
+// is currently in working processing. Implicit intersection, explicit
+// intersection, array formula, table formula and some other formulas are not
+// supported currently. The ErrCalcCircularReference error will be returned if
+// the formula refers back to its own cell through other formula cells, unless
+// the iterative calculation enabled by the MaxCalcIterations option. The
+// formula which directly references its own cell, either by a single cell
+// reference or inside a range reference, will use the cached value of the
+// cell.
 //
 // Supported formula functions:
 //
@@ -405,9 +437,12 @@ type formulaFuncs struct {
 //	BITOR
 //	BITRSHIFT
 //	BITXOR
+//	BYCOL
+//	BYROW
 //	CEILING
 //	CEILING.MATH
 //	CEILING.PRECISE
+//	CELL
 //	CHAR
 //	CHIDIST
 //	CHIINV
@@ -418,6 +453,8 @@ type formulaFuncs struct {
 //	CHISQ.TEST
 //	CHITEST
 //	CHOOSE
+//	CHOOSECOLS
+//	CHOOSEROWS
 //	CLEAN
 //	CODE
 //	COLUMN
@@ -481,6 +518,7 @@ type formulaFuncs struct {
 //	DOLLARDE
 //	DOLLARFR
 //	DPRODUCT
+//	DROP
 //	DSTDEV
 //	DSTDEVP
 //	DSUM
@@ -500,6 +538,7 @@ type formulaFuncs struct {
 //	EVEN
 //	EXACT
 //	EXP
+//	EXPAND
 //	EXPON.DIST
 //	EXPONDIST
 //	F.DIST
@@ -538,6 +577,7 @@ type formulaFuncs struct {
 //	GCD
 //	GEOMEAN
 //	GESTEP
+//	GETPIVOTDATA
 //	GROWTH
 //	HARMEAN
 //	HEX2BIN
@@ -553,6 +593,7 @@ type formulaFuncs struct {
 //	IFNA
 //	IFS
 //	IMABS
+//	IMAGE
 //	IMAGINARY
 //	IMARGUMENT
 //	IMCONJUGATE
@@ -600,21 +641,25 @@ type formulaFuncs struct {
 //	ISREF
 //	ISTEXT
 //	KURT
+//	LAMBDA
 //	LARGE
 //	LCM
 //	LEFT
 //	LEFTB
 //	LEN
 //	LENB
+//	LINEST
 //	LN
 //	LOG
 //	LOG10
+//	LOGEST
 //	LOGINV
 //	LOGNORM.DIST
 //	LOGNORM.INV
 //	LOGNORMDIST
 //	LOOKUP
 //	LOWER
+//	MAP
 //	MATCH
 //	MAX
 //	MAXA
@@ -697,11 +742,13 @@ type formulaFuncs struct {
 //	QUOTIENT
 //	RADIANS
 //	RAND
+//	RANDARRAY
 //	RANDBETWEEN
 //	RANK
 //	RANK.EQ
 //	RATE
 //	RECEIVED
+//	REDUCE
 //	REPLACE
 //	REPLACEB
 //	REPT
@@ -715,11 +762,13 @@ type formulaFuncs struct {
 //	ROWS
 //	RRI
 //	RSQ
+//	SCAN
 //	SEARCH
 //	SEARCHB
 //	SEC
 //	SECH
 //	SECOND
+//	SEQUENCE
 //	SERIESSUM
 //	SHEET
 //	SHEETS
@@ -760,6 +809,7 @@ type formulaFuncs struct {
 //	T.INV
 //	T.INV.2T
 //	T.TEST
+//	TAKE
 //	TAN
 //	TANH
 //	TBILLEQ
@@ -770,6 +820,7 @@ type formulaFuncs struct {
 //	TEXTAFTER
 //	TEXTBEFORE
 //	TEXTJOIN
+//	TEXTSPLIT
 //	TIME
 //	TIMEVALUE
 //	TINV
@@ -803,6 +854,7 @@ type formulaFuncs struct {
 //	WORKDAY.INTL
 //	XIRR
 //	XLOOKUP
+//	XMATCH
 //	XNPV
 //	XOR
 //	YEAR
@@ -824,6 +876,7 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 		maxCalcIterations: options.MaxCalcIterations,
 		iterations:        make(map[string]uint),
 		iterationsCache:   make(map[string]formulaArg),
+		rand:              f.getCalcRand(options.RandSeed),
 	}, sheet, cell); err != nil {
 		result = token.String
 		return
@@ -856,7 +909,11 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	if tokens == nil {
 		return f.cellResolver(ctx, sheet, cell)
 	}
-	result, err = f.evalInfixExp(ctx, sheet, cell, tokens)
+	if result, err = f.evalInfixExp(ctx, sheet, cell, tokens); err != nil {
+		if _, ok := err.(ErrCalcCircularReference); ok {
+			result = newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+		}
+	}
 	return
 }
 
@@ -956,6 +1013,29 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				inArrayRow, formulaArrayRow = true, []formulaArg{}
 				continue
 			}
+			if isLambdaStartToken(token) {
+				if end := lambdaEndIndex(tokens, i); end+1 < len(tokens) && isBeginParenthesesToken(tokens[end+1]) {
+					result, stop, err := f.evalInlineLambda(ctx, sheet, cell, tokens, i, end)
+					if err != nil {
+						return result, err
+					}
+					resultTokens, result := lambdaArgToTokens(result)
+					if result.Type == ArgError {
+						return result, errors.New(result.Error)
+					}
+					// replace the LAMBDA function and the calling parameters
+					// with the result, and evaluate it again
+					tokens = append(append(append([]efp.Token{}, tokens[:i]...), resultTokens...), tokens[stop+1:]...)
+					i--
+					continue
+				}
+			}
+			if opfStack.Len() > 0 && isLambdaStartToken(token) {
+				end := lambdaEndIndex(tokens, i)
+				argsStack.Peek().(*list.List).PushBack(newLambdaFormulaArg(tokens[i : end+1]))
+				i = end
+				continue
+			}
 			opfStack.Push(token)
 			argsStack.Push(list.New().Init())
 			opftStack.Push(token) // to know which operators belong to a function use the function as a separator
@@ -1072,9 +1152,9 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	}
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
-		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	fn := &formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}
+	name := strings.NewReplacer("_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue)
+	arg := f.callFuncOrLambda(fn, name, argsStack.Peek().(*list.List))
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
 	}
@@ -1098,6 +1178,266 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	return newEmptyFormulaArg()
 }
 
+// callFuncOrLambda calls the built-in formula function by given name, or the
+// LAMBDA function defined by a defined name if the built-in function doesn't
+// exist.
+func (f *File) callFuncOrLambda(fn *formulaFuncs, name string, argsList *list.List) formulaArg {
+	if !reflect.ValueOf(fn).MethodByName(name).IsValid() {
+		if params, body, ok := f.getDefinedNameLambda(name, fn.sheet); ok {
+			return fn.callLambda(params, body, argsList)
+		}
+	}
+	return callFuncByName(fn, name, []reflect.Value{reflect.ValueOf(argsList)})
+}
+
+// getDefinedNameLambda returns the parameters and calculation tokens of the
+// LAMBDA function which bound to the defined name by given defined name and
+// current worksheet name.
+func (f *File) getDefinedNameLambda(name, sheet string) ([]string, []efp.Token, bool) {
+	refTo := f.getDefinedNameRefTo(name, sheet)
+	if refTo == "" {
+		return nil, nil, false
+	}
+	ps := efp.ExcelParser()
+	return parseLambdaTokens(ps.Parse(strings.TrimPrefix(refTo, "=")))
+}
+
+// isLambdaStartToken returns if the given token is the start token of the
+// LAMBDA function.
+func isLambdaStartToken(token efp.Token) bool {
+	return isFunctionStartToken(token) && strings.EqualFold(strings.TrimPrefix(token.TValue, "_xlfn."), "LAMBDA")
+}
+
+// lambdaEndIndex returns the index of the stop token of the LAMBDA function
+// which starts at the given index in the tokens.
+func lambdaEndIndex(tokens []efp.Token, start int) int {
+	var depth int
+	for i := start; i < len(tokens); i++ {
+		if isFunctionStartToken(tokens[i]) || isBeginParenthesesToken(tokens[i]) {
+			depth++
+		}
+		if isFunctionStopToken(tokens[i]) || isEndParenthesesToken(tokens[i]) {
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// evalInlineLambda evaluate the LAMBDA function which called directly in the
+// formula, such as LAMBDA(x,x*x)(3), by given tokens and the index of the
+// start and stop token of the LAMBDA function. It returns the result and the
+// index of the end token of the calling parameters.
+func (f *File) evalInlineLambda(ctx *calcContext, sheet, cell string, tokens []efp.Token, start, end int) (formulaArg, int, error) {
+	params, body, ok := parseLambdaTokens(tokens[start : end+1])
+	if !ok {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE), end, errors.New(formulaErrorVALUE)
+	}
+	var (
+		depth, stop int
+		arg         []efp.Token
+		args        [][]efp.Token
+	)
+	for stop = end + 1; stop < len(tokens); stop++ {
+		token := tokens[stop]
+		if isFunctionStartToken(token) || isBeginParenthesesToken(token) {
+			if depth++; depth == 1 {
+				continue
+			}
+		}
+		if isFunctionStopToken(token) || isEndParenthesesToken(token) {
+			if depth--; depth == 0 {
+				break
+			}
+		}
+		if depth == 1 && token.TType == efp.TokenTypeOperatorInfix && token.TSubType == efp.TokenSubTypeUnion {
+			args, arg = append(args, arg), nil
+			continue
+		}
+		arg = append(arg, token)
+	}
+	if stop == len(tokens) {
+		stop--
+	}
+	if len(args) > 0 || len(arg) > 0 {
+		args = append(args, arg)
+	}
+	argsList := list.New()
+	for _, arg := range args {
+		if len(arg) == 1 && arg[0].TSubType == efp.TokenSubTypeRange {
+			// pass the cell range arguments by reference
+			ref := arg[0].TValue
+			if refTo := f.getDefinedNameRefTo(ref, sheet); refTo != "" {
+				ref = refTo
+			}
+			value, err := f.parseReference(ctx, sheet, ref)
+			if err != nil {
+				return value, stop, err
+			}
+			argsList.PushBack(value)
+			continue
+		}
+		value, err := f.evalInfixExp(ctx, sheet, cell, arg)
+		if err != nil {
+			return value, stop, err
+		}
+		argsList.PushBack(value)
+	}
+	fn := &formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}
+	return fn.callLambda(params, body, argsList), stop, nil
+}
+
+// newLambdaFormulaArg create a formula argument which stores the LAMBDA
+// function by given tokens of the LAMBDA function.
+func newLambdaFormulaArg(tokens []efp.Token) formulaArg {
+	params, body, ok := parseLambdaTokens(tokens)
+	if !ok {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	return formulaArg{Type: ArgUnknown, lambda: &formulaLambda{params: params, body: body}}
+}
+
+// parseLambdaTokens returns the parameters and calculation tokens by given
+// tokens of the LAMBDA function.
+func parseLambdaTokens(tokens []efp.Token) ([]string, []efp.Token, bool) {
+	if len(tokens) < 3 || !isLambdaStartToken(tokens[0]) || !isFunctionStopToken(tokens[len(tokens)-1]) {
+		return nil, nil, false
+	}
+	var (
+		depth  int
+		arg    []efp.Token
+		params []string
+		args   [][]efp.Token
+	)
+	for _, token := range tokens[1 : len(tokens)-1] {
+		if isFunctionStartToken(token) || isBeginParenthesesToken(token) {
+			depth++
+		}
+		if isFunctionStopToken(token) || isEndParenthesesToken(token) {
+			depth--
+		}
+		if depth == 0 && token.TType == efp.TokenTypeArgument {
+			args, arg = append(args, arg), nil
+			continue
+		}
+		arg = append(arg, token)
+	}
+	args = append(args, arg)
+	for _, param := range args[:len(args)-1] {
+		if len(param) != 1 || param[0].TSubType != efp.TokenSubTypeRange {
+			return nil, nil, false
+		}
+		params = append(params, trimLambdaParam(param[0].TValue))
+	}
+	return params, args[len(args)-1], len(args[len(args)-1]) > 0
+}
+
+// trimLambdaParam returns the LAMBDA function parameter name without the
+// prefix.
+func trimLambdaParam(name string) string {
+	if strings.HasPrefix(strings.ToLower(name), "_xlpm.") {
+		return name[6:]
+	}
+	return name
+}
+
+// callLambda evaluate the calculation of the LAMBDA function by given
+// parameters, calculation tokens and arguments list.
+func (fn *formulaFuncs) callLambda(params []string, body []efp.Token, argsList *list.List) formulaArg {
+	if argsList.Len() != len(params) {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("LAMBDA requires %d arguments", len(params)))
+	}
+	if fn.ctx.lambdaCallDepth >= maxLambdaCallDepth {
+		fn.ctx.lambdaCallOverflow = true
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	values := map[string][]efp.Token{}
+	for i, arg := 0, argsList.Front(); arg != nil; i, arg = i+1, arg.Next() {
+		argTokens, value := lambdaArgToTokens(arg.Value.(formulaArg))
+		if value.Type == ArgError {
+			return value
+		}
+		values[strings.ToUpper(params[i])] = argTokens
+	}
+	tokens := make([]efp.Token, 0, len(body))
+	for _, token := range body {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if value, ok := values[strings.ToUpper(trimLambdaParam(token.TValue))]; ok {
+				tokens = append(tokens, value...)
+				continue
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	fn.ctx.lambdaCallDepth++
+	result, err := fn.f.evalInfixExp(fn.ctx, fn.sheet, fn.cell, tokens)
+	if fn.ctx.lambdaCallDepth--; fn.ctx.lambdaCallOverflow {
+		// propagate the #NUM! error of the too deep recursive calls
+		// without wrapping it level by level
+		fn.ctx.lambdaCallOverflow = fn.ctx.lambdaCallDepth > 0
+		if result.Value() == formulaErrorNUM || (err != nil && err.Error() == formulaErrorNUM) {
+			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+		}
+	}
+	if err != nil && result.Type != ArgError {
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	return result
+}
+
+// lambdaArgToTokens create tokens by given argument of the LAMBDA function,
+// the cell range arguments will be passed by reference, and the array
+// arguments will be passed as the array constant.
+func lambdaArgToTokens(arg formulaArg) ([]efp.Token, formulaArg) {
+	if arg.cellRanges != nil && arg.cellRanges.Len() == 1 {
+		cr := arg.cellRanges.Front().Value.(cellRange)
+		from, _ := CoordinatesToCellName(cr.From.Col, cr.From.Row)
+		to, _ := CoordinatesToCellName(cr.To.Col, cr.To.Row)
+		ref := from + ":" + to
+		if cr.From.Sheet != "" {
+			ref = escapeSheetName(cr.From.Sheet) + "!" + ref
+		}
+		return []efp.Token{{TValue: ref, TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeRange}}, arg
+	}
+	switch arg.Type {
+	case ArgError:
+		return nil, arg
+	case ArgMatrix:
+		if len(arg.Matrix) == 0 || len(arg.Matrix[0]) == 0 {
+			return nil, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return arrayToTokens(arg.Matrix), arg
+	case ArgList:
+		if len(arg.List) == 0 {
+			return nil, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return arrayToTokens([][]formulaArg{arg.List}), arg
+	}
+	return []efp.Token{formulaArgToToken(arg)}, arg
+}
+
+// arrayToTokens create the tokens of the array constant by given array.
+func arrayToTokens(array [][]formulaArg) []efp.Token {
+	separator := efp.Token{TValue: ",", TType: efp.TokenTypeArgument}
+	stop := efp.Token{TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStop}
+	tokens := []efp.Token{{TValue: "ARRAY", TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStart}}
+	for r, row := range array {
+		if r > 0 {
+			tokens = append(tokens, separator)
+		}
+		tokens = append(tokens, efp.Token{TValue: "ARRAYROW", TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStart})
+		for c, cell := range row {
+			if c > 0 {
+				tokens = append(tokens, separator)
+			}
+			tokens = append(tokens, formulaArgToToken(cell))
+		}
+		tokens = append(tokens, stop)
+	}
+	return append(tokens, stop)
+}
+
 // prepareEvalInfixExp check the token and stack state for formula function
 // evaluate.
 func prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack *Stack) {
@@ -1450,6 +1790,9 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 		}
 		result, err := f.parseReference(ctx, sheet, token.TValue)
 		if err != nil {
+			if _, ok := err.(ErrCalcCircularReference); ok {
+				return err
+			}
 			return errors.New(formulaErrorNAME)
 		}
 		token = formulaArgToToken(result)
@@ -1483,7 +1826,8 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 	return nil
 }
 
-// parseRef parse reference for a cell, column name or row number.
+// parseRef parse reference for a cell, column name or row number, the quoted
+// worksheet name in the reference will be unescaped.
 func parseRef(ref string) (cellRef, bool, bool, error) {
 	var (
 		err, colErr, rowErr error
@@ -1492,7 +1836,7 @@ func parseRef(ref string) (cellRef, bool, bool, error) {
 		tokens              = strings.Split(ref, "!")
 	)
 	if len(tokens) == 2 { // have a worksheet
-		cr.Sheet, cell = tokens[0], tokens[1]
+		cr.Sheet, cell = unquoteSheetName(tokens[0]), tokens[1]
 	}
 	if cr.Col, cr.Row, err = CellNameToCoordinates(cell); err != nil {
 		if cr.Col, colErr = ColumnNameToNumber(cell); colErr == nil { // cast to column
@@ -1535,9 +1879,9 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 	return nil
 }
 
-// parseReference parse reference and extract values by given reference
-// characters and default sheet name.
-func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
+// prepareReference parse reference to the cell references and the cell ranges
+// by given reference characters and default sheet name.
+func prepareReference(sheet, reference string) (*list.List, *list.List, error) {
 	reference = strings.ReplaceAll(reference, "$", "")
 	ranges, cellRanges, cellRefs := strings.Split(reference, ":"), list.New(), list.New()
 	if len(ranges) > 1 {
@@ -1545,7 +1889,7 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 		for i, ref := range ranges {
 			cellRef, col, row, err := parseRef(ref)
 			if err != nil {
-				return newErrorFormulaArg(formulaErrorNAME, "invalid reference"), errors.New("invalid reference")
+				return cellRefs, cellRanges, errors.New("invalid reference")
 			}
 			if i == 0 {
 				if col {
@@ -1561,20 +1905,30 @@ func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formul
 				continue
 			}
 			if err := cr.prepareCellRange(col, row, cellRef); err != nil {
-				return newErrorFormulaArg(formulaErrorNAME, err.Error()), err
+				return cellRefs, cellRanges, err
 			}
 		}
 		cellRanges.PushBack(cr)
-		return f.rangeResolver(ctx, cellRefs, cellRanges)
+		return cellRefs, cellRanges, nil
 	}
 	cellRef, _, _, err := parseRef(reference)
 	if err != nil {
-		return newErrorFormulaArg(formulaErrorNAME, "invalid reference"), errors.New("invalid reference")
+		return cellRefs, cellRanges, errors.New("invalid reference")
 	}
 	if cellRef.Sheet == "" {
 		cellRef.Sheet = sheet
 	}
 	cellRefs.PushBack(cellRef)
+	return cellRefs, cellRanges, nil
+}
+
+// parseReference parse reference and extract values by given reference
+// characters and default sheet name.
+func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
+	cellRefs, cellRanges, err := prepareReference(sheet, reference)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorNAME, err.Error()), err
+	}
 	return f.rangeResolver(ctx, cellRefs, cellRanges)
 }
 
@@ -1610,6 +1964,20 @@ func prepareValueRef(cr cellRef, valueRange []int) {
 	}
 }
 
+// isCircularRef returns if the given cell reference has been in the
+// calculating formula chain. The reference of the cell which is calculating
+// currently will be treated as a direct self-reference and the cached value of
+// the cell will be used.
+func (ctx *calcContext) isCircularRef(ref string) bool {
+	if len(ctx.calculating) == 0 {
+		return false
+	}
+	if ref == ctx.calculating[len(ctx.calculating)-1] {
+		return false
+	}
+	return ref == ctx.entry || inStrSlice(ctx.calculating, ref, true) != -1
+}
+
 // cellResolver calc cell value by given worksheet name, cell reference and context.
 func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
 	var (
@@ -1620,16 +1988,29 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
 	if formula, _ := f.getCellFormula(sheet, cell, true); len(formula) != 0 {
 		ctx.mu.Lock()
-		if ctx.entry != ref {
-			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
+		if ctx.maxCalcIterations == 0 && ctx.isCircularRef(ref) {
+			ctx.mu.Unlock()
+			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), ErrCalcCircularReference{Cell: ref}
+		}
+		if ctx.entry != ref || ctx.maxCalcIterations > 0 {
+			if ctx.iterations[ref] <= ctx.maxCalcIterations {
 				ctx.iterations[ref]++
+				ctx.calculating = append(ctx.calculating, ref)
 				ctx.mu.Unlock()
-				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				arg, err = f.calcCellValue(ctx, sheet, cell)
+				ctx.mu.Lock()
+				ctx.calculating = ctx.calculating[:len(ctx.calculating)-1]
 				ctx.iterationsCache[ref] = arg
+				ctx.mu.Unlock()
+				if _, ok := err.(ErrCalcCircularReference); ok {
+					return arg, err
+				}
 				return arg, nil
 			}
-			ctx.mu.Unlock()
-			return ctx.iterationsCache[ref], nil
+			if cache, ok := ctx.iterationsCache[ref]; ok {
+				ctx.mu.Unlock()
+				return cache, nil
+			}
 		}
 		ctx.mu.Unlock()
 	}
@@ -1769,12 +2150,6 @@ func formulaCriteriaParser(exp formulaArg) *formulaCriteria {
 			return fc
 		}
 	}
-	if strings.Contains(val, "?") {
-		val = strings.ReplaceAll(val, "?", ".")
-	}
-	if strings.Contains(val, "*") {
-		val = strings.ReplaceAll(val, "*", ".*")
-	}
 	fc.Type, fc.Condition = criteriaRegexp, newStringFormulaArg(val)
 	if num := fc.Condition.ToNumber(); num.Type == ArgNumber {
 		fc.Condition = num
@@ -1794,18 +2169,75 @@ func formulaCriteriaEval(val formulaArg, criteria *formulaCriteria) (result bool
 		criteriaGe: calcGe,
 	}
 	switch criteria.Type {
-	case criteriaEq, criteriaLe, criteriaGe, criteriaNe, criteriaL, criteriaG:
+	case criteriaEq, criteriaNe:
+		if criteria.Condition.Type == ArgString {
+			if result, err = regexp.MatchString(formulaCriteriaPattern(criteria.Condition.Value()), val.Value()); criteria.Type == criteriaNe {
+				result = !result
+			}
+			return
+		}
+		fallthrough
+	case criteriaLe, criteriaGe, criteriaL, criteriaG:
+		// the relational operators only compare the numbers with the numeric
+		// criteria, and the text with the text criteria case-insensitively
+		condition := criteria.Condition
+		if criteria.Type != criteriaEq && criteria.Type != criteriaNe {
+			if (condition.Type == ArgNumber) != (val.ToNumber().Type == ArgNumber) {
+				return
+			}
+			if condition.Type == ArgString {
+				condition, val = newStringFormulaArg(strings.ToLower(condition.Value())), newStringFormulaArg(strings.ToLower(val.Value()))
+			}
+		}
 		if fn, ok := tokenCalcFunc[criteria.Type]; ok {
-			if _ = fn(criteria.Condition, val, s); s.Len() > 0 {
+			if _ = fn(condition, val, s); s.Len() > 0 {
 				return s.Pop().(formulaArg).Number == 1, err
 			}
 		}
 	case criteriaRegexp:
-		return regexp.MatchString(criteria.Condition.Value(), val.Value())
+		return regexp.MatchString(formulaCriteriaPattern(criteria.Condition.Value()), val.Value())
 	}
 	return
 }
 
+// formulaCriteriaPattern returns the case-insensitive regular expression
+// pattern by given criteria which may contain the wildcard characters, the
+// question mark (?) matches any single character, the asterisk (*) matches
+// any sequence of characters, and the tilde (~) escapes the next wildcard
+// character.
+func formulaCriteriaPattern(criteria string) string {
+	var (
+		escape  bool
+		pattern strings.Builder
+	)
+	pattern.WriteString("(?i)^")
+	for _, r := range criteria {
+		if escape {
+			escape = false
+			if r == '*' || r == '?' || r == '~' {
+				pattern.WriteString(regexp.QuoteMeta(string(r)))
+				continue
+			}
+			pattern.WriteString("~")
+		}
+		switch r {
+		case '~':
+			escape = true
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escape {
+		pattern.WriteString("~")
+	}
+	pattern.WriteString("$")
+	return pattern.String()
+}
+
 // Engineering Functions
 
 // BESSELI function the modified Bessel function, which is equivalent to the
@@ -5131,6 +5563,66 @@ func (fn *formulaFuncs) RADIANS(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(math.Pi / 180.0 * angle.Number)
 }
 
+// newCalcRand provides a function to create the random number generator for
+// the calculation by given seed, the current time will be used as the seed if
+// the given seed is zero.
+func newCalcRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// getCalcRand provides a function to get the random number generator for a
+// calculation by given seed. The random number generator of the workbook will
+// be seeded only once unless the seed changed, and each calculation derives
+// its own random number generator from it, so that the random values of the
+// cells are different from each other.
+func (f *File) getCalcRand(seed int64) *rand.Rand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calcRand == nil || f.calcRandSeed != seed {
+		f.calcRand, f.calcRandSeed = newCalcRand(seed), seed
+	}
+	return rand.New(rand.NewSource(f.calcRand.Int63()))
+}
+
+// rand returns the random number generator of the calculation context.
+func (fn *formulaFuncs) rand() *rand.Rand {
+	if fn.ctx == nil || fn.ctx.rand == nil {
+		return newCalcRand(0)
+	}
+	return fn.ctx.rand
+}
+
+// prepareArrayDimension checks and returns the number of rows and columns
+// arguments of the dynamic array functions. It returns the #NUM! error if the
+// number of rows or columns exceeds the worksheet limits, or the total number
+// of cells exceeds maxArrayCells.
+func prepareArrayDimension(rows, cols formulaArg) (int, int, formulaArg) {
+	for _, arg := range []formulaArg{rows, cols} {
+		if arg.Type == ArgError {
+			return 0, 0, arg
+		}
+	}
+	r, c := rows.ToNumber(), cols.ToNumber()
+	for _, arg := range []formulaArg{r, c} {
+		if arg.Type == ArgError {
+			return 0, 0, arg
+		}
+		if arg.Number < 0 {
+			return 0, 0, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		if int(arg.Number) == 0 {
+			return 0, 0, newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+		}
+	}
+	if int(r.Number) > TotalRows || int(c.Number) > MaxColumns || math.Trunc(r.Number)*math.Trunc(c.Number) > maxArrayCells {
+		return 0, 0, newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	return int(r.Number), int(c.Number), newEmptyFormulaArg()
+}
+
 // RAND function generates a random real number between 0 and 1. The syntax of
 // the function is:
 //
@@ -5139,7 +5631,58 @@ func (fn *formulaFuncs) RAND(argsList *list.List) formulaArg {
 	if argsList.Len() != 0 {
 		return newErrorFormulaArg(formulaErrorVALUE, "RAND accepts no arguments")
 	}
-	return newNumberFormulaArg(rand.New(rand.NewSource(time.Now().UnixNano())).Float64())
+	return newNumberFormulaArg(fn.rand().Float64())
+}
+
+// RANDARRAY function returns an array of random numbers. The random numbers
+// are decimal values between 0 and 1 by default, or integer values if the
+// whole_number argument is TRUE. The syntax of the function is:
+//
+//	RANDARRAY([rows],[columns],[min],[max],[whole_number])
+func (fn *formulaFuncs) RANDARRAY(argsList *list.List) formulaArg {
+	if argsList.Len() > 5 {
+		return newErrorFormulaArg(formulaErrorVALUE, "RANDARRAY accepts at most 5 arguments")
+	}
+	args := []formulaArg{newNumberFormulaArg(1), newNumberFormulaArg(1), newNumberFormulaArg(0), newNumberFormulaArg(1), newBoolFormulaArg(false)}
+	for i, arg := 0, argsList.Front(); arg != nil; i, arg = i+1, arg.Next() {
+		if arg.Value.(formulaArg).Value() != "" {
+			args[i] = arg.Value.(formulaArg)
+		}
+	}
+	rows, cols, err := prepareArrayDimension(args[0], args[1])
+	if err.Type == ArgError {
+		return err
+	}
+	minimum, maximum, wholeNumber := args[2].ToNumber(), args[3].ToNumber(), args[4].ToBool()
+	for _, arg := range []formulaArg{minimum, maximum, wholeNumber} {
+		if arg.Type == ArgError {
+			return arg
+		}
+	}
+	if minimum.Number > maximum.Number {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	isWhole := wholeNumber.Number == 1
+	if isWhole && (minimum.Number != math.Trunc(minimum.Number) || maximum.Number != math.Trunc(maximum.Number)) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	span := maximum.Number - minimum.Number
+	if isWhole && !(span >= 0 && span < math.MaxInt64) {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	r := fn.rand()
+	matrix := make([][]formulaArg, rows)
+	for i := range matrix {
+		matrix[i] = make([]formulaArg, cols)
+		for j := range matrix[i] {
+			if isWhole {
+				matrix[i][j] = newNumberFormulaArg(minimum.Number + float64(r.Int63n(int64(span)+1)))
+				continue
+			}
+			matrix[i][j] = newNumberFormulaArg(minimum.Number + r.Float64()*span)
+		}
+	}
+	return newMatrixFormulaArg(matrix)
 }
 
 // RANDBETWEEN function generates a random integer between two supplied
@@ -5161,7 +5704,7 @@ func (fn *formulaFuncs) RANDBETWEEN(argsList *list.List) formulaArg {
 	if top.Number < bottom.Number {
 		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 	}
-	num := rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(int64(top.Number - bottom.Number + 1))
+	num := fn.rand().Int63n(int64(top.Number - bottom.Number + 1))
 	return newNumberFormulaArg(float64(num + int64(bottom.Number)))
 }
 
@@ -5341,11 +5884,17 @@ const (
 // round rounds a supplied number up or down.
 func (fn *formulaFuncs) round(number, digits float64, mode roundMode) float64 {
 	var significance float64
-	if digits > 0 {
+	if digits = math.Trunc(digits); digits > 0 {
 		significance = math.Pow(1/10.0, digits)
 	} else {
 		significance = math.Pow(10.0, -digits)
 	}
+	if math.IsInf(significance, 0) {
+		return 0
+	}
+	if significance == 0 || math.IsInf(number/significance, 0) {
+		return number
+	}
 	val, res := math.Modf(number / significance)
 	switch mode {
 	case closest:
@@ -5453,6 +6002,44 @@ func (fn *formulaFuncs) SECH(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(1 / math.Cosh(number.Number))
 }
 
+// SEQUENCE function generates an array of sequential numbers. The syntax of
+// the function is:
+//
+//	SEQUENCE(rows,[columns],[start],[step])
+func (fn *formulaFuncs) SEQUENCE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SEQUENCE requires at least 1 argument")
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SEQUENCE accepts at most 4 arguments")
+	}
+	args := []formulaArg{newNumberFormulaArg(1), newNumberFormulaArg(1), newNumberFormulaArg(1), newNumberFormulaArg(1)}
+	for i, arg := 0, argsList.Front(); arg != nil; i, arg = i+1, arg.Next() {
+		if arg.Value.(formulaArg).Value() != "" {
+			args[i] = arg.Value.(formulaArg)
+		}
+	}
+	rows, cols, err := prepareArrayDimension(args[0], args[1])
+	if err.Type == ArgError {
+		return err
+	}
+	start, step := args[2].ToNumber(), args[3].ToNumber()
+	if start.Type == ArgError {
+		return start
+	}
+	if step.Type == ArgError {
+		return step
+	}
+	matrix := make([][]formulaArg, rows)
+	for i := range matrix {
+		matrix[i] = make([]formulaArg, cols)
+		for j := range matrix[i] {
+			matrix[i][j] = newNumberFormulaArg(start.Number + float64(i*cols+j)*step.Number)
+		}
+	}
+	return newMatrixFormulaArg(matrix)
+}
+
 // SERIESSUM function returns the sum of a power series. The syntax of the
 // function is:
 //
@@ -5908,14 +6495,15 @@ func (fn *formulaFuncs) SUMIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	sum, sumRange := 0.0, argsList.Front().Value.(formulaArg).Matrix
+	sum, sumRange := 0.0, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
-	for _, ref := range formulaIfsMatch(args) {
-		if ref.Row >= len(sumRange) || ref.Col >= len(sumRange[ref.Row]) {
-			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
-		}
+	cellRefs, err := formulaIfsMatch(sumRange, args)
+	if err.Type == ArgError {
+		return err
+	}
+	for _, ref := range cellRefs {
 		if num := sumRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber {
 			sum += num.Number
 		}
@@ -6269,12 +6857,16 @@ func (fn *formulaFuncs) AVERAGEIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	sum, sumRange := 0.0, argsList.Front().Value.(formulaArg).Matrix
+	sum, sumRange := 0.0, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
+	cellRefs, err := formulaIfsMatch(sumRange, args)
+	if err.Type == ArgError {
+		return err
+	}
 	count := 0.0
-	for _, ref := range formulaIfsMatch(args) {
+	for _, ref := range cellRefs {
 		if num := sumRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber {
 			sum += num.Number
 			count++
@@ -7942,11 +8534,37 @@ func (fn *formulaFuncs) COUNTIF(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(count)
 }
 
-// formulaIfsMatch function returns cells reference array which match criteria.
-func formulaIfsMatch(args []formulaArg) (cellRefs []cellRef) {
+// formulaIfsRange returns the matrix of the range argument for the formula
+// functions with multiple criteria, the single value will be converted to a
+// matrix with one cell.
+func formulaIfsRange(arg formulaArg) [][]formulaArg {
+	if arg.Type == ArgMatrix {
+		return arg.Matrix
+	}
+	return [][]formulaArg{{arg}}
+}
+
+// formulaIfsMatch function returns cells reference array which match all
+// criteria by given value range and criteria arguments. The value range and
+// all criteria ranges should be in the same dimensions, and the value range
+// will not be checked if it is nil.
+func formulaIfsMatch(valueRange [][]formulaArg, args []formulaArg) (cellRefs []cellRef, err formulaArg) {
+	dimensions := func(matrix [][]formulaArg) (rows, cols int) {
+		if rows = len(matrix); rows > 0 {
+			cols = len(matrix[0])
+		}
+		return
+	}
+	rows, cols := dimensions(formulaIfsRange(args[0]))
+	if r, c := dimensions(valueRange); valueRange != nil && (r != rows || c != cols) {
+		return nil, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
 	for i := 0; i < len(args)-1; i += 2 {
+		matrix, criteria := formulaIfsRange(args[i]), formulaCriteriaParser(args[i+1])
+		if r, c := dimensions(matrix); r != rows || c != cols {
+			return nil, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
 		var match []cellRef
-		matrix, criteria := args[i].Matrix, formulaCriteriaParser(args[i+1])
 		if i == 0 {
 			for rowIdx, row := range matrix {
 				for colIdx, col := range row {
@@ -7958,15 +8576,14 @@ func formulaIfsMatch(args []formulaArg) (cellRefs []cellRef) {
 		} else {
 			match = []cellRef{}
 			for _, ref := range cellRefs {
-				value := matrix[ref.Row][ref.Col]
-				if ok, _ := formulaCriteriaEval(value, criteria); ok {
+				if ok, _ := formulaCriteriaEval(matrix[ref.Row][ref.Col], criteria); ok {
 					match = append(match, ref)
 				}
 			}
 		}
 		cellRefs = match[:]
 	}
-	return
+	return cellRefs, newEmptyFormulaArg()
 }
 
 // COUNTIFS function returns the number of rows within a table, that satisfy a
@@ -7984,7 +8601,11 @@ func (fn *formulaFuncs) COUNTIFS(argsList *list.List) formulaArg {
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
-	return newNumberFormulaArg(float64(len(formulaIfsMatch(args))))
+	cellRefs, err := formulaIfsMatch(nil, args)
+	if err.Type == ArgError {
+		return err
+	}
+	return newNumberFormulaArg(float64(len(cellRefs)))
 }
 
 // CRITBINOM function returns the inverse of the Cumulative Binomial
@@ -9507,17 +10128,265 @@ func (fn *formulaFuncs) FTEST(argsList *list.List) formulaArg {
 	return fn.FdotTEST(argsList)
 }
 
-// LOGINV function calculates the inverse of the Cumulative Log-Normal
-// Distribution Function of x, for a supplied probability. The syntax of the
+// LINEST function calculates the statistics for a straight line that best fits
+// the supplied data by using the least squares method, and returns an array
+// that describes the line. The syntax of the function is:
+//
+//	LINEST(known_y's,[known_x's],[const],[stats])
+func (fn *formulaFuncs) LINEST(argsList *list.List) formulaArg {
+	return fn.linest("LINEST", argsList)
+}
+
+// LOGEST function calculates an exponential curve that fits the supplied data
+// by using the least squares method on the natural logarithms of the y-values,
+// and returns an array of values that describes the curve. The syntax of the
 // function is:
 //
-//	LOGINV(probability,mean,standard_dev)
-func (fn *formulaFuncs) LOGINV(argsList *list.List) formulaArg {
-	if argsList.Len() != 3 {
-		return newErrorFormulaArg(formulaErrorVALUE, "LOGINV requires 3 arguments")
-	}
-	var probability, mean, stdDev formulaArg
-	if probability = argsList.Front().Value.(formulaArg).ToNumber(); probability.Type != ArgNumber {
+//	LOGEST(known_y's,[known_x's],[const],[stats])
+func (fn *formulaFuncs) LOGEST(argsList *list.List) formulaArg {
+	return fn.linest("LOGEST", argsList)
+}
+
+// prepareLinestData returns the observations of the independent variables and
+// the dependent variable by given known y's and known x's for the formula
+// functions LINEST and LOGEST.
+func prepareLinestData(knownY, knownX [][]float64) ([][]float64, []float64, bool) {
+	rows, cols := len(knownY), len(knownY[0])
+	var obsX [][]float64
+	var obsY []float64
+	for r := 0; r < rows; r++ {
+		obsY = append(obsY, knownY[r]...)
+	}
+	if knownX == nil {
+		for i := range obsY {
+			obsX = append(obsX, []float64{float64(i + 1)})
+		}
+		return obsX, obsY, true
+	}
+	xRows, xCols := len(knownX), len(knownX[0])
+	switch {
+	case cols == 1 && xRows == rows:
+		return knownX, obsY, true
+	case rows == 1 && xCols == cols:
+		for c := 0; c < cols; c++ {
+			var obs []float64
+			for r := 0; r < xRows; r++ {
+				obs = append(obs, knownX[r][c])
+			}
+			obsX = append(obsX, obs)
+		}
+		return obsX, obsY, true
+	case xRows == rows && xCols == cols:
+		for r := 0; r < xRows; r++ {
+			for c := 0; c < xCols; c++ {
+				obsX = append(obsX, []float64{knownX[r][c]})
+			}
+		}
+		return obsX, obsY, true
+	}
+	return nil, nil, false
+}
+
+// linestInverse returns the inverse of the given square matrix by Gauss-Jordan
+// elimination with partial pivoting, the second return value will be false if
+// the matrix is singular.
+func linestInverse(mtx [][]float64) ([][]float64, bool) {
+	n := len(mtx)
+	aug := make([][]float64, n)
+	for i := range mtx {
+		aug[i] = make([]float64, 2*n)
+		copy(aug[i], mtx[i])
+		aug[i][n+i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(aug[r][col]) > math.Abs(aug[pivot][col]) {
+				pivot = r
+			}
+		}
+		if aug[pivot][col] == 0 {
+			return nil, false
+		}
+		aug[col], aug[pivot] = aug[pivot], aug[col]
+		for c, div := 0, aug[col][col]; c < 2*n; c++ {
+			aug[col][c] /= div
+		}
+		for r := 0; r < n; r++ {
+			if factor := aug[r][col]; r != col && factor != 0 {
+				for c := 0; c < 2*n; c++ {
+					aug[r][c] -= factor * aug[col][c]
+				}
+			}
+		}
+	}
+	inv := make([][]float64, n)
+	for i := range aug {
+		inv[i] = aug[i][n:]
+	}
+	return inv, true
+}
+
+// linestStats returns the regression statistics array by given observations,
+// the independent variables and the dependent variable for the formula
+// functions LINEST and LOGEST.
+func linestStats(obsX [][]float64, obsY []float64, constant, stats, logest bool) formulaArg {
+	n, k := len(obsY), len(obsX[0])
+	df := n - k
+	if constant {
+		df--
+	}
+	if df < 0 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	meanX, meanY := make([]float64, k), 0.0
+	if constant {
+		for t := 0; t < n; t++ {
+			meanY += obsY[t] / float64(n)
+			for j := 0; j < k; j++ {
+				meanX[j] += obsX[t][j] / float64(n)
+			}
+		}
+	}
+	xtx, xty := getNewMatrix(k, k), make([]float64, k)
+	for t := 0; t < n; t++ {
+		for i := 0; i < k; i++ {
+			xty[i] += (obsX[t][i] - meanX[i]) * (obsY[t] - meanY)
+			for j := 0; j < k; j++ {
+				xtx[i][j] += (obsX[t][i] - meanX[i]) * (obsX[t][j] - meanX[j])
+			}
+		}
+	}
+	inv, ok := linestInverse(xtx)
+	if !ok {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
+	coef, intercept := make([]float64, k), meanY
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			coef[i] += inv[i][j] * xty[j]
+		}
+		intercept -= meanX[i] * coef[i]
+	}
+	var ssReg, ssResid float64
+	for t := 0; t < n; t++ {
+		estimate := intercept
+		for j := 0; j < k; j++ {
+			estimate += coef[j] * obsX[t][j]
+		}
+		ssReg += (estimate - meanY) * (estimate - meanY)
+		ssResid += (obsY[t] - estimate) * (obsY[t] - estimate)
+	}
+	result, naArg := make([][]formulaArg, 1), newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	for j := k - 1; j >= 0; j-- {
+		if logest {
+			result[0] = append(result[0], newNumberFormulaArg(math.Exp(coef[j])))
+			continue
+		}
+		result[0] = append(result[0], newNumberFormulaArg(coef[j]))
+	}
+	if logest {
+		intercept = math.Exp(intercept)
+	}
+	result[0] = append(result[0], newNumberFormulaArg(intercept))
+	if !stats {
+		return newMatrixFormulaArg(result)
+	}
+	numArg := func(num float64, valid bool) formulaArg {
+		if !valid || math.IsNaN(num) || math.IsInf(num, 0) {
+			return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+		}
+		return newNumberFormulaArg(num)
+	}
+	for i := 1; i < 5; i++ {
+		result = append(result, make([]formulaArg, k+1))
+		for j := range result[i] {
+			result[i][j] = naArg
+		}
+	}
+	varY := ssResid / float64(df)
+	for j := 0; j < k; j++ {
+		result[1][k-1-j] = numArg(math.Sqrt(varY*inv[j][j]), df > 0)
+	}
+	if constant {
+		seIntercept := 1 / float64(n)
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				seIntercept += meanX[i] * inv[i][j] * meanX[j]
+			}
+		}
+		result[1][k] = numArg(math.Sqrt(varY*seIntercept), df > 0)
+	}
+	result[2][0] = numArg(ssReg/(ssReg+ssResid), ssReg+ssResid != 0)
+	result[2][1] = numArg(math.Sqrt(varY), df > 0)
+	result[3][0] = numArg(ssReg/float64(k)/varY, df > 0 && ssResid != 0)
+	result[3][1] = newNumberFormulaArg(float64(df))
+	result[4][0], result[4][1] = newNumberFormulaArg(ssReg), newNumberFormulaArg(ssResid)
+	return newMatrixFormulaArg(result)
+}
+
+// linest is an implementation of the formula functions LINEST and LOGEST.
+func (fn *formulaFuncs) linest(name string, argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 1 argument", name))
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s allows at most 4 arguments", name))
+	}
+	knownY, errArg := newNumberMatrix(newMatrixFormulaArg(lambdaHelperArray(argsList.Front().Value.(formulaArg))), false)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var knownX [][]float64
+	if argsList.Len() > 1 {
+		if arg := argsList.Front().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+			if knownX, errArg = newNumberMatrix(newMatrixFormulaArg(lambdaHelperArray(arg)), false); errArg.Type == ArgError {
+				return errArg
+			}
+		}
+	}
+	constant, stats := true, false
+	if argsList.Len() > 2 {
+		if arg := argsList.Front().Next().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+			if arg = arg.ToBool(); arg.Type != ArgNumber {
+				return arg
+			}
+			constant = arg.Number == 1
+		}
+	}
+	if argsList.Len() > 3 {
+		arg := argsList.Back().Value.(formulaArg).ToBool()
+		if arg.Type != ArgNumber {
+			return arg
+		}
+		stats = arg.Number == 1
+	}
+	obsX, obsY, ok := prepareLinestData(knownY, knownX)
+	if !ok {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	if name == "LOGEST" {
+		for t := range obsY {
+			if obsY[t] <= 0 {
+				return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+			}
+			obsY[t] = math.Log(obsY[t])
+		}
+	}
+	return linestStats(obsX, obsY, constant, stats, name == "LOGEST")
+}
+
+// LOGINV function calculates the inverse of the Cumulative Log-Normal
+// Distribution Function of x, for a supplied probability. The syntax of the
+// function is:
+//
+//	LOGINV(probability,mean,standard_dev)
+func (fn *formulaFuncs) LOGINV(argsList *list.List) formulaArg {
+	if argsList.Len() != 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "LOGINV requires 3 arguments")
+	}
+	var probability, mean, stdDev formulaArg
+	if probability = argsList.Front().Value.(formulaArg).ToNumber(); probability.Type != ArgNumber {
 		return probability
 	}
 	if mean = argsList.Front().Next().Value.(formulaArg).ToNumber(); mean.Type != ArgNumber {
@@ -10049,11 +10918,15 @@ func (fn *formulaFuncs) MAXIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	maxVal, maxRange := -math.MaxFloat64, argsList.Front().Value.(formulaArg).Matrix
+	maxVal, maxRange := -math.MaxFloat64, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
-	for _, ref := range formulaIfsMatch(args) {
+	cellRefs, err := formulaIfsMatch(maxRange, args)
+	if err.Type == ArgError {
+		return err
+	}
+	for _, ref := range cellRefs {
 		if num := maxRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber && maxVal < num.Number {
 			maxVal = num.Number
 		}
@@ -10192,11 +11065,15 @@ func (fn *formulaFuncs) MINIFS(argsList *list.List) formulaArg {
 		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
 	var args []formulaArg
-	minVal, minRange := math.MaxFloat64, argsList.Front().Value.(formulaArg).Matrix
+	minVal, minRange := math.MaxFloat64, formulaIfsRange(argsList.Front().Value.(formulaArg))
 	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
 		args = append(args, arg.Value.(formulaArg))
 	}
-	for _, ref := range formulaIfsMatch(args) {
+	cellRefs, err := formulaIfsMatch(minRange, args)
+	if err.Type == ArgError {
+		return err
+	}
+	for _, ref := range cellRefs {
 		if num := minRange[ref.Row][ref.Col].ToNumber(); num.Type == ArgNumber && minVal > num.Number {
 			minVal = num.Number
 		}
@@ -10347,7 +11224,13 @@ func (fn *formulaFuncs) PERCENTILEdotEXC(argsList *list.List) formulaArg {
 	cnt := len(numbers)
 	sort.Float64s(numbers)
 	idx := k.Number * (float64(cnt) + 1)
+	if idx < 1 || idx > float64(cnt) {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	base := math.Floor(idx)
+	if idx == base {
+		return newNumberFormulaArg(numbers[int(base)-1])
+	}
 	next := base - 1
 	proportion := math.Nextafter(idx, idx) - base
 	return newNumberFormulaArg(numbers[int(next)] + ((numbers[int(base)] - numbers[int(next)]) * proportion))
@@ -10392,6 +11275,9 @@ func (fn *formulaFuncs) PERCENTILE(argsList *list.List) formulaArg {
 		}
 	}
 	cnt := len(numbers)
+	if cnt == 0 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	sort.Float64s(numbers)
 	idx := k.Number * (float64(cnt) - 1)
 	base := math.Floor(idx)
@@ -11407,6 +12293,127 @@ func (fn *formulaFuncs) ZTEST(argsList *list.List) formulaArg {
 
 // Information Functions
 
+// cellFormatCodes defined the text values corresponding to the built-in number
+// format for the formula function CELL.
+var cellFormatCodes = map[int]string{
+	0: "G", 1: "F0", 2: "F2", 3: ",0", 4: ",2", 5: "C0", 6: "C0-", 7: "C2",
+	8: "C2-", 9: "P0", 10: "P2", 11: "S2", 12: "G", 13: "G", 14: "D4", 15: "D1",
+	16: "D2", 17: "D3", 18: "D7", 19: "D6", 20: "D9", 21: "D8", 22: "D4",
+	37: ",0", 38: ",0-", 39: ",2", 40: ",2-", 45: "D9", 46: "D8", 47: "D9",
+	48: "S2", 49: "G",
+}
+
+// cellXf returns the cell formatting record of the given cell for the formula
+// function CELL.
+func (fn *formulaFuncs) cellXf(sheet, cell string) (xlsxXf, error) {
+	var xf xlsxXf
+	styleIdx, err := fn.f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return xf, err
+	}
+	fn.f.mu.Lock()
+	defer fn.f.mu.Unlock()
+	s, err := fn.f.stylesReader()
+	if err != nil {
+		return xf, err
+	}
+	if s.CellXfs != nil && styleIdx < len(s.CellXfs.Xf) {
+		xf = s.CellXfs.Xf[styleIdx]
+	}
+	return xf, err
+}
+
+// CELL function returns information about the formatting, location, or
+// contents of a cell. The info_type argument could be one of "address",
+// "col", "color", "contents", "filename", "format", "protect", "row", "type"
+// and "width". The syntax of the function is:
+//
+//	CELL(info_type,[reference])
+func (fn *formulaFuncs) CELL(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "CELL requires at least 1 argument")
+	}
+	if argsList.Len() > 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "CELL allows at most 2 arguments")
+	}
+	infoType := argsList.Front().Value.(formulaArg)
+	if infoType.Type == ArgError {
+		return infoType
+	}
+	sheet, ref := fn.sheet, cellRef{}
+	ref.Col, ref.Row, _ = CellNameToCoordinates(fn.cell)
+	if argsList.Len() == 2 {
+		arg := argsList.Back().Value.(formulaArg)
+		if arg.cellRanges != nil && arg.cellRanges.Len() > 0 {
+			ref = arg.cellRanges.Front().Value.(cellRange).From
+		} else if arg.cellRefs != nil && arg.cellRefs.Len() > 0 {
+			ref = arg.cellRefs.Front().Value.(cellRef)
+		} else {
+			return newErrorFormulaArg(formulaErrorVALUE, "invalid reference")
+		}
+		if ref.Sheet != "" {
+			sheet = ref.Sheet
+		}
+	}
+	cell, _ := CoordinatesToCellName(ref.Col, ref.Row)
+	switch strings.ToLower(infoType.Value()) {
+	case "address":
+		addr, _ := CoordinatesToCellName(ref.Col, ref.Row, true)
+		if sheet != fn.sheet {
+			addr = escapeSheetName(sheet) + "!" + addr
+		}
+		return newStringFormulaArg(addr)
+	case "col":
+		return newNumberFormulaArg(float64(ref.Col))
+	case "row":
+		return newNumberFormulaArg(float64(ref.Row))
+	case "filename":
+		if fn.f.Path == "" {
+			return newStringFormulaArg("")
+		}
+		return newStringFormulaArg(filepath.Join(filepath.Dir(fn.f.Path), "["+filepath.Base(fn.f.Path)+"]"+sheet))
+	case "contents", "type":
+		arg, _ := fn.f.cellResolver(fn.ctx, sheet, cell)
+		if strings.EqualFold(infoType.Value(), "contents") {
+			return arg
+		}
+		switch arg.Type {
+		case ArgEmpty:
+			return newStringFormulaArg("b")
+		case ArgString:
+			return newStringFormulaArg("l")
+		default:
+			return newStringFormulaArg("v")
+		}
+	case "width":
+		return newNumberFormulaArg(math.Trunc((float64(fn.f.getColWidth(sheet, ref.Col)) - 5) / 7))
+	}
+	xf, err := fn.cellXf(sheet, cell)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	switch strings.ToLower(infoType.Value()) {
+	case "color":
+		if xf.NumFmtID != nil && strings.HasSuffix(cellFormatCodes[*xf.NumFmtID], "-") {
+			return newNumberFormulaArg(1)
+		}
+		return newNumberFormulaArg(0)
+	case "format":
+		if xf.NumFmtID != nil {
+			if code, ok := cellFormatCodes[*xf.NumFmtID]; ok {
+				return newStringFormulaArg(code)
+			}
+		}
+		return newStringFormulaArg("G")
+	case "protect":
+		if xf.Protection != nil && xf.Protection.Locked != nil && !*xf.Protection.Locked {
+			return newNumberFormulaArg(0)
+		}
+		return newNumberFormulaArg(1)
+	}
+	return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+}
+
 // ERRORdotTYPE function receives an error value and returns an integer, that
 // tells you the type of the supplied error. The syntax of the function is:
 //
@@ -11534,13 +12541,8 @@ func (fn *formulaFuncs) ISFORMULA(argsList *list.List) formulaArg {
 	if argsList.Len() != 1 {
 		return newErrorFormulaArg(formulaErrorVALUE, "ISFORMULA requires 1 argument")
 	}
-	arg := argsList.Front().Value.(formulaArg)
-	if arg.cellRefs != nil && arg.cellRefs.Len() == 1 {
-		ref := arg.cellRefs.Front().Value.(cellRef)
-		cell, _ := CoordinatesToCellName(ref.Col, ref.Row)
-		if formula, _ := fn.f.GetCellFormula(ref.Sheet, cell); len(formula) > 0 {
-			return newBoolFormulaArg(true)
-		}
+	if formula, _ := fn.getCellFormulaByRef(argsList.Front().Value.(formulaArg)); len(formula) > 0 {
+		return newBoolFormulaArg(true)
 	}
 	return newBoolFormulaArg(false)
 }
@@ -12118,7 +13120,10 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 }
 
 // DATEDIF function calculates the number of days, months, or years between
-// two dates. The syntax of the function is:
+// two dates. The time parts of the dates are ignored, and the "MD" unit may
+// result in a negative number when the day of the start date is greater than
+// the number of days in the month before the end date, as Excel does. The
+// syntax of the function is:
 //
 //	DATEDIF(start_date,end_date,unit)
 func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
@@ -12129,6 +13134,8 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 	if startArg.Type != ArgNumber || endArg.Type != ArgNumber {
 		return startArg
 	}
+	// The time parts of the dates are ignored
+	startArg.Number, endArg.Number = math.Trunc(startArg.Number), math.Trunc(endArg.Number)
 	if startArg.Number > endArg.Number {
 		return newErrorFormulaArg(formulaErrorNUM, "start_date > end_date")
 	}
@@ -13489,6 +14496,24 @@ func prepareToText(name string, argsList *list.List) formulaArg {
 	return format
 }
 
+// valueToText converts the formula argument to text for the formula functions
+// ARRAYTOTEXT and VALUETOTEXT. In the strict format, the text values will be
+// enclosed in double quotes with the inner double quotes escaped, and the
+// numbers, logical values, errors and empty values will not be quoted.
+func valueToText(arg formulaArg, strict bool) string {
+	val := arg.Value()
+	if !strict || arg.Type != ArgString || val == "" {
+		return val
+	}
+	if num := arg.ToNumber(); num.Type == ArgNumber {
+		return val
+	}
+	if upper := strings.ToUpper(val); upper == "TRUE" || upper == "FALSE" {
+		return upper
+	}
+	return fmt.Sprintf("\"%s\"", strings.ReplaceAll(val, "\"", "\"\""))
+}
+
 // ARRAYTOTEXT function returns an array of text values from any specified
 // range. It passes text values unchanged, and converts non-text values to
 // text. The syntax of the function is:
@@ -13500,14 +14525,10 @@ func (fn *formulaFuncs) ARRAYTOTEXT(argsList *list.List) formulaArg {
 	if format.Type != ArgNumber {
 		return format
 	}
-	for _, rows := range argsList.Front().Value.(formulaArg).Matrix {
+	for _, rows := range lambdaHelperArray(argsList.Front().Value.(formulaArg)) {
 		var row []string
 		for _, cell := range rows {
-			if num := cell.ToNumber(); num.Type != ArgNumber && format.Number == 1 {
-				row = append(row, fmt.Sprintf("\"%s\"", cell.Value()))
-				continue
-			}
-			row = append(row, cell.Value())
+			row = append(row, valueToText(cell, format.Number == 1))
 		}
 		mtx = append(mtx, row)
 	}
@@ -13590,7 +14611,7 @@ func (fn *formulaFuncs) code(name string, argsList *list.List) formulaArg {
 //
 //	CONCAT(text1,[text2],...)
 func (fn *formulaFuncs) CONCAT(argsList *list.List) formulaArg {
-	return fn.concat(argsList)
+	return fn.concat("CONCAT", argsList)
 }
 
 // CONCATENATE function joins together a series of supplied text strings into
@@ -13598,12 +14619,16 @@ func (fn *formulaFuncs) CONCAT(argsList *list.List) formulaArg {
 //
 //	CONCATENATE(text1,[text2],...)
 func (fn *formulaFuncs) CONCATENATE(argsList *list.List) formulaArg {
-	return fn.concat(argsList)
+	return fn.concat("CONCATENATE", argsList)
 }
 
 // concat is an implementation of the formula functions CONCAT and
-// CONCATENATE.
-func (fn *formulaFuncs) concat(argsList *list.List) formulaArg {
+// CONCATENATE, the cells of the range arguments will be joined in row-major
+// order, and the empty cells contribute empty strings.
+func (fn *formulaFuncs) concat(name string, argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 1 argument", name))
+	}
 	var buf bytes.Buffer
 	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
 		for _, cell := range arg.Value.(formulaArg).ToList() {
@@ -13613,6 +14638,9 @@ func (fn *formulaFuncs) concat(argsList *list.List) formulaArg {
 			buf.WriteString(cell.Value())
 		}
 	}
+	if utf8.RuneCount(buf.Bytes()) > TotalCellChars {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s function exceeds %d characters", name, TotalCellChars))
+	}
 	return newStringFormulaArg(buf.String())
 }
 
@@ -14175,7 +15203,7 @@ func (fn *formulaFuncs) TEXT(argsList *list.List) formulaArg {
 	if num := value.ToNumber(); num.Type != ArgNumber {
 		cellType = CellTypeSharedString
 	}
-	return newStringFormulaArg(format(value.Value(), fmtText.Value(), false, cellType, nil))
+	return newStringFormulaArg(format(value.Value(), fmtText.Value(), false, cellType, fn.f.options))
 }
 
 // prepareTextAfterBefore checking and prepare arguments for the formula
@@ -14392,6 +15420,136 @@ func textJoin(arg *list.Element, arr []string, ignoreEmpty bool) ([]string, form
 	return arr, newBoolFormulaArg(true)
 }
 
+// textSplitDelimiters returns the delimiters of the formula function TEXTSPLIT
+// by given delimiter argument, the empty delimiters will be skipped.
+func textSplitDelimiters(arg formulaArg) []string {
+	var delimiters []string
+	args := []formulaArg{arg}
+	if arg.Type == ArgMatrix {
+		args = arg.ToList()
+	}
+	for _, d := range args {
+		if val := d.Value(); val != "" {
+			delimiters = append(delimiters, val)
+		}
+	}
+	return delimiters
+}
+
+// textSplit is an implementation of the formula function TEXTSPLIT, splitting
+// the text by the earliest and longest matched delimiters.
+func textSplit(text string, delimiters []string, ignoreEmpty, ignoreCase bool) []string {
+	var result []string
+	appendResult := func(val string) {
+		if val != "" || !ignoreEmpty {
+			result = append(result, val)
+		}
+	}
+	start := 0
+	for i := 0; i < len(text); {
+		matched := 0
+		for _, d := range delimiters {
+			if len(d) <= matched || len(text)-i < len(d) {
+				continue
+			}
+			if sub := text[i : i+len(d)]; sub == d || (ignoreCase && strings.EqualFold(sub, d)) {
+				matched = len(d)
+			}
+		}
+		if matched == 0 {
+			i++
+			continue
+		}
+		appendResult(text[start:i])
+		i += matched
+		start = i
+	}
+	appendResult(text[start:])
+	return result
+}
+
+// TEXTSPLIT function splits the text into rows and columns by the given column
+// and row delimiters, and returns the result as an array. The syntax of the
+// function is:
+//
+//	TEXTSPLIT(text,col_delimiter,[row_delimiter],[ignore_empty],[match_mode],[pad_with])
+func (fn *formulaFuncs) TEXTSPLIT(argsList *list.List) formulaArg {
+	argsLen := argsList.Len()
+	if argsLen < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT requires at least 2 arguments")
+	}
+	if argsLen > 6 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT accepts at most 6 arguments")
+	}
+	text := argsList.Front().Value.(formulaArg)
+	if text.Type == ArgError {
+		return text
+	}
+	colDelimiters := textSplitDelimiters(argsList.Front().Next().Value.(formulaArg))
+	var rowDelimiters []string
+	ignoreEmpty, matchMode, padWith := newBoolFormulaArg(false), newNumberFormulaArg(0), newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	if argsLen > 2 {
+		rowDelimiters = textSplitDelimiters(argsList.Front().Next().Next().Value.(formulaArg))
+	}
+	if argsLen > 3 {
+		if arg := argsList.Front().Next().Next().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+			if ignoreEmpty = arg.ToBool(); ignoreEmpty.Type != ArgNumber {
+				return ignoreEmpty
+			}
+		}
+	}
+	if argsLen > 4 {
+		if arg := argsList.Front().Next().Next().Next().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+			if matchMode = arg.ToNumber(); matchMode.Type != ArgNumber {
+				return matchMode
+			}
+		}
+		if matchMode.Number != 0 && matchMode.Number != 1 {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+	}
+	if argsLen > 5 {
+		if arg := argsList.Back().Value.(formulaArg); arg.Type != ArgEmpty {
+			padWith = arg
+		}
+	}
+	if len(colDelimiters) == 0 && len(rowDelimiters) == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var (
+		ignore, ignoreCase = ignoreEmpty.Number == 1, matchMode.Number == 1
+		rows               = []string{text.Value()}
+		cols               int
+		mtx                [][]formulaArg
+	)
+	if len(rowDelimiters) > 0 {
+		rows = textSplit(text.Value(), rowDelimiters, ignore, ignoreCase)
+	}
+	for _, row := range rows {
+		cells := []string{row}
+		if len(colDelimiters) > 0 {
+			cells = textSplit(row, colDelimiters, ignore, ignoreCase)
+		}
+		var args []formulaArg
+		for _, cell := range cells {
+			args = append(args, newStringFormulaArg(cell))
+		}
+		if len(args) > cols {
+			cols = len(args)
+		}
+		mtx = append(mtx, args)
+	}
+	if cols == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	for i := range mtx {
+		for len(mtx[i]) < cols {
+			mtx[i] = append(mtx[i], padWith)
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
 // TRIM removes extra spaces (i.e. all spaces except for single spaces between
 // words or characters) from a supplied text string. The syntax of the
 // function is:
@@ -14487,11 +15645,7 @@ func (fn *formulaFuncs) VALUETOTEXT(argsList *list.List) formulaArg {
 	if format.Type != ArgNumber {
 		return format
 	}
-	cell := argsList.Front().Value.(formulaArg)
-	if num := cell.ToNumber(); num.Type != ArgNumber && format.Number == 1 {
-		return newStringFormulaArg(fmt.Sprintf("\"%s\"", cell.Value()))
-	}
-	return newStringFormulaArg(cell.Value())
+	return newStringFormulaArg(valueToText(argsList.Front().Value.(formulaArg), format.Number == 1))
 }
 
 // Conditional Functions
@@ -14643,37 +15797,209 @@ func (fn *formulaFuncs) ANCHORARRAY(argsList *list.List) formulaArg {
 	return newMatrixFormulaArg(mtx)
 }
 
-// CHOOSE function returns a value from an array, that corresponds to a
-// supplied index number (position). The syntax of the function is:
+// BYCOL function applies a LAMBDA function to each column of the array and
+// returns a row array of the results. The syntax of the function is:
 //
-//	CHOOSE(index_num,value1,[value2],...)
-func (fn *formulaFuncs) CHOOSE(argsList *list.List) formulaArg {
-	if argsList.Len() < 2 {
-		return newErrorFormulaArg(formulaErrorVALUE, "CHOOSE requires 2 arguments")
-	}
-	idx, err := strconv.Atoi(argsList.Front().Value.(formulaArg).Value())
-	if err != nil {
-		return newErrorFormulaArg(formulaErrorVALUE, "CHOOSE requires first argument of type number")
+//	BYCOL(array,lambda(column))
+func (fn *formulaFuncs) BYCOL(argsList *list.List) formulaArg {
+	return fn.byRowOrCol("BYCOL", argsList, false)
+}
+
+// BYROW function applies a LAMBDA function to each row of the array and
+// returns a column array of the results. The syntax of the function is:
+//
+//	BYROW(array,lambda(row))
+func (fn *formulaFuncs) BYROW(argsList *list.List) formulaArg {
+	return fn.byRowOrCol("BYROW", argsList, true)
+}
+
+// byRowOrCol is an implementation of the formula functions BYCOL and BYROW,
+// the rows or columns of the cell range will be passed by reference to the
+// LAMBDA function, and the rows or columns of the other arrays will be passed
+// as the array constant.
+func (fn *formulaFuncs) byRowOrCol(name string, argsList *list.List, byRow bool) formulaArg {
+	if argsList.Len() != 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires 2 arguments", name))
 	}
-	if argsList.Len() <= idx {
-		return newErrorFormulaArg(formulaErrorVALUE, "index_num should be <= to the number of values")
+	array, lambda := argsList.Front().Value.(formulaArg), argsList.Back().Value.(formulaArg)
+	if array.Type == ArgError {
+		return array
 	}
-	arg := argsList.Front()
-	for i := 0; i < idx; i++ {
-		arg = arg.Next()
+	if lambda.Type == ArgError {
+		return lambda
 	}
-	return arg.Value.(formulaArg)
-}
-
-// matchPatternToRegExp convert find text pattern to regular expression.
-func matchPatternToRegExp(findText string, dbcs bool) (string, bool) {
-	var (
-		exp      string
-		wildCard bool
-		mark     = "."
-	)
-	if dbcs {
-		mark = "(?:(?:[\\x00-\\x0081])|(?:[\\xFF61-\\xFFA0])|(?:[\\xF8F1-\\xF8F4])|[0-9A-Za-z])"
+	if lambda.lambda == nil || len(lambda.lambda.params) != 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var results []formulaArg
+	if array.cellRanges != nil && array.cellRanges.Len() == 1 {
+		cr := array.cellRanges.Front().Value.(cellRange)
+		coordinates := []int{cr.From.Col, cr.From.Row, cr.To.Col, cr.To.Row}
+		_ = sortCoordinates(coordinates)
+		for i := coordinates[1]; byRow && i <= coordinates[3]; i++ {
+			results = append(results, fn.callLambdaWithArgs(lambda.lambda, newCellRangeFormulaArg(cellRange{
+				From: cellRef{Col: coordinates[0], Row: i, Sheet: cr.From.Sheet},
+				To:   cellRef{Col: coordinates[2], Row: i, Sheet: cr.From.Sheet},
+			})))
+		}
+		for i := coordinates[0]; !byRow && i <= coordinates[2]; i++ {
+			results = append(results, fn.callLambdaWithArgs(lambda.lambda, newCellRangeFormulaArg(cellRange{
+				From: cellRef{Col: i, Row: coordinates[1], Sheet: cr.From.Sheet},
+				To:   cellRef{Col: i, Row: coordinates[3], Sheet: cr.From.Sheet},
+			})))
+		}
+	} else {
+		values := lambdaHelperArray(array)
+		for r := 0; byRow && r < len(values); r++ {
+			results = append(results, fn.callLambdaWithArgs(lambda.lambda, newMatrixFormulaArg([][]formulaArg{values[r]})))
+		}
+		for c := 0; !byRow && c < len(values[0]); c++ {
+			var column [][]formulaArg
+			for r := range values {
+				column = append(column, []formulaArg{values[r][c]})
+			}
+			results = append(results, fn.callLambdaWithArgs(lambda.lambda, newMatrixFormulaArg(column)))
+		}
+	}
+	var mtx [][]formulaArg
+	for _, result := range results {
+		if result.Type == ArgError {
+			return result
+		}
+		if byRow {
+			mtx = append(mtx, []formulaArg{result})
+			continue
+		}
+		if len(mtx) == 0 {
+			mtx = append(mtx, []formulaArg{})
+		}
+		mtx[0] = append(mtx[0], result)
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// newCellRangeFormulaArg create a formula argument which refers to the given
+// cell range, the argument will be passed by reference to the LAMBDA
+// function.
+func newCellRangeFormulaArg(cr cellRange) formulaArg {
+	arg := formulaArg{cellRanges: list.New()}
+	arg.cellRanges.PushBack(cr)
+	return arg
+}
+
+// callLambdaWithArgs evaluate the calculation of the LAMBDA function with the
+// given arguments, returns #VALUE! error if the result is not a single value.
+func (fn *formulaFuncs) callLambdaWithArgs(lambda *formulaLambda, arg ...formulaArg) formulaArg {
+	args := list.New()
+	for _, a := range arg {
+		args.PushBack(a)
+	}
+	result := fn.callLambda(lambda.params, lambda.body, args)
+	if result.Type == ArgMatrix {
+		if len(result.Matrix) != 1 || len(result.Matrix[0]) != 1 {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return result.Matrix[0][0]
+	}
+	if result.Type == ArgList {
+		if len(result.List) != 1 {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return result.List[0]
+	}
+	return result
+}
+
+// CHOOSE function returns a value from an array, that corresponds to a
+// supplied index number (position). The syntax of the function is:
+//
+//	CHOOSE(index_num,value1,[value2],...)
+func (fn *formulaFuncs) CHOOSE(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "CHOOSE requires 2 arguments")
+	}
+	idx, err := strconv.Atoi(argsList.Front().Value.(formulaArg).Value())
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorVALUE, "CHOOSE requires first argument of type number")
+	}
+	if argsList.Len() <= idx {
+		return newErrorFormulaArg(formulaErrorVALUE, "index_num should be <= to the number of values")
+	}
+	arg := argsList.Front()
+	for i := 0; i < idx; i++ {
+		arg = arg.Next()
+	}
+	return arg.Value.(formulaArg)
+}
+
+// CHOOSECOLS function returns the specified columns from an array. The syntax
+// of the function is:
+//
+//	CHOOSECOLS(array,col_num1,[col_num2],...)
+func (fn *formulaFuncs) CHOOSECOLS(argsList *list.List) formulaArg {
+	return fn.chooseColsOrRows("CHOOSECOLS", true, argsList)
+}
+
+// CHOOSEROWS function returns the specified rows from an array. The syntax of
+// the function is:
+//
+//	CHOOSEROWS(array,row_num1,[row_num2],...)
+func (fn *formulaFuncs) CHOOSEROWS(argsList *list.List) formulaArg {
+	return fn.chooseColsOrRows("CHOOSEROWS", false, argsList)
+}
+
+// chooseColsOrRows is an implementation of the formula functions CHOOSECOLS
+// and CHOOSEROWS.
+func (fn *formulaFuncs) chooseColsOrRows(name string, cols bool, argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 2 arguments", name))
+	}
+	mtx := lambdaHelperArray(argsList.Front().Value.(formulaArg))
+	size := len(mtx)
+	if cols {
+		size = len(mtx[0])
+	}
+	var indexes []int
+	for arg := argsList.Front().Next(); arg != nil; arg = arg.Next() {
+		num := arg.Value.(formulaArg).ToNumber()
+		if num.Type != ArgNumber {
+			return num
+		}
+		idx := int(num.Number)
+		if idx < 0 {
+			idx += size + 1
+		}
+		if idx < 1 || idx > size {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		indexes = append(indexes, idx-1)
+	}
+	var result [][]formulaArg
+	if cols {
+		for _, row := range mtx {
+			var cells []formulaArg
+			for _, idx := range indexes {
+				cells = append(cells, row[idx])
+			}
+			result = append(result, cells)
+		}
+		return newMatrixFormulaArg(result)
+	}
+	for _, idx := range indexes {
+		result = append(result, append([]formulaArg{}, mtx[idx]...))
+	}
+	return newMatrixFormulaArg(result)
+}
+
+// matchPatternToRegExp convert find text pattern to regular expression.
+func matchPatternToRegExp(findText string, dbcs bool) (string, bool) {
+	var (
+		exp      string
+		wildCard bool
+		mark     = "."
+	)
+	if dbcs {
+		mark = "(?:(?:[\\x00-\\x0081])|(?:[\\xFF61-\\xFFA0])|(?:[\\xF8F1-\\xF8F4])|[0-9A-Za-z])"
 	}
 	for _, char := range findText {
 		if strings.ContainsAny(string(char), ".+$^[](){}|/") {
@@ -14878,31 +16204,298 @@ func (fn *formulaFuncs) COLUMNS(argsList *list.List) formulaArg {
 	return newNumberFormulaArg(float64(result))
 }
 
-// FORMULATEXT function returns a formula as a text string. The syntax of the
-// function is:
+// DROP function excludes a specified number of rows or columns from the start
+// or end of an array, the negative number drops from the end of the array.
+// The syntax of the function is:
+//
+//	DROP(array,rows,[columns])
+func (fn *formulaFuncs) DROP(argsList *list.List) formulaArg {
+	return fn.takeOrDrop("DROP", false, argsList)
+}
+
+// takeOrDrop is an implementation of the formula functions DROP and TAKE.
+func (fn *formulaFuncs) takeOrDrop(name string, take bool, argsList *list.List) formulaArg {
+	argsLen := argsList.Len()
+	if argsLen < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 2 arguments", name))
+	}
+	if argsLen > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s allows at most 3 arguments", name))
+	}
+	mtx := lambdaHelperArray(argsList.Front().Value.(formulaArg))
+	// bounds returns the start and end index of the kept items by given the
+	// count argument and the number of items in the dimension
+	bounds := func(arg formulaArg, size int) (int, int, formulaArg) {
+		if arg.Type == ArgEmpty {
+			return 0, size, arg
+		}
+		num := arg.ToNumber()
+		if num.Type != ArgNumber {
+			return 0, 0, num
+		}
+		// clamp the count before converting to avoid integer overflow
+		count := int(math.Max(math.Min(num.Number, float64(size+1)), -float64(size+1)))
+		if take {
+			if count == 0 || count > size || -count > size {
+				return 0, 0, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+			}
+			if count < 0 {
+				return size + count, size, num
+			}
+			return 0, count, num
+		}
+		if count >= size || -count >= size {
+			return 0, 0, newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		if count < 0 {
+			return 0, size + count, num
+		}
+		return count, size, num
+	}
+	rowStart, rowEnd, arg := bounds(argsList.Front().Next().Value.(formulaArg), len(mtx))
+	if arg.Type == ArgError {
+		return arg
+	}
+	colStart, colEnd := 0, len(mtx[0])
+	if argsLen > 2 {
+		if colStart, colEnd, arg = bounds(argsList.Back().Value.(formulaArg), len(mtx[0])); arg.Type == ArgError {
+			return arg
+		}
+	}
+	var result [][]formulaArg
+	for _, row := range mtx[rowStart:rowEnd] {
+		result = append(result, row[colStart:colEnd])
+	}
+	return newMatrixFormulaArg(result)
+}
+
+// EXPAND function expands or pads an array to the specified row and column
+// dimensions, and returns the #NUM! error if the dimensions exceed the
+// worksheet limits. The syntax of the function is:
+//
+//	EXPAND(array,rows,[columns],[pad_with])
+func (fn *formulaFuncs) EXPAND(argsList *list.List) formulaArg {
+	argsLen := argsList.Len()
+	if argsLen < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "EXPAND requires at least 2 arguments")
+	}
+	if argsLen > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "EXPAND allows at most 4 arguments")
+	}
+	mtx := lambdaHelperArray(argsList.Front().Value.(formulaArg))
+	rows, cols, padWith := len(mtx), len(mtx[0]), newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	if arg := argsList.Front().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+		num := arg.ToNumber()
+		if num.Type != ArgNumber {
+			return num
+		}
+		if rows = int(num.Number); rows < len(mtx) {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+	}
+	if argsLen > 2 {
+		if arg := argsList.Front().Next().Next().Value.(formulaArg); arg.Type != ArgEmpty {
+			num := arg.ToNumber()
+			if num.Type != ArgNumber {
+				return num
+			}
+			if cols = int(num.Number); cols < len(mtx[0]) {
+				return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+			}
+		}
+	}
+	if _, _, err := prepareArrayDimension(newNumberFormulaArg(float64(rows)), newNumberFormulaArg(float64(cols))); err.Type == ArgError {
+		return err
+	}
+	if argsLen > 3 {
+		if arg := argsList.Back().Value.(formulaArg); arg.Type != ArgEmpty {
+			padWith = arg
+		}
+	}
+	result := make([][]formulaArg, rows)
+	for r := range result {
+		result[r] = make([]formulaArg, cols)
+		for c := range result[r] {
+			result[r][c] = padWith
+			if r < len(mtx) && c < len(mtx[r]) {
+				result[r][c] = mtx[r][c]
+			}
+		}
+	}
+	return newMatrixFormulaArg(result)
+}
+
+// FORMULATEXT function returns a formula as a text string, and returns the
+// #N/A error if the referenced cell doesn't contain a formula. The syntax of
+// the function is:
 //
 //	FORMULATEXT(reference)
 func (fn *formulaFuncs) FORMULATEXT(argsList *list.List) formulaArg {
 	if argsList.Len() != 1 {
 		return newErrorFormulaArg(formulaErrorVALUE, "FORMULATEXT requires 1 argument")
 	}
-	refs := argsList.Front().Value.(formulaArg).cellRefs
-	col, row := 0, 0
-	if refs != nil && refs.Len() > 0 {
-		col, row = refs.Front().Value.(cellRef).Col, refs.Front().Value.(cellRef).Row
+	formula, ok := fn.getCellFormulaByRef(argsList.Front().Value.(formulaArg))
+	if !ok {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 	}
-	ranges := argsList.Front().Value.(formulaArg).cellRanges
-	if ranges != nil && ranges.Len() > 0 {
-		col, row = ranges.Front().Value.(cellRange).From.Col, ranges.Front().Value.(cellRange).From.Row
+	if formula == "" {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
-	cell, err := CoordinatesToCellName(col, row)
-	if err != nil {
-		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
 	}
-	formula, _ := fn.f.GetCellFormula(fn.sheet, cell)
 	return newStringFormulaArg(formula)
 }
 
+// getCellFormulaByRef returns the formula of the top-left cell in the given
+// reference argument for the formula functions FORMULATEXT and ISFORMULA, the
+// second return value will be false if the argument is not a reference.
+func (fn *formulaFuncs) getCellFormulaByRef(arg formulaArg) (string, bool) {
+	var ref cellRef
+	if arg.cellRanges != nil && arg.cellRanges.Len() > 0 {
+		ref = arg.cellRanges.Front().Value.(cellRange).From
+	} else if arg.cellRefs != nil && arg.cellRefs.Len() > 0 {
+		ref = arg.cellRefs.Front().Value.(cellRef)
+	} else {
+		return "", false
+	}
+	cell, err := CoordinatesToCellName(ref.Col, ref.Row)
+	if err != nil {
+		return "", false
+	}
+	if ref.Sheet == "" {
+		ref.Sheet = fn.sheet
+	}
+	formula, _ := fn.f.GetCellFormula(ref.Sheet, cell)
+	return formula, true
+}
+
+// getPivotTableByRef returns the pivot table definition and the source data
+// range reference of the pivot table which contains the given reference for
+// the formula function GETPIVOTDATA.
+func (fn *formulaFuncs) getPivotTableByRef(arg formulaArg) (*PivotTableOptions, string, string) {
+	var ref cellRef
+	if arg.cellRanges != nil && arg.cellRanges.Len() > 0 {
+		ref = arg.cellRanges.Front().Value.(cellRange).From
+	} else if arg.cellRefs != nil && arg.cellRefs.Len() > 0 {
+		ref = arg.cellRefs.Front().Value.(cellRef)
+	} else {
+		return nil, "", ""
+	}
+	if ref.Sheet == "" {
+		ref.Sheet = fn.sheet
+	}
+	pivotTables, _ := fn.f.GetPivotTables(ref.Sheet)
+	for _, pivotTable := range pivotTables {
+		coordinates, err := rangeRefToCoordinates(strings.TrimPrefix(pivotTable.PivotTableRange, pivotTable.pivotSheetName+"!"))
+		if err != nil || !cellInRange([]int{ref.Col, ref.Row}, coordinates) {
+			continue
+		}
+		if pivotTable.namedDataRange {
+			sheet, rangeRef, _ := strings.Cut(pivotTable.pivotDataRange, "!")
+			return &pivotTable, strings.Trim(sheet, "'"), rangeRef
+		}
+		pc, _ := fn.f.pivotCacheReader(pivotTable.pivotCacheXML)
+		sheet := pc.CacheSource.WorksheetSource.Sheet
+		if sheet == "" {
+			sheet = ref.Sheet
+		}
+		return &pivotTable, sheet, pc.CacheSource.WorksheetSource.Ref
+	}
+	return nil, "", ""
+}
+
+// GETPIVOTDATA function extracts the data stored in a pivot table, by
+// aggregating the source data of the pivot table with the summarize function
+// of the data field and the given field and item pairs. The syntax of the
+// function is:
+//
+//	GETPIVOTDATA(data_field,pivot_table,[field1,item1],...)
+func (fn *formulaFuncs) GETPIVOTDATA(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "GETPIVOTDATA requires at least 2 arguments")
+	}
+	if argsList.Len()%2 != 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "GETPIVOTDATA requires field and item pairs")
+	}
+	dataField := argsList.Front().Value.(formulaArg)
+	if dataField.Type == ArgError {
+		return dataField
+	}
+	pivotTable, sheet, rangeRef := fn.getPivotTableByRef(argsList.Front().Next().Value.(formulaArg))
+	if pivotTable == nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	var data *PivotTableField
+	for i, field := range pivotTable.Data {
+		if strings.EqualFold(field.Name, dataField.Value()) || strings.EqualFold(field.Data, dataField.Value()) {
+			data = &pivotTable.Data[i]
+			break
+		}
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if data == nil || err != nil {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	_ = sortCoordinates(coordinates)
+	header := map[string]int{}
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		cell, _ := CoordinatesToCellName(col, coordinates[1])
+		name, _ := fn.f.GetCellValue(sheet, cell)
+		header[strings.ToLower(name)] = col
+	}
+	criteria := map[int]string{}
+	for arg := argsList.Front().Next().Next(); arg != nil; arg = arg.Next().Next() {
+		field, item, found := arg.Value.(formulaArg).Value(), arg.Next().Value.(formulaArg).Value(), false
+		for _, axisField := range append(append(append([]PivotTableField{}, pivotTable.Rows...), pivotTable.Columns...), pivotTable.Filter...) {
+			found = found || strings.EqualFold(axisField.Data, field)
+		}
+		col, ok := header[strings.ToLower(field)]
+		if !found || !ok {
+			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+		}
+		criteria[col] = item
+	}
+	dataCol, ok := header[strings.ToLower(data.Data)]
+	if !ok {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	values := list.New()
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		matched := true
+		for col, item := range criteria {
+			cell, _ := CoordinatesToCellName(col, row)
+			if value, _ := fn.f.GetCellValue(sheet, cell); !strings.EqualFold(value, item) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			cell, _ := CoordinatesToCellName(dataCol, row)
+			arg, _ := fn.f.cellResolver(fn.ctx, sheet, cell)
+			values.PushBack(arg)
+		}
+	}
+	if values.Len() == 0 {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	if subtotal, ok := map[string]func(argsList *list.List) formulaArg{
+		"average":   fn.AVERAGE,
+		"count":     fn.COUNTA,
+		"countnums": fn.COUNT,
+		"max":       fn.MAX,
+		"min":       fn.MIN,
+		"product":   fn.PRODUCT,
+		"stddev":    fn.STDEV,
+		"stddevp":   fn.STDEVP,
+		"var":       fn.VAR,
+		"varp":      fn.VARP,
+	}[strings.ToLower(data.Subtotal)]; ok {
+		return subtotal(values)
+	}
+	return fn.SUM(values)
+}
+
 // checkHVLookupArgs checking arguments, prepare extract mode, lookup value,
 // and data for the formula functions HLOOKUP and VLOOKUP.
 func checkHVLookupArgs(name string, argsList *list.List) (idx int, lookupValue, tableArray, matchMode, errArg formulaArg) {
@@ -14987,6 +16580,49 @@ func (fn *formulaFuncs) HYPERLINK(argsList *list.List) formulaArg {
 	return newStringFormulaArg(argsList.Back().Value.(formulaArg).Value())
 }
 
+// IMAGE function inserts an image from a web source into the cell, and
+// returns the source of the image. The syntax of the function is:
+//
+//	IMAGE(source,[alt_text],[sizing],[height],[width])
+func (fn *formulaFuncs) IMAGE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "IMAGE requires at least 1 argument")
+	}
+	if argsList.Len() > 5 {
+		return newErrorFormulaArg(formulaErrorVALUE, "IMAGE allows at most 5 arguments")
+	}
+	source := argsList.Front().Value.(formulaArg)
+	if source.Type == ArgError {
+		return source
+	}
+	if argsList.Len() < 3 {
+		return newStringFormulaArg(source.Value())
+	}
+	sizing := argsList.Front().Next().Next().Value.(formulaArg).ToNumber()
+	if sizing.Type != ArgNumber {
+		return sizing
+	}
+	if sizing.Number < 0 || sizing.Number > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	if sizing.Number != 3 {
+		if argsList.Len() > 3 {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return newStringFormulaArg(source.Value())
+	}
+	for arg := argsList.Front().Next().Next().Next(); arg != nil; arg = arg.Next() {
+		size := arg.Value.(formulaArg).ToNumber()
+		if size.Type != ArgNumber {
+			return size
+		}
+		if size.Number < 1 {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+	}
+	return newStringFormulaArg(source.Value())
+}
+
 // calcMatch returns the position of the value by given match type, criteria
 // and lookup array for the formula function MATCH.
 func calcMatch(matchType int, criteria *formulaCriteria, lookupArray []formulaArg) formulaArg {
@@ -15029,6 +16665,56 @@ func calcMatch(matchType int, criteria *formulaCriteria, lookupArray []formulaAr
 	return newNumberFormulaArg(float64(idx + 1))
 }
 
+// MAP function returns an array formed by applying a LAMBDA function to each
+// value of the arrays, all the arrays should have the same dimensions. The
+// syntax of the function is:
+//
+//	MAP(array1,[array2],...,lambda)
+func (fn *formulaFuncs) MAP(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "MAP requires at least 2 arguments")
+	}
+	lambda := argsList.Back().Value.(formulaArg)
+	if lambda.Type == ArgError {
+		return lambda
+	}
+	if lambda.lambda == nil || len(lambda.lambda.params) != argsList.Len()-1 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	var arrays [][][]formulaArg
+	for arg := argsList.Front(); arg != argsList.Back(); arg = arg.Next() {
+		array := lambdaHelperArray(arg.Value.(formulaArg))
+		if len(arrays) > 0 && (len(array) != len(arrays[0]) || len(array[0]) != len(arrays[0][0])) {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		arrays = append(arrays, array)
+	}
+	mtx := make([][]formulaArg, len(arrays[0]))
+	for r := range arrays[0] {
+		for c := range arrays[0][r] {
+			var args []formulaArg
+			for _, array := range arrays {
+				args = append(args, array[r][c])
+			}
+			result := fn.callLambdaWithArgs(lambda.lambda, args...)
+			if result.Type == ArgError {
+				return result
+			}
+			mtx[r] = append(mtx[r], result)
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// lambdaHelperArray converts the given formula argument to the array for the
+// lambda helper functions.
+func lambdaHelperArray(arg formulaArg) [][]formulaArg {
+	if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
+		return arg.Matrix
+	}
+	return [][]formulaArg{{arg}}
+}
+
 // MATCH function looks up a value in an array, and returns the position of
 // the value within the array. The user can specify that the function should
 // only return a result if an exact match is found, or that the function
@@ -15067,6 +16753,69 @@ func (fn *formulaFuncs) MATCH(argsList *list.List) formulaArg {
 	return calcMatch(matchType, formulaCriteriaParser(argsList.Front().Value.(formulaArg)), lookupArray)
 }
 
+// REDUCE function reduces an array to an accumulated value by applying a
+// LAMBDA function to each value of the array and the accumulator. The syntax
+// of the function is:
+//
+//	REDUCE([initial_value],array,lambda(accumulator,value))
+func (fn *formulaFuncs) REDUCE(argsList *list.List) formulaArg {
+	results := fn.reduceOrScan("REDUCE", argsList)
+	if results.Type != ArgMatrix {
+		return results
+	}
+	return results.Matrix[len(results.Matrix)-1][len(results.Matrix[0])-1]
+}
+
+// SCAN function scans an array by applying a LAMBDA function to each value of
+// the array and the accumulator, and returns an array that has each
+// intermediate value. The syntax of the function is:
+//
+//	SCAN([initial_value],array,lambda(accumulator,value))
+func (fn *formulaFuncs) SCAN(argsList *list.List) formulaArg {
+	return fn.reduceOrScan("SCAN", argsList)
+}
+
+// reduceOrScan is an implementation of the formula functions REDUCE and SCAN,
+// returns an array that has each intermediate accumulated value.
+func (fn *formulaFuncs) reduceOrScan(name string, argsList *list.List) formulaArg {
+	if argsList.Len() != 2 && argsList.Len() != 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires 2 or 3 arguments", name))
+	}
+	accumulator := newNumberFormulaArg(0)
+	if argsList.Len() == 3 {
+		accumulator = argsList.Front().Value.(formulaArg)
+	}
+	array, lambda := argsList.Back().Prev().Value.(formulaArg), argsList.Back().Value.(formulaArg)
+	for _, arg := range []formulaArg{accumulator, array, lambda} {
+		if arg.Type == ArgError {
+			return arg
+		}
+	}
+	if lambda.lambda == nil || len(lambda.lambda.params) != 2 || accumulator.Type == ArgMatrix {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	values := lambdaHelperArray(array)
+	mtx := make([][]formulaArg, len(values))
+	for r := range values {
+		for c := range values[r] {
+			if accumulator = fn.callLambdaWithArgs(lambda.lambda, accumulator, values[r][c]); accumulator.Type == ArgError {
+				return accumulator
+			}
+			mtx[r] = append(mtx[r], accumulator)
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// TAKE function returns a specified number of contiguous rows or columns from
+// the start or end of an array, the negative number takes from the end of the
+// array. The syntax of the function is:
+//
+//	TAKE(array,rows,[columns])
+func (fn *formulaFuncs) TAKE(argsList *list.List) formulaArg {
+	return fn.takeOrDrop("TAKE", true, argsList)
+}
+
 // TRANSPOSE function 'transposes' an array of cells (i.e. the function copies
 // a horizontal range of cells into a vertical range and vice versa). The
 // syntax of the function is:
@@ -15431,6 +17180,160 @@ func (fn *formulaFuncs) XLOOKUP(argsList *list.List) formulaArg {
 	return fn.xlookup(lookupRows, lookupCols, returnArrayRows, returnArrayCols, matchIdx, condition1, condition2, condition3, condition4, returnArray)
 }
 
+// xmatchApproximate finds the position of the exact match item, or the next
+// smaller or the next larger item when no exact match is found in the lookup
+// array for the formula function XMATCH, the lookup array doesn't need to be
+// sorted.
+func xmatchApproximate(tableArray []formulaArg, lookupValue, matchMode, searchMode formulaArg) int {
+	matchIdx, matchCell, closer := -1, newEmptyFormulaArg(), byte(criteriaL)
+	if matchMode.Number == matchModeMaxLess {
+		closer = criteriaG
+	}
+	for i := range tableArray {
+		idx := i
+		if searchMode.Number == searchModeReverseLinear {
+			idx = len(tableArray) - 1 - i
+		}
+		cell := tableArray[idx]
+		if cell.Type == ArgEmpty {
+			continue
+		}
+		lhs := newStringFormulaArg(cell.Value())
+		if lookupValue.Type == ArgNumber {
+			if lhs = cell.ToNumber(); lhs.Type == ArgError {
+				continue
+			}
+		}
+		result := compareFormulaArg(lhs, lookupValue, newNumberFormulaArg(matchModeExact), false)
+		if result == criteriaEq {
+			return idx
+		}
+		if (matchMode.Number == matchModeMinGreater && result == criteriaG ||
+			matchMode.Number == matchModeMaxLess && result == criteriaL) &&
+			(matchIdx == -1 || compareFormulaArg(lhs, matchCell, newNumberFormulaArg(matchModeExact), false) == closer) {
+			matchIdx, matchCell = idx, lhs
+		}
+	}
+	return matchIdx
+}
+
+// xmatchBinarySearch finds the position of the exact match item, or the next
+// smaller or the next larger item when no exact match is found in the sorted
+// lookup array by binary search for the formula function XMATCH. The lookup
+// array should be sorted in ascending order for the search mode 2, and in
+// descending order for the search mode -2.
+func xmatchBinarySearch(tableArray []formulaArg, lookupValue, matchMode, searchMode formulaArg) int {
+	ascending := searchMode.Number == searchModeAscBinary
+	compare := func(idx int, matchMode formulaArg) byte {
+		cell := tableArray[idx]
+		lhs := newStringFormulaArg(cell.Value())
+		if lookupValue.Type == ArgNumber {
+			if lhs = cell.ToNumber(); lhs.Type == ArgError {
+				lhs = cell
+			}
+		}
+		return compareFormulaArg(lhs, lookupValue, matchMode, false)
+	}
+	// find the first item which is not less than the lookup value in the
+	// ascending array, or not greater than the lookup value in the
+	// descending array
+	low, high := 0, len(tableArray)
+	for low < high {
+		mid := low + (high-low)/2
+		if result := compare(mid, newNumberFormulaArg(matchModeExact)); ascending && result == criteriaL || !ascending && result == criteriaG {
+			low = mid + 1
+			continue
+		}
+		high = mid
+	}
+	if low < len(tableArray) && compare(low, matchMode) == criteriaEq {
+		return low
+	}
+	switch matchMode.Number {
+	case matchModeMinGreater:
+		if !ascending {
+			return low - 1
+		}
+		if low < len(tableArray) {
+			return low
+		}
+	case matchModeMaxLess:
+		if ascending {
+			return low - 1
+		}
+		if low < len(tableArray) {
+			return low
+		}
+	}
+	return -1
+}
+
+// XMATCH function searches for a specified item in an array or range of
+// cells, and then returns the item's relative position. The syntax of the
+// function is:
+//
+//	XMATCH(lookup_value,lookup_array,[match_mode],[search_mode])
+func (fn *formulaFuncs) XMATCH(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "XMATCH requires at least 2 arguments")
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "XMATCH allows at most 4 arguments")
+	}
+	lookupValue := argsList.Front().Value.(formulaArg)
+	lookupArray := argsList.Front().Next().Value.(formulaArg)
+	matchMode, searchMode := newNumberFormulaArg(matchModeExact), newNumberFormulaArg(searchModeLinear)
+	if argsList.Len() > 2 {
+		if matchMode = argsList.Front().Next().Next().Value.(formulaArg).ToNumber(); matchMode.Type != ArgNumber {
+			return matchMode
+		}
+	}
+	if argsList.Len() > 3 {
+		if searchMode = argsList.Back().Value.(formulaArg).ToNumber(); searchMode.Type != ArgNumber {
+			return searchMode
+		}
+	}
+	switch lookupArray.Type {
+	case ArgError:
+		return lookupArray
+	case ArgList:
+		lookupArray = newMatrixFormulaArg([][]formulaArg{lookupArray.List})
+	case ArgMatrix:
+	default:
+		lookupArray = newMatrixFormulaArg([][]formulaArg{{lookupArray}})
+	}
+	if !validateMatchMode(matchMode.Number) || !validateSearchMode(searchMode.Number) {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	lookupRows, lookupCols := len(lookupArray.Matrix), 0
+	if lookupRows > 0 {
+		lookupCols = len(lookupArray.Matrix[0])
+	}
+	if lookupRows != 1 && lookupCols != 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	verticalLookup := lookupRows >= lookupCols
+	tableArray := lookupArray.Matrix[0]
+	if verticalLookup {
+		tableArray = lookupCol(lookupArray, 0)
+	}
+	var matchIdx int
+	switch searchMode.Number {
+	case searchModeLinear, searchModeReverseLinear:
+		if matchMode.Number == matchModeMinGreater || matchMode.Number == matchModeMaxLess {
+			matchIdx = xmatchApproximate(tableArray, lookupValue, matchMode, searchMode)
+			break
+		}
+		matchIdx, _ = lookupLinearSearch(verticalLookup, lookupValue, lookupArray, matchMode, searchMode)
+	default:
+		matchIdx = xmatchBinarySearch(tableArray, lookupValue, matchMode, searchMode)
+	}
+	if matchIdx == -1 {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	return newNumberFormulaArg(float64(matchIdx + 1))
+}
+
 // INDEX function returns a reference to a cell that lies in a specified row
 // and column of a range of cells. The syntax of the function is:
 //
@@ -2,8 +2,10 @@ package excelize
 
 import (
 	"container/list"
+	"fmt"
 	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -703,6 +705,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=MROUND(-555.4,-1)":     "-555",
 		"=MROUND(-1555,-1000)":   "-2000",
 		"=MROUND(MROUND(1,1),1)": "1",
+		"=MROUND(10,3)":          "9",
 		// MULTINOMIAL
 		"=MULTINOMIAL(3,1,2,5)":        "27720",
 		"=MULTINOMIAL(\"\",3,1,2,5)":   "27720",
@@ -760,6 +763,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=ROUND(999,-1)":          "1000",
 		"=ROUND(991,-1)":          "990",
 		"=ROUND(ROUND(100,1),-1)": "100",
+		"=ROUND(1234.5,-2)":       "1200",
+		"=ROUND(1.2345,2.7)":      "1.23",
+		"=ROUND(1E+300,10)":       "1E+300",
+		"=ROUND(5,-400)":          "0",
 		// ROUNDDOWN
 		"=ROUNDDOWN(99.999,1)":            "99.9",
 		"=ROUNDDOWN(99.999,2)":            "99.99",
@@ -768,6 +775,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=ROUNDDOWN(-99.999,2)":           "-99.99",
 		"=ROUNDDOWN(-99.999,-1)":          "-90",
 		"=ROUNDDOWN(ROUNDDOWN(100,1),-1)": "100",
+		"=ROUNDDOWN(1.2345,-0.5)":         "1",
 		// ROUNDUP
 		"=ROUNDUP(11.111,1)":          "11.2",
 		"=ROUNDUP(11.111,2)":          "11.12",
@@ -776,6 +784,8 @@ func TestCalcCellValue(t *testing.T) {
 		"=ROUNDUP(-11.111,2)":         "-11.12",
 		"=ROUNDUP(-11.111,-1)":        "-20",
 		"=ROUNDUP(ROUNDUP(100,1),-1)": "100",
+		"=ROUNDUP(-1.1,0)":            "-2",
+		"=ROUNDUP(-1E+300,2)":         "-1E+300",
 		// SEARCH
 		"=SEARCH(\"s\",F1)":           "1",
 		"=SEARCH(\"s\",F1,2)":         "5",
@@ -1543,19 +1553,30 @@ func TestCalcCellValue(t *testing.T) {
 		"=DATE(2020,10,21)": "2020-10-21 00:00:00 +0000 UTC",
 		"=DATE(1900,1,1)":   "1899-12-31 00:00:00 +0000 UTC",
 		// DATEDIF
-		"=DATEDIF(43101,43101,\"D\")":  "0",
-		"=DATEDIF(43101,43891,\"d\")":  "790",
-		"=DATEDIF(43101,43891,\"Y\")":  "2",
-		"=DATEDIF(42156,44242,\"y\")":  "5",
-		"=DATEDIF(43101,43891,\"M\")":  "26",
-		"=DATEDIF(42171,44242,\"m\")":  "67",
-		"=DATEDIF(42156,44454,\"MD\")": "14",
-		"=DATEDIF(42171,44242,\"md\")": "30",
-		"=DATEDIF(43101,43891,\"YM\")": "2",
-		"=DATEDIF(42171,44242,\"ym\")": "7",
-		"=DATEDIF(43101,43891,\"YD\")": "59",
-		"=DATEDIF(36526,73110,\"YD\")": "60",
-		"=DATEDIF(42171,44242,\"yd\")": "244",
+		"=DATEDIF(43101,43101,\"D\")":       "0",
+		"=DATEDIF(43101,43891,\"d\")":       "790",
+		"=DATEDIF(43101,43891,\"Y\")":       "2",
+		"=DATEDIF(42156,44242,\"y\")":       "5",
+		"=DATEDIF(43101,43891,\"M\")":       "26",
+		"=DATEDIF(42171,44242,\"m\")":       "67",
+		"=DATEDIF(42156,44454,\"MD\")":      "14",
+		"=DATEDIF(42171,44242,\"md\")":      "30",
+		"=DATEDIF(43101,43891,\"YM\")":      "2",
+		"=DATEDIF(42171,44242,\"ym\")":      "7",
+		"=DATEDIF(43101,43891,\"YD\")":      "59",
+		"=DATEDIF(36526,73110,\"YD\")":      "60",
+		"=DATEDIF(42171,44242,\"yd\")":      "244",
+		"=DATEDIF(43101.75,43102.25,\"D\")": "1",
+		"=DATEDIF(43101.25,43101.75,\"D\")": "0",
+		"=DATEDIF(44211,44237,\"MD\")":      "26",
+		"=DATEDIF(44180,44206,\"MD\")":      "26",
+		"=DATEDIF(44255,44286,\"MD\")":      "3",
+		"=DATEDIF(43861,43891,\"MD\")":      "-1",
+		"=DATEDIF(44227,44256,\"MD\")":      "-2",
+		"=DATEDIF(43861,43890,\"M\")":       "0",
+		"=DATEDIF(43830,43890,\"YM\")":      "1",
+		"=DATEDIF(43525,43890,\"YD\")":      "365",
+		"=DATEDIF(43890,44255,\"YD\")":      "365",
 		// DATEVALUE
 		"=DATEVALUE(\"01/01/16\")":   "42370",
 		"=DATEVALUE(\"01/01/2016\")": "42370",
@@ -1990,6 +2011,11 @@ func TestCalcCellValue(t *testing.T) {
 		// HYPERLINK
 		"=HYPERLINK(\"https://github.com/xuri/excelize\")":              "https://github.com/xuri/excelize",
 		"=HYPERLINK(\"https://github.com/xuri/excelize\",\"Excelize\")": "Excelize",
+		// IMAGE
+		"=IMAGE(\"https://xuri.me/excelize/logo.png\")":                      "https://xuri.me/excelize/logo.png",
+		"=_xlfn.IMAGE(\"https://xuri.me/excelize/logo.png\",\"Excelize\")":   "https://xuri.me/excelize/logo.png",
+		"=IMAGE(\"https://xuri.me/excelize/logo.png\",\"Excelize\",1)":       "https://xuri.me/excelize/logo.png",
+		"=IMAGE(\"https://xuri.me/excelize/logo.png\",\"Excelize\",3,50,80)": "https://xuri.me/excelize/logo.png",
 		// VLOOKUP
 		"=VLOOKUP(D2,D:D,1,FALSE)":            "Jan",
 		"=VLOOKUP(D2,D1:D10,1)":               "Jan",
@@ -3837,9 +3863,12 @@ func TestCalcCellValue(t *testing.T) {
 		"=CODE()":    {"#VALUE!", "CODE requires 1 argument"},
 		"=CODE(1,2)": {"#VALUE!", "CODE requires 1 argument"},
 		// CONCAT
-		"=CONCAT(NA())":  {"#N/A", "#N/A"},
-		"=CONCAT(1,1/0)": {"#DIV/0!", "#DIV/0!"},
+		"=CONCAT()":                        {"#VALUE!", "CONCAT requires at least 1 argument"},
+		"=CONCAT(NA())":                    {"#N/A", "#N/A"},
+		"=CONCAT(1,1/0)":                   {"#DIV/0!", "#DIV/0!"},
+		"=CONCAT(REPT(\"a\",32767),\"b\")": {"#VALUE!", "CONCAT function exceeds 32767 characters"},
 		// CONCATENATE
+		"=CONCATENATE()":      {"#VALUE!", "CONCATENATE requires at least 1 argument"},
 		"=CONCATENATE(NA())":  {"#N/A", "#N/A"},
 		"=CONCATENATE(1,1/0)": {"#DIV/0!", "#DIV/0!"},
 		// DBCS
@@ -4041,6 +4070,15 @@ func TestCalcCellValue(t *testing.T) {
 		// HYPERLINK
 		"=HYPERLINK()": {"#VALUE!", "HYPERLINK requires at least 1 argument"},
 		"=HYPERLINK(\"https://github.com/xuri/excelize\",\"Excelize\",\"\")": {"#VALUE!", "HYPERLINK allows at most 2 arguments"},
+		// IMAGE
+		"=IMAGE()":                    {"#VALUE!", "IMAGE requires at least 1 argument"},
+		"=IMAGE(\"\",\"\",3,1,1,1)":   {"#VALUE!", "IMAGE allows at most 5 arguments"},
+		"=IMAGE(NA())":                {"#N/A", "#N/A"},
+		"=IMAGE(\"\",\"\",\"\")":      {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=IMAGE(\"\",\"\",4)":         {"#VALUE!", "#VALUE!"},
+		"=IMAGE(\"\",\"\",0,50)":      {"#VALUE!", "#VALUE!"},
+		"=IMAGE(\"\",\"\",3,\"\",80)": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=IMAGE(\"\",\"\",3,50,0)":    {"#VALUE!", "#VALUE!"},
 		// VLOOKUP
 		"=VLOOKUP()":                     {"#VALUE!", "VLOOKUP requires at least 3 arguments"},
 		"=VLOOKUP(D2,D1,1,FALSE)":        {"#VALUE!", "VLOOKUP requires second argument of table array"},
@@ -4960,6 +4998,191 @@ func TestCalcTRANSPOSE(t *testing.T) {
 	assert.NoError(t, err, formula)
 }
 
+func TestCalcRANDARRAY(t *testing.T) {
+	f, g := NewFile(), NewFile()
+	// Test the random values are in the bounds and deterministic with seed
+	for formula, bounds := range map[string][]float64{
+		"RANDARRAY()":                   {0, 1},
+		"RANDARRAY(3,4)":                {0, 1},
+		"RANDARRAY(3,4,10,20)":          {10, 20},
+		"RANDARRAY(3,4,-5,5,FALSE)":     {-5, 5},
+		"RANDARRAY(3,4,1,6,TRUE)":       {1, 6},
+		"RANDARRAY(3,4,\"\",\"\",\"\")": {0, 1},
+	} {
+		for r := 1; r <= 3; r++ {
+			for c := 1; c <= 4; c++ {
+				assert.NoError(t, f.SetCellFormula("Sheet1", "A1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula, r, c)))
+				assert.NoError(t, g.SetCellFormula("Sheet1", "A1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula, r, c)))
+				result, err := f.CalcCellValue("Sheet1", "A1", Options{RandSeed: 1, RawCellValue: true})
+				expected, _ := g.CalcCellValue("Sheet1", "A1", Options{RandSeed: 1, RawCellValue: true})
+				if formula == "RANDARRAY()" && (r > 1 || c > 1) {
+					assert.Error(t, err, formula)
+					assert.Equal(t, "#REF!", result, formula)
+					continue
+				}
+				assert.NoError(t, err, formula)
+				num, err := strconv.ParseFloat(result, 64)
+				assert.NoError(t, err, formula)
+				assert.GreaterOrEqual(t, num, bounds[0], formula)
+				assert.LessOrEqual(t, num, bounds[1], formula)
+				if strings.HasSuffix(formula, "TRUE)") {
+					assert.Equal(t, math.Trunc(num), num, formula)
+				}
+				assert.Equal(t, expected, result, formula)
+			}
+		}
+	}
+	// Test the random values of the cells are different with seed
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=RAND()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "=RAND()"))
+	rand1, err := f.CalcCellValue("Sheet1", "A1", Options{RandSeed: 1})
+	assert.NoError(t, err)
+	rand2, err := f.CalcCellValue("Sheet1", "A2", Options{RandSeed: 1})
+	assert.NoError(t, err)
+	assert.NotEqual(t, rand1, rand2)
+	// Test the integer random values cover the range
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=MIN(RANDARRAY(100,10,1,3,TRUE))&MAX(RANDARRAY(100,10,1,3,TRUE))"))
+	result, err := f.CalcCellValue("Sheet1", "A1", Options{RandSeed: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "13", result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=MIN(RANDARRAY(10,10,7,7,TRUE))+MAX(RANDARRAY(10,10,7,7,TRUE))"))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "14", result)
+	for formula, expected := range map[string][]string{
+		"=RANDARRAY(1,1,0,1,FALSE,0)":     {"#VALUE!", "RANDARRAY accepts at most 5 arguments"},
+		"=RANDARRAY(\"x\")":               {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=RANDARRAY(1,\"x\")":             {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=RANDARRAY(-1)":                  {"#VALUE!", "#VALUE!"},
+		"=RANDARRAY(0)":                   {"#CALC!", "#CALC!"},
+		"=RANDARRAY(1,0)":                 {"#CALC!", "#CALC!"},
+		"=RANDARRAY(1048577)":             {"#NUM!", "#NUM!"},
+		"=RANDARRAY(1,16385)":             {"#NUM!", "#NUM!"},
+		"=RANDARRAY(1048576,16384)":       {"#NUM!", "#NUM!"},
+		"=RANDARRAY(1,1,\"x\")":           {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=RANDARRAY(1,1,0,\"x\")":         {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=RANDARRAY(1,1,0,1,\"x\")":       {"#VALUE!", "strconv.ParseBool: parsing \"x\": invalid syntax"},
+		"=RANDARRAY(1,1,2,1)":             {"#VALUE!", "#VALUE!"},
+		"=RANDARRAY(1,1,0.5,1,TRUE)":      {"#VALUE!", "#VALUE!"},
+		"=RANDARRAY(1,1,-1E19,1E19,TRUE)": {"#NUM!", "#NUM!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcSEQUENCE(t *testing.T) {
+	f := NewFile()
+	for formula, expected := range map[string][][]string{
+		"SEQUENCE(3)":                {{"1"}, {"2"}, {"3"}},
+		"SEQUENCE(2,3)":              {{"1", "2", "3"}, {"4", "5", "6"}},
+		"SEQUENCE(2,3,10)":           {{"10", "11", "12"}, {"13", "14", "15"}},
+		"SEQUENCE(2,2,0,-0.5)":       {{"0", "-0.5"}, {"-1", "-1.5"}},
+		"SEQUENCE(1,4,5,0)":          {{"5", "5", "5", "5"}},
+		"SEQUENCE(2.9,2.9)":          {{"1", "2"}, {"3", "4"}},
+		"SEQUENCE(2,\"\",\"\",\"\")": {{"1"}, {"2"}},
+	} {
+		// Test the dimensions and each element of the result array
+		for _, idx := range [][]int{{len(expected) + 1, 1}, {1, len(expected[0]) + 1}} {
+			assert.NoError(t, f.SetCellFormula("Sheet1", "A1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula, idx[0], idx[1])))
+			result, err := f.CalcCellValue("Sheet1", "A1")
+			assert.Error(t, err, formula)
+			assert.Equal(t, "#REF!", result, formula)
+		}
+		for r, row := range expected {
+			for c, value := range row {
+				assert.NoError(t, f.SetCellFormula("Sheet1", "A1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula, r+1, c+1)))
+				result, err := f.CalcCellValue("Sheet1", "A1")
+				assert.NoError(t, err, formula)
+				assert.Equal(t, value, result, formula)
+			}
+		}
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=SUM(SEQUENCE(10,10))"))
+	result, err := f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "5050", result)
+	for formula, expected := range map[string][]string{
+		"=SEQUENCE()":              {"#VALUE!", "SEQUENCE requires at least 1 argument"},
+		"=SEQUENCE(1,1,1,1,1)":     {"#VALUE!", "SEQUENCE accepts at most 4 arguments"},
+		"=SEQUENCE(\"x\")":         {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=SEQUENCE(-1)":            {"#VALUE!", "#VALUE!"},
+		"=SEQUENCE(0)":             {"#CALC!", "#CALC!"},
+		"=SEQUENCE(1048577)":       {"#NUM!", "#NUM!"},
+		"=SEQUENCE(1048576,2)":     {"#NUM!", "#NUM!"},
+		"=SEQUENCE(1048576,16384)": {"#NUM!", "#NUM!"},
+		"=SEQUENCE(1,1,\"x\")":     {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=SEQUENCE(1,1,1,\"x\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=SEQUENCE(NA())":          {"#N/A", "#N/A"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcTEXTSPLIT(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Name,Age;Alice,30;Bob"))
+	for formula, expected := range map[string][][]string{
+		"=TEXTSPLIT(\"a,b,c\",\",\")":                    {{"a", "b", "c"}},
+		"=TEXTSPLIT(\"a,b;c,d\",\",\",\";\")":            {{"a", "b"}, {"c", "d"}},
+		"=TEXTSPLIT(\"a;b;c\",\"\",\";\")":               {{"a"}, {"b"}, {"c"}},
+		"=TEXTSPLIT(A1,\",\",\";\")":                     {{"Name", "Age"}, {"Alice", "30"}, {"Bob", "#N/A"}},
+		"=TEXTSPLIT(A1,\",\",\";\",FALSE,0,\"-\")":       {{"Name", "Age"}, {"Alice", "30"}, {"Bob", "-"}},
+		"=TEXTSPLIT(\"a,,b\",\",\")":                     {{"a", "", "b"}},
+		"=TEXTSPLIT(\"a,,b\",\",\",\"\",TRUE)":           {{"a", "b"}},
+		"=TEXTSPLIT(\"a-b_c\",{\"-\",\"_\"})":            {{"a", "b", "c"}},
+		"=TEXTSPLIT(\"1x2X3\",\"x\")":                    {{"1", "2X3"}},
+		"=TEXTSPLIT(\"1x2X3\",\"x\",\"\",FALSE,1)":       {{"1", "2", "3"}},
+		"=TEXTSPLIT(\"a--b-c\",{\"-\",\"--\"})":          {{"a", "b", "c"}},
+		"=TEXTSPLIT(\"a,b\",\",\",\";\",FALSE,0,0)":      {{"a", "b"}},
+		"=TEXTSPLIT(\"abc\",\",\")":                      {{"abc"}},
+		"=TEXTSPLIT(\"a,b;c\",\",\",\";\",FALSE,0,\"\")": {{"a", "b"}, {"c", ""}},
+		"=TEXTSPLIT(\"a,b;;c\",\",\",\";\",TRUE)":        {{"a", "b"}, {"c", "#N/A"}},
+		"=TEXTSPLIT(\"a,b;;c\",\",\",\";\")":             {{"a", "b"}, {"", "#N/A"}, {"c", "#N/A"}},
+		"=TEXTSPLIT(\"a,b\",\",\",\"\")":                 {{"a", "b"}},
+	} {
+		// Test the dimensions and each element of the result array
+		for _, idx := range [][]int{{len(expected) + 1, 1}, {1, len(expected[0]) + 1}} {
+			assert.NoError(t, f.SetCellFormula("Sheet1", "C1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula[1:], idx[0], idx[1])))
+			result, err := f.CalcCellValue("Sheet1", "C1")
+			assert.Error(t, err, formula)
+			assert.Equal(t, "#REF!", result, formula)
+		}
+		for r, row := range expected {
+			for c, value := range row {
+				assert.NoError(t, f.SetCellFormula("Sheet1", "C1", fmt.Sprintf("=INDEX(%s,%d,%d)", formula[1:], r+1, c+1)))
+				result, err := f.CalcCellValue("Sheet1", "C1")
+				if value == "#N/A" {
+					assert.EqualError(t, err, value, formula)
+				} else {
+					assert.NoError(t, err, formula)
+				}
+				assert.Equal(t, value, result, formula)
+			}
+		}
+	}
+	for formula, expected := range map[string][]string{
+		"=TEXTSPLIT()":                             {"#VALUE!", "TEXTSPLIT requires at least 2 arguments"},
+		"=TEXTSPLIT(\"a\",\",\",\";\",0,0,0,0)":    {"#VALUE!", "TEXTSPLIT accepts at most 6 arguments"},
+		"=TEXTSPLIT(NA(),\",\")":                   {"#N/A", "#N/A"},
+		"=TEXTSPLIT(\"a\",\"\")":                   {"#VALUE!", "#VALUE!"},
+		"=TEXTSPLIT(\"a\",\",\",\"\",\"x\")":       {"#VALUE!", "strconv.ParseBool: parsing \"x\": invalid syntax"},
+		"=TEXTSPLIT(\"a\",\",\",\"\",FALSE,\"x\")": {"#VALUE!", "strconv.ParseFloat: parsing \"x\": invalid syntax"},
+		"=TEXTSPLIT(\"a\",\",\",\"\",FALSE,2)":     {"#VALUE!", "#VALUE!"},
+		"=TEXTSPLIT(\",,\",\",\",\"\",TRUE)":       {"#CALC!", "#CALC!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "G1", formula))
+		result, err := f.CalcCellValue("Sheet1", "G1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
 func TestCalcVLOOKUP(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, nil, nil, nil, nil, nil},
@@ -5220,6 +5443,40 @@ func TestCalcFORMULATEXT(t *testing.T) {
 		assert.NoError(t, err, formula)
 		assert.Equal(t, formulaText, result, formula)
 	}
+	// Test get formula text of the cell without formula
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=FORMULATEXT(B1)"))
+	result, err := f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, formulaErrorNA)
+	assert.Equal(t, formulaErrorNA, result)
+	// Test get formula text of the cell on another worksheet, and the formula
+	// stored without the equal sign
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "AVERAGE(Sheet1!B1:C1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=FORMULATEXT(Sheet2!A1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=AVERAGE(Sheet1!B1:C1)", result)
+}
+
+func TestCalcFormulaInspection(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=SUM(1,2)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 3))
+	for formula, expected := range map[string]string{
+		"=ISFORMULA(A1)":   "TRUE",
+		"=ISFORMULA(A2)":   "FALSE",
+		"=FORMULATEXT(A1)": "=SUM(1,2)",
+		"=FORMULATEXT(A2)": formulaErrorNA,
+		"=ISREF(A1)":       "TRUE",
+		"=ISREF(A2)":       "TRUE",
+		"=ISREF(A1+A2)":    "FALSE",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula), formula)
+		result, _ := f.CalcCellValue("Sheet1", "B1")
+		assert.Equal(t, expected, result, formula)
+	}
 }
 
 func TestCalcGROWTHandTREND(t *testing.T) {
@@ -5300,6 +5557,108 @@ func TestCalcGROWTHandTREND(t *testing.T) {
 	}
 }
 
+func TestCalcLINESTandLOGEST(t *testing.T) {
+	cellData := [][]interface{}{
+		{"x", "x2", "y", "month", "units"},
+		{1, 2, 3100, 11, 33100},
+		{2, 1, 4500, 12, 47300},
+		{3, 5, 4400, 13, 69000},
+		{4, 3, 5400, 14, 102000},
+		{5, 4, 7500, 15, 150000},
+		{6, 8, 8100, 16, 220000},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=INDEX(LINEST(C2:C7,A2:A7),1,1)":                       "1000",
+		"=INDEX(LINEST(C2:C7,A2:A7),1,2)":                       "2000",
+		"=INDEX(LINEST(C2:C7),1,1)":                             "1000",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),2,1)":             "133.095025129738",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),2,2)":             "518.330653798004",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),3,1)":             "0.933831376734258",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),3,2)":             "556.776436283002",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),4,1)":             "56.4516129032258",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),4,2)":             "4",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),5,1)":             "17500000",
+		"=INDEX(LINEST(C2:C7,A2:A7,TRUE,TRUE),5,2)":             "1240000",
+		"=INDEX(LINEST(C2:C7,A2:A7,FALSE,TRUE),1,1)":            "1461.53846153846",
+		"=INDEX(LINEST(C2:C7,A2:A7,FALSE,TRUE),1,2)":            "0",
+		"=INDEX(LINEST(C2:C7,A2:A7,FALSE,TRUE),2,2)":            "#N/A",
+		"=INDEX(LINEST(C2:C7,A2:A7,FALSE,TRUE),4,2)":            "5",
+		"=INDEX(LINEST(C2:C7,A2:A7,FALSE,TRUE),5,1)":            "194384615.384615",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),1,1)":             "-115.287162162162",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),1,2)":             "1121.875",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),1,3)":             "2015.37162162162",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),2,1)":             "179.513310979819",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),2,3)":             "561.692422406795",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),3,3)":             "#N/A",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),4,1)":             "24.2859937553749",
+		"=INDEX(LINEST(C2:C7,A2:B7,TRUE,TRUE),4,2)":             "3",
+		"=INDEX(LINEST(TRANSPOSE(C2:C7),TRANSPOSE(A2:B7)),1,1)": "-115.287162162162",
+		"=INDEX(LINEST(A2:B3,A2:B3),1,1)":                       "1",
+		"=INDEX(LINEST(A2:A3,A2:A3,TRUE,TRUE),2,1)":             "#NUM!",
+		"=INDEX(LINEST(A2:A3,A2:A3,TRUE,TRUE),4,1)":             "#NUM!",
+		"=INDEX(LOGEST(E2:E7,D2:D7),1,1)":                       "1.46327562811618",
+		"=INDEX(LOGEST(E2:E7,D2:D7),1,2)":                       "495.304770158729",
+		"=INDEX(LOGEST(E2:E7,D2:D7,TRUE,TRUE),3,1)":             "0.999808619775817",
+		"=INDEX(LOGEST(E2:E7,D2:D7,FALSE),1,2)":                 "1",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "H1", formula))
+		result, _ := f.CalcCellValue("Sheet1", "H1")
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=LINEST()":                        {"#VALUE!", "LINEST requires at least 1 argument"},
+		"=LINEST(C2:C7,A2:A7,TRUE,TRUE,1)": {"#VALUE!", "LINEST allows at most 4 arguments"},
+		"=LINEST(C1:C7)":                   {"#VALUE!", "#VALUE!"},
+		"=LINEST(C2:C7,A1:A6)":             {"#VALUE!", "#VALUE!"},
+		"=LINEST(C2:C7,A2:A7,\"\")":        {"#VALUE!", "strconv.ParseBool: parsing \"\": invalid syntax"},
+		"=LINEST(C2:C7,A2:A7,TRUE,\"\")":   {"#VALUE!", "strconv.ParseBool: parsing \"\": invalid syntax"},
+		"=LINEST(C2:C7,A2:A6)":             {"#REF!", "#REF!"},
+		"=LINEST(A2:B3,A2:A3)":             {"#REF!", "#REF!"},
+		"=LINEST(C2:C3,A2:B3)":             {"#NUM!", "#NUM!"},
+		"=LINEST(C2:C4,{1;1;1})":           {"#NUM!", "#NUM!"},
+		"=LOGEST()":                        {"#VALUE!", "LOGEST requires at least 1 argument"},
+		"=LOGEST(A2:A7,{1;2;3})":           {"#REF!", "#REF!"},
+		"=LOGEST({1;0},{1;2})":             {"#NUM!", "#NUM!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "H1", formula))
+		result, err := f.CalcCellValue("Sheet1", "H1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcARRAYTOTEXTandVALUETOTEXT(t *testing.T) {
+	cellData := [][]interface{}{
+		{1, "Apple", true, "Say \"Hi\""},
+		{2.5, nil, false, "Banana"},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=ARRAYTOTEXT(A1:D2)":              "1, Apple, TRUE, Say \"Hi\", 2.5, , FALSE, Banana",
+		"=ARRAYTOTEXT(A1:D2,1)":            "{1,\"Apple\",TRUE,\"Say \"\"Hi\"\"\";2.5,,FALSE,\"Banana\"}",
+		"=ARRAYTOTEXT({1,\"a\";TRUE,2})":   "1, a, TRUE, 2",
+		"=ARRAYTOTEXT({1,\"a\";TRUE,2},1)": "{1,\"a\";TRUE,2}",
+		"=ARRAYTOTEXT(\"Apple\",1)":        "{\"Apple\"}",
+		"=ARRAYTOTEXT(NA(),1)":             "{#N/A}",
+		"=VALUETOTEXT(A1,1)":               "1",
+		"=VALUETOTEXT(B1)":                 "Apple",
+		"=VALUETOTEXT(B1,1)":               "\"Apple\"",
+		"=VALUETOTEXT(C1,1)":               "TRUE",
+		"=VALUETOTEXT(D1,1)":               "\"Say \"\"Hi\"\"\"",
+		"=VALUETOTEXT(B2,1)":               "",
+		"=VALUETOTEXT(NA(),1)":             "#N/A",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", formula))
+		result, err := f.CalcCellValue("Sheet1", "F1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
 func TestCalcHLOOKUP(t *testing.T) {
 	cellData := [][]interface{}{
 		{"Example Result Table"},
@@ -5508,6 +5867,63 @@ func TestCalcMIRR(t *testing.T) {
 	}
 }
 
+func TestCalcPERCENTILEAndQUARTILE(t *testing.T) {
+	cellData := [][]interface{}{{10}, {2}, {8}, {4}, {6}, {1}, {9}, {3}, {7}, {5}}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=PERCENTILE.INC(A1:A10,0.05)": "1.45",
+		"=PERCENTILE.INC(A1:A10,0.25)": "3.25",
+		"=PERCENTILE.INC(A1:A10,0.5)":  "5.5",
+		"=PERCENTILE.INC(A1:A10,0.95)": "9.55",
+		"=PERCENTILE.EXC(A1:A10,0.25)": "2.75",
+		"=PERCENTILE.EXC(A1:A10,0.5)":  "5.5",
+		"=PERCENTILE.EXC(A1:A10,0.75)": "8.25",
+		"=QUARTILE.INC(A1:A10,1)":      "3.25",
+		"=QUARTILE.INC(A1:A10,2)":      "5.5",
+		"=QUARTILE.INC(A1:A10,3)":      "7.75",
+		"=QUARTILE.EXC(A1:A10,1)":      "2.75",
+		"=QUARTILE.EXC(A1:A10,2)":      "5.5",
+		"=QUARTILE.EXC(A1:A10,3)":      "8.25",
+		"=PERCENTILE.EXC(A1:A3,0.25)":  "2",
+		"=PERCENTILE.EXC(A1:A3,0.75)":  "10",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=PERCENTILE.EXC(A1:A10,0.05)": {"#NUM!", "#NUM!"},
+		"=PERCENTILE.EXC(A1:A10,0.95)": {"#NUM!", "#NUM!"},
+		"=PERCENTILE.EXC(C1:C10,0.5)":  {"#NUM!", "#NUM!"},
+		"=PERCENTILE.INC(C1:C10,0.5)":  {"#NUM!", "#NUM!"},
+		"=QUARTILE.EXC(A1:A2,1)":       {"#NUM!", "#NUM!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.Equal(t, expected[0], result, formula)
+		assert.EqualError(t, err, expected[1], formula)
+	}
+}
+
+func TestCalcCONCAT(t *testing.T) {
+	cellData := [][]interface{}{{"a", "b"}, {nil, 1}}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=CONCAT(A1:B2,\"c\")":   "ab1c",
+		"=CONCAT(\"c\",B1:A2)":   "cab1",
+		"=CONCAT(A1:B2,A1:A2,2)": "ab1a2",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", formula))
+		result, err := f.CalcCellValue("Sheet1", "C1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
 func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 	cellData := [][]interface{}{
 		{"Quarter", "Area", "Sales Rep.", "Sales"},
@@ -5534,6 +5950,14 @@ func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 		"=SUMIFS(D2:D13,A2:A13,1,D2:D13,\">100000\",C2:C13,\"Chris\")": "125000",
 		"=SUMIFS(D2:D13,A2:A13,1,D2:D13,\"<40000\",C2:C13,\"Chris\")":  "0",
 		"=SUMIFS(D2:D13,A2:A13,1,A2:A13,2)":                            "0",
+		"=SUMIFS(D2:D13,B2:B13,\"north\",C2:C13,\"C*\")":               "1104000",
+		"=SUMIFS(D2:D13,C2:C13,\"<>C*\")":                              "1116000",
+		"=AVERAGEIFS(D2:D13,C2:C13,\"Car*\",A2:A13,\"<3\")":            "327000",
+		"=COUNTIFS(C2:C13,\"C*\",D2:D13,\">300000\")":                  "5",
+		"=COUNTIFS(C2:C13,\"?eff\",B2:B13,\"=NORTH\")":                 "4",
+		"=COUNTIFS(C2:C13,\"Je\")":                                     "0",
+		"=MAXIFS(D2:D13,C2:C13,\"C*\",B2:B13,\"North\")":               "389000",
+		"=MINIFS(D2:D13,C2:C13,\"C*\",B2:B13,\"North\")":               "125000",
 	}
 	for formula, expected := range formulaList {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5550,6 +5974,11 @@ func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 		"=SUMIFS()":                                      {"#VALUE!", "SUMIFS requires at least 3 arguments"},
 		"=SUMIFS(D2:D13,A2:A13,1,B2:B13)":                {"#N/A", "#N/A"},
 		"=SUMIFS(D20:D23,A2:A13,\">2\",C2:C13,\"Jeff\")": {"#VALUE!", "#VALUE!"},
+		"=SUMIFS(D2:D13,A2:A13,1,B2:B12,\"North\")":      {"#VALUE!", "#VALUE!"},
+		"=AVERAGEIFS(D2:D12,A2:A13,1)":                   {"#VALUE!", "#VALUE!"},
+		"=COUNTIFS(A2:A13,1,B2:C13,\"North\")":           {"#VALUE!", "#VALUE!"},
+		"=MAXIFS(D2:D13,A2:A12,1)":                       {"#VALUE!", "#VALUE!"},
+		"=MINIFS(D2:D13,A2:A12,1)":                       {"#VALUE!", "#VALUE!"},
 	}
 	for formula, expected := range calcError {
 		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
@@ -5557,6 +5986,38 @@ func TestCalcSUMIFSAndAVERAGEIFS(t *testing.T) {
 		assert.Equal(t, expected[0], result, formula)
 		assert.EqualError(t, err, expected[1], formula)
 	}
+	// Test criteria pattern with wildcard and escape characters
+	assert.Equal(t, `(?i)^\*a~x\.\?.~$`, formulaCriteriaPattern("~*a~x.~??~"))
+}
+
+func TestCalcCriteriaOperatorsAndWildcards(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Apple", 1}, {"Apricot", 2}, {"Banana", 3}, {"a*b", 4},
+		{"abb", 5}, {"a?", 6}, {10, 7}, {20, 8},
+	}
+	f := prepareCalcData(cellData)
+	for formula, expected := range map[string]string{
+		"=COUNTIF(A1:A8,\"ap*\")":       "2",
+		"=COUNTIF(A1:A8,\"a*b\")":       "2",
+		"=COUNTIF(A1:A8,\"a~*b\")":      "1",
+		"=COUNTIF(A1:A8,\"a~?\")":       "1",
+		"=COUNTIF(A1:A8,\"?????\")":     "1",
+		"=COUNTIF(A1:A8,\"<>ap*\")":     "6",
+		"=COUNTIF(A1:A8,\"=banana\")":   "1",
+		"=COUNTIF(A1:A8,\">=15\")":      "1",
+		"=COUNTIF(A1:A8,\">b\")":        "1",
+		"=SUMIF(A1:A8,\"ap*\",B1:B8)":   "3",
+		"=SUMIF(A1:A8,\"<>ap*\",B1:B8)": "33",
+		"=SUMIF(A1:A8,\"a~*b\",B1:B8)":  "4",
+		"=SUMIF(A1:A8,\">10\",B1:B8)":   "8",
+		"=SUMIF(A1:A8,\"<=10\",B1:B8)":  "7",
+		"=SUMIF(A1:A8,\"<>10\",B1:B8)":  "29",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
 }
 
 func TestCalcXIRR(t *testing.T) {
@@ -5704,6 +6165,92 @@ func TestCalcXLOOKUP(t *testing.T) {
 	}
 }
 
+func TestCalcXMATCH(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Product", "Price", "Region"},
+		{"Apples", 30, "East", 10, 20, 30, 40, 50},
+		{"Bananas", 50, "West", 50, 40, 30, 20, 10},
+		{"Cherries", 20, "East"},
+		{"Grapes", 40, "North"},
+		{"Lemons", 10, "West"},
+		{"Oranges", 25, "East"},
+		{nil, nil, "South"},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		// Test match mode with exact match
+		"=XMATCH(\"Grapes\",A2:A7)":   "4",
+		"=XMATCH(\"grapes\",A2:A7,0)": "4",
+		"=XMATCH(40,B2:B7)":           "4",
+		"=XMATCH(30,D2:H2)":           "3",
+		// Test match mode with approximate match (next smaller item)
+		"=XMATCH(35,B2:B7,-1)":          "1",
+		"=XMATCH(35,D2:H2,-1)":          "3",
+		"=XMATCH(40,B2:B7,-1)":          "4",
+		"=XMATCH(\"Coconut\",A2:A7,-1)": "3",
+		// Test match mode with approximate match (next larger item)
+		"=XMATCH(35,B2:B7,1)":    "4",
+		"=XMATCH(35,D2:H2,1)":    "4",
+		"=XMATCH(5,B2:B7,1)":     "5",
+		"=XMATCH(\"D\",A2:A7,1)": "4",
+		// Test match mode with partial match (wildcards)
+		"=XMATCH(\"*an*\",A2:A7,2)":   "2",
+		"=XMATCH(\"?emons\",A2:A7,2)": "5",
+		// Test search mode
+		"=XMATCH(\"East\",C2:C8,0,1)":  "1",
+		"=XMATCH(\"East\",C2:C8,0,-1)": "6",
+		"=XMATCH(\"*an*\",A2:A7,2,-1)": "6",
+		"=XMATCH(25,B2:B7,1,-1)":       "6",
+		"=XMATCH(30,D2:H2,0,2)":        "3",
+		"=XMATCH(30,D3:H3,0,-2)":       "3",
+		"=XMATCH(\"East\",C2,0,2)":     "1",
+		"=XMATCH(1,1)":                 "1",
+		"=XMATCH(2,{1,2,3})":           "2",
+		// Test binary search with the next larger or smaller item
+		"=XMATCH(4,{1,3,5,7,9},1,2)":   "3",
+		"=XMATCH(4,{1,3,5,7,9},-1,2)":  "2",
+		"=XMATCH(10,{1,3,5,7,9},-1,2)": "5",
+		"=XMATCH(0,{1,3,5,7,9},1,2)":   "1",
+		"=XMATCH(4,{5,3,1},-1,-2)":     "2",
+		"=XMATCH(4,{5,3,1},1,-2)":      "1",
+		"=XMATCH(35,D2:H2,1,2)":        "4",
+		"=XMATCH(35,D2:H2,-1,2)":       "3",
+		"=XMATCH(35,D3:H3,1,-2)":       "2",
+		"=XMATCH(35,D3:H3,-1,-2)":      "3",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=XMATCH()":                 {"#VALUE!", "XMATCH requires at least 2 arguments"},
+		"=XMATCH(1,B2:B7,0,1,1)":    {"#VALUE!", "XMATCH allows at most 4 arguments"},
+		"=XMATCH(1,B2:B7,\"\")":     {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=XMATCH(1,B2:B7,0,\"\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=XMATCH(1,B2:B7,3)":        {"#VALUE!", "#VALUE!"},
+		"=XMATCH(1,B2:B7,0,0)":      {"#VALUE!", "#VALUE!"},
+		"=XMATCH(1,A1:B7)":          {"#VALUE!", "#VALUE!"},
+		"=XMATCH(2,1)":              {"#N/A", "#N/A"},
+		"=XMATCH(0,{1,3,5},-1,2)":   {"#N/A", "#N/A"},
+		"=XMATCH(6,{1,3,5},1,2)":    {"#N/A", "#N/A"},
+		"=XMATCH(6,{5,3,1},1,-2)":   {"#N/A", "#N/A"},
+		"=XMATCH(0,{5,3,1},-1,-2)":  {"#N/A", "#N/A"},
+		"=XMATCH(4,{1,3,5},0,2)":    {"#N/A", "#N/A"},
+		"=XMATCH(\"Kiwis\",A2:A7)":  {"#N/A", "#N/A"},
+		"=XMATCH(\"*an*\",A2:A7,0)": {"#N/A", "#N/A"},
+		"=XMATCH(60,B2:B7,1)":       {"#N/A", "#N/A"},
+		"=XMATCH(5,B2:B7,-1)":       {"#N/A", "#N/A"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.Equal(t, expected[0], result, formula)
+		assert.EqualError(t, err, expected[1], formula)
+	}
+}
+
 func TestCalcXNPV(t *testing.T) {
 	cellData := [][]interface{}{
 		{nil, 0.05},
@@ -5789,6 +6336,53 @@ func TestCalcMATCH(t *testing.T) {
 	assert.Equal(t, newErrorFormulaArg(formulaErrorNA, formulaErrorNA), calcMatch(2, nil, []formulaArg{}))
 }
 
+func TestCalcCircularReference(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1+1"))
+	for _, cell := range []string{"A1", "B1"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.Equal(t, ErrCalcCircularReference{Cell: "Sheet1!" + cell}, err, cell)
+		assert.EqualError(t, err, fmt.Sprintf("circular reference detected at cell Sheet1!%s", cell), cell)
+		assert.Equal(t, "#REF!", result, cell)
+	}
+	// Test circular reference through multiple cells and worksheets
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=SUM(Sheet2!A1:A2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A2", "=D1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "D1", "=Sheet1!C1"))
+	result, err := f.CalcCellValue("Sheet1", "C1")
+	assert.Equal(t, ErrCalcCircularReference{Cell: "Sheet1!C1"}, err)
+	assert.Equal(t, "#REF!", result)
+	// Test the formula without circular reference which refers the same cell
+	// multiple times
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=C2+C2*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=3"))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	// Test iterative calculation converges on the circular reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=B1/2+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1"))
+	result, err = f.CalcCellValue("Sheet1", "A1", Options{MaxCalcIterations: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	// Test the self-reference of the cell use the cached value
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 5))
+	for _, formula := range []string{"=A1+1", "=SUM(A1:A2)+1"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err = f.CalcCellValue("Sheet1", "A1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, "6", result, formula)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=A1/2+1"))
+	result, err = f.CalcCellValue("Sheet1", "A1", Options{MaxCalcIterations: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	assert.NoError(t, f.Close())
+}
+
 func TestCalcISFORMULA(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=ISFORMULA(A1)"))
@@ -5965,6 +6559,167 @@ func TestCalcSLOP(t *testing.T) {
 	}
 }
 
+func TestCalcCELL(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Excelize"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", 100))
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	styleID, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", styleID))
+	styleID, err = f.NewStyle(&Style{NumFmt: 38, Protection: &Protection{Locked: false}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B4", "B4", styleID))
+	formulaList := map[string]string{
+		"=CELL(\"address\",B2)":           "$B$2",
+		"=CELL(\"address\",B2:C3)":        "$B$2",
+		"=CELL(\"address\",'Sheet 2'!C5)": "'Sheet 2'!$C$5",
+		"=CELL(\"address\")":              "$A$1",
+		"=CELL(\"col\",C5)":               "3",
+		"=CELL(\"row\",C5)":               "5",
+		"=CELL(\"ROW\")":                  "1",
+		"=CELL(\"contents\",B2)":          "Excelize",
+		"=CELL(\"contents\",B3)":          "100",
+		"=CELL(\"contents\",B5)":          "",
+		"=CELL(\"type\",B2)":              "l",
+		"=CELL(\"type\",B3)":              "v",
+		"=CELL(\"type\",B5)":              "b",
+		"=CELL(\"format\",B2)":            "G",
+		"=CELL(\"format\",B3)":            ",2",
+		"=CELL(\"format\",B4)":            ",0-",
+		"=CELL(\"color\",B3)":             "0",
+		"=CELL(\"color\",B4)":             "1",
+		"=CELL(\"protect\",B3)":           "1",
+		"=CELL(\"protect\",B4)":           "0",
+		"=CELL(\"width\",B2)":             "8",
+		"=CELL(\"width\",C2)":             "20",
+		"=CELL(\"filename\",B2)":          "",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=CELL()":              {"#VALUE!", "CELL requires at least 1 argument"},
+		"=CELL(\"col\",B2,B3)": {"#VALUE!", "CELL allows at most 2 arguments"},
+		"=CELL(NA())":          {"#N/A", "#N/A"},
+		"=CELL(\"col\",1)":     {"#VALUE!", "invalid reference"},
+		"=CELL(\"info\",B2)":   {"#VALUE!", "#VALUE!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	// Test get the file name of the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcCELL.xlsx")))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=CELL(\"filename\",'Sheet 2'!A1)"))
+	result, err := f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("test", "[TestCalcCELL.xlsx]Sheet 2"), result)
+	// Test get cell format with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=CELL(\"format\",B3)"))
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, "#VALUE!", result)
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcGETPIVOTDATA(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Sales", "Region"}))
+	for i, row := range [][]interface{}{
+		{"Jan", 2017, "Meat", 100, "East"},
+		{"Jan", 2017, "Dairy", 200, "West"},
+		{"Feb", 2018, "Meat", 300, "East"},
+		{"Jan", 2018, "Meat", 400, "North"},
+		{"Feb", 2017, "Dairy", 500, "South"},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(i+2), &row))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E6",
+		PivotTableRange: "Sheet1!G2:M34",
+		Rows:            []PivotTableField{{Data: "Month"}, {Data: "Year"}},
+		Filter:          []PivotTableField{{Data: "Region"}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales", Name: "Summarize", Subtotal: "Sum"}},
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E6",
+		PivotTableRange: "Sheet1!G40:M50",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Average"}},
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	formulaList := map[string]string{
+		"=GETPIVOTDATA(\"Summarize\",G2)":                                                   "1500",
+		"=GETPIVOTDATA(\"Sales\",Sheet1!H10:I12)":                                           "1500",
+		"=GETPIVOTDATA(\"Summarize\",G2,\"Month\",\"Jan\")":                                 "700",
+		"=GETPIVOTDATA(\"Summarize\",G2,\"month\",\"jan\",\"Type\",\"Meat\")":               "500",
+		"=GETPIVOTDATA(\"Summarize\",G2,\"Month\",\"Jan\",\"Year\",2017,\"Type\",\"Meat\")": "100",
+		"=GETPIVOTDATA(\"Sales\",G45,\"Month\",\"Feb\")":                                    "400",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "O1", formula))
+		result, err := f.CalcCellValue("Sheet1", "O1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=GETPIVOTDATA()": {"#VALUE!", "GETPIVOTDATA requires at least 2 arguments"},
+		"=GETPIVOTDATA(\"Summarize\",G2,\"Month\")":         {"#VALUE!", "GETPIVOTDATA requires field and item pairs"},
+		"=GETPIVOTDATA(NA(),G2)":                            {"#N/A", "#N/A"},
+		"=GETPIVOTDATA(\"Summarize\",1)":                    {"#REF!", "#REF!"},
+		"=GETPIVOTDATA(\"Summarize\",A1)":                   {"#REF!", "#REF!"},
+		"=GETPIVOTDATA(\"Total\",G2)":                       {"#REF!", "#REF!"},
+		"=GETPIVOTDATA(\"Summarize\",G2,\"Sales\",100)":     {"#REF!", "#REF!"},
+		"=GETPIVOTDATA(\"Summarize\",G2,\"Month\",\"Mar\")": {"#REF!", "#REF!"},
+		"=GETPIVOTDATA(\"Sales\",G45,\"Region\",\"East\")":  {"#REF!", "#REF!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "O1", formula))
+		result, err := f.CalcCellValue("Sheet1", "O1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	assert.NoError(t, f.Close())
+	// Test get pivot data with the defined name as data source
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 100}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Jan", 200}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "dataRange", RefersTo: "Sheet1!$A$1:$B$3"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "dataRange",
+		PivotTableRange: "Sheet1!D2:E5",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales", Subtotal: "Max"}},
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "=GETPIVOTDATA(\"Sales\",D2,\"Month\",\"Jan\")"))
+	result, err := f.CalcCellValue("Sheet1", "G1")
+	assert.NoError(t, err)
+	assert.Equal(t, "200", result)
+	// Test get pivot data with unsupported charset pivot cache
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", MacintoshCyrillicCharset)
+	result, err = f.CalcCellValue("Sheet1", "G1")
+	assert.EqualError(t, err, "#REF!")
+	assert.Equal(t, "#REF!", result)
+	assert.NoError(t, f.Close())
+}
+
 func TestCalcSHEET(t *testing.T) {
 	f := NewFile()
 	_, err := f.NewSheet("Sheet2")
@@ -6349,6 +7104,386 @@ func TestCalcColRowQRDecomposition(t *testing.T) {
 	assert.False(t, calcColQRDecomposition([][]float64{{0, 0}, {0, 0}}, []float64{0, 0}, 1, 0))
 }
 
+func TestCalcIMAGE(t *testing.T) {
+	f := NewFile()
+	formula := "_xlfn.IMAGE(\"https://xuri.me/excelize/logo.png\",\"Excelize\",3,50,80)"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formula))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	result, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, formula, result)
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://xuri.me/excelize/logo.png", result)
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcLAMBDA(t *testing.T) {
+	f := NewFile()
+	for _, definedName := range []DefinedName{
+		{Name: "SQUARE", RefersTo: "=LAMBDA(x,x*x)"},
+		{Name: "TOTAL", RefersTo: "_xlfn.LAMBDA(_xlpm.rng,_xlpm.rate,SUM(_xlpm.rng)*(1+_xlpm.rate))"},
+		{Name: "GREET", RefersTo: "LAMBDA(name,\"Hello, \"&name)"},
+		{Name: "PLAIN", RefersTo: "Sheet1!$A$1"},
+		{Name: "INVALID", RefersTo: "LAMBDA(1,x)"},
+		{Name: "ABS", RefersTo: "LAMBDA(x,x*100)"},
+		{Name: "FACT2", RefersTo: "LAMBDA(n,IF(n<=1,1,n*FACT2(n-1)))"},
+		{Name: "LOOP", RefersTo: "LAMBDA(n,n*LOOP(n-1))"},
+	} {
+		assert.NoError(t, f.SetDefinedName(&definedName))
+	}
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Sheet 2", "A1", &[]interface{}{4, 5, 6}))
+	for formula, expected := range map[string]string{
+		"SQUARE(3)":                   "9",
+		"SQUARE(A3)+1":                "10",
+		"SQUARE(SQUARE(2))":           "16",
+		"SUM(SQUARE(2),1)":            "5",
+		"TOTAL(A1:A3,0.5)":            "9",
+		"TOTAL('Sheet 2'!A1:A3,1)":    "30",
+		"TOTAL({1,2;3,4},0)":          "10",
+		"GREET(\"Excel\")":            "Hello, Excel",
+		"ABS(-2)":                     "2",
+		"FACT2(5)":                    "120",
+		"LAMBDA(x,x*x)(3)":            "9",
+		"LAMBDA(x,x*x)(3)+1":          "10",
+		"SUM(LAMBDA(x,y,x*y)(3,4),1)": "13",
+		"LAMBDA(r,SUM(r))(A1:A3)":     "6",
+		"2*LAMBDA(x,x+1)((1+2)*2)":    "14",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"SQUARE()":           {"#VALUE!", "LAMBDA requires 1 arguments"},
+		"SQUARE(1,2)":        {"#VALUE!", "LAMBDA requires 1 arguments"},
+		"SQUARE(1/0)":        {"#DIV/0!", "#DIV/0!"},
+		"SQUARE(\"text\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"text\": invalid syntax"},
+		"PLAIN(1)":           {"#VALUE!", "not support PLAIN function"},
+		"INVALID(1)":         {"#VALUE!", "not support INVALID function"},
+		"FACT2(2000)":        {"#NUM!", "#NUM!"},
+		"LOOP(1)":            {"#NUM!", "#NUM!"},
+		"LAMBDA(x,y,x+y)(1)": {"#VALUE!", "LAMBDA requires 2 arguments"},
+		"LAMBDA(1,x)(1)":     {"#VALUE!", "#VALUE!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	// Test create token by the cell range argument without worksheet name
+	cellRanges := list.New()
+	cellRanges.PushBack(cellRange{From: cellRef{Col: 1, Row: 1}, To: cellRef{Col: 2, Row: 2}})
+	tokens, _ := lambdaArgToTokens(formulaArg{cellRanges: cellRanges})
+	assert.Equal(t, "A1:B2", tokens[0].TValue)
+}
+
+func TestCalcBYROW(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{7, 8, 9}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "TOTAL", RefersTo: "LAMBDA(x,SUM(x))"}))
+	for formula, expected := range map[string]string{
+		"INDEX(BYROW(A1:C3,LAMBDA(r,SUM(r))),1,1)":                             "6",
+		"INDEX(BYROW(A1:C3,LAMBDA(r,SUM(r))),2,1)":                             "15",
+		"INDEX(BYROW(A1:C3,LAMBDA(r,SUM(r))),3,1)":                             "24",
+		"INDEX(BYROW(C3:A1,LAMBDA(r,MAX(r))),3,1)":                             "9",
+		"INDEX(_xlfn.BYROW(A1:C3,_xlfn.LAMBDA(_xlpm.r,TOTAL(_xlpm.r)*2)),2,1)": "30",
+		"SUM(BYROW(A1:C3,LAMBDA(r,SUM(r))))":                                   "45",
+		"COUNT(BYROW(A1:C3,LAMBDA(r,SUM(r))))":                                 "3",
+		"INDEX(BYROW({1,2;3,4},LAMBDA(r,SUM(r))),2,1)":                         "7",
+		"SUM(BYROW(SEQUENCE(3,2),LAMBDA(r,SUM(r))))":                           "21",
+		"INDEX(BYROW(SEQUENCE(3,2),LAMBDA(r,MAX(r))),3,1)":                     "6",
+		"BYROW(5,LAMBDA(r,r+1))":                                               "6",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"BYROW()":                         {"#VALUE!", "BYROW requires 2 arguments"},
+		"BYROW(A1:C3,1)":                  {"#VALUE!", "#VALUE!"},
+		"BYROW(A1:C3,LAMBDA(r,s,SUM(r)))": {"#VALUE!", "#VALUE!"},
+		"BYROW(A1:C3,LAMBDA(1,SUM(r)))":   {"#VALUE!", "#VALUE!"},
+		"BYROW(1/0,LAMBDA(r,SUM(r)))":     {"#VALUE!", "#DIV/0!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcBYCOL(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	for formula, expected := range map[string]string{
+		"INDEX(BYCOL(A1:C2,LAMBDA(c,MAX(c))),1,1)":     "4",
+		"INDEX(BYCOL(A1:C2,LAMBDA(c,MAX(c))),1,3)":     "6",
+		"COUNT(BYCOL(A1:C2,LAMBDA(c,MAX(c))))":         "3",
+		"INDEX(BYCOL({1,2;3,4},LAMBDA(c,SUM(c))),1,2)": "6",
+		"SUM(BYCOL(SEQUENCE(2,3),LAMBDA(c,MAX(c))))":   "15",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"BYCOL(A1:C2)":                    {"#VALUE!", "BYCOL requires 2 arguments"},
+		"BYCOL(A1:C2,LAMBDA(c,1,MAX(c)))": {"#VALUE!", "#VALUE!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcCHOOSECOLSAndCHOOSEROWS(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{7, 8, 9}))
+	for formula, expected := range map[string]string{
+		"INDEX(CHOOSECOLS(A1:C3,3,1),1,1)":          "3",
+		"INDEX(CHOOSECOLS(A1:C3,3,1),3,2)":          "7",
+		"INDEX(CHOOSECOLS(A1:C3,-1),2,1)":           "6",
+		"INDEX(CHOOSECOLS(A1:C3,2,2),1,2)":          "2",
+		"SUM(CHOOSECOLS(A1:C3,-3,2))":               "27",
+		"INDEX(_xlfn.CHOOSECOLS({1,2;3,4},2),2,1)":  "4",
+		"INDEX(CHOOSEROWS(A1:C3,3,1),1,3)":          "9",
+		"INDEX(CHOOSEROWS(A1:C3,3,1),2,1)":          "1",
+		"INDEX(CHOOSEROWS(A1:C3,-2),1,2)":           "5",
+		"SUM(CHOOSEROWS(A1:C3,1,1))":                "12",
+		"INDEX(_xlfn.CHOOSEROWS({1,2;3,4},-1),1,2)": "4",
+		"CHOOSEROWS(5,1)":                           "5",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"CHOOSECOLS(A1:C3)":       {"#VALUE!", "CHOOSECOLS requires at least 2 arguments"},
+		"CHOOSECOLS(A1:C3,0)":     {"#VALUE!", "#VALUE!"},
+		"CHOOSECOLS(A1:C3,4)":     {"#VALUE!", "#VALUE!"},
+		"CHOOSECOLS(A1:C3,-4)":    {"#VALUE!", "#VALUE!"},
+		"CHOOSECOLS(A1:C3,\"a\")": {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+		"CHOOSEROWS(A1:C3)":       {"#VALUE!", "CHOOSEROWS requires at least 2 arguments"},
+		"CHOOSEROWS(A1:C3,1,4)":   {"#VALUE!", "#VALUE!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcEXPAND(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{3, 4}))
+	for formula, expected := range map[string]string{
+		"INDEX(EXPAND(A1:B2,3,3,\"-\"),1,1)":   "1",
+		"INDEX(EXPAND(A1:B2,3,3,\"-\"),2,2)":   "4",
+		"INDEX(EXPAND(A1:B2,3,3,\"-\"),1,3)":   "-",
+		"INDEX(EXPAND(A1:B2,3,3,\"-\"),3,1)":   "-",
+		"INDEX(EXPAND(A1:B2,3,3,\"-\"),3,3)":   "-",
+		"SUM(EXPAND(A1:B2,3,3,0))":             "10",
+		"INDEX(EXPAND(A1:B2,2,3,0),2,3)":       "0",
+		"INDEX(EXPAND(A1:B2,2,2),2,1)":         "3",
+		"INDEX(_xlfn.EXPAND({1,2},2,2,5),2,2)": "5",
+		"INDEX(EXPAND(A1:B2,3),2,2)":           "4",
+		"INDEX(EXPAND(7,2,2,\"\"),1,1)":        "7",
+		"COUNTA(EXPAND(A1:B2,4,3,\"-\"))":      "12",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"INDEX(EXPAND(A1:B2,3,3),3,3)": {"#N/A", "#N/A"},
+		"INDEX(EXPAND(A1:B2,3),3,1)":   {"#N/A", "#N/A"},
+		"EXPAND(A1:B2)":                {"#VALUE!", "EXPAND requires at least 2 arguments"},
+		"EXPAND(A1:B2,3,3,0,0)":        {"#VALUE!", "EXPAND allows at most 4 arguments"},
+		"EXPAND(A1:B2,1,3)":            {"#VALUE!", "#VALUE!"},
+		"EXPAND(A1:B2,3,1)":            {"#VALUE!", "#VALUE!"},
+		"EXPAND(A1:B2,1048577)":        {"#NUM!", "#NUM!"},
+		"EXPAND(A1:B2,2,16385)":        {"#NUM!", "#NUM!"},
+		"EXPAND(A1:B2,1048576,16384)":  {"#NUM!", "#NUM!"},
+		"EXPAND(A1:B2,\"a\")":          {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+		"EXPAND(A1:B2,3,\"a\")":        {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcTAKEAndDROP(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{7, 8, 9}))
+	for formula, expected := range map[string]string{
+		// Test take the last 2 rows
+		"INDEX(TAKE(A1:C3,-2),1,1)":           "4",
+		"INDEX(TAKE(A1:C3,-2),2,3)":           "9",
+		"SUM(TAKE(A1:C3,-2))":                 "39",
+		"SUM(TAKE(A1:C3,1))":                  "6",
+		"SUM(TAKE(A1:C3,3,2))":                "27",
+		"SUM(TAKE(A1:C3,2,-1))":               "9",
+		"SUM(TAKE(A1:C3,3,3))":                "45",
+		"INDEX(_xlfn.TAKE({1,2,3},1,-1),1,1)": "3",
+		"SUM(TAKE(5,1))":                      "5",
+		// Test drop the first column
+		"INDEX(DROP(A1:C3,0,1),1,1)":         "2",
+		"INDEX(DROP(A1:C3,0,1),3,2)":         "9",
+		"SUM(DROP(A1:C3,0,1))":               "33",
+		"SUM(DROP(A1:C3,1))":                 "39",
+		"SUM(DROP(A1:C3,-1))":                "21",
+		"SUM(DROP(A1:C3,1,-2))":              "11",
+		"SUM(DROP(A1:C3,0,0))":               "45",
+		"INDEX(_xlfn.DROP({1,2,3},0,2),1,1)": "3",
+		// Test combine the TAKE and DROP functions to slice the array
+		"SUM(TAKE(DROP(A1:C3,1,1),1,1))": "5",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"TAKE(A1:C3)":         {"#VALUE!", "TAKE requires at least 2 arguments"},
+		"TAKE(A1:C3,1,1,1)":   {"#VALUE!", "TAKE allows at most 3 arguments"},
+		"TAKE(A1:C3,0)":       {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,4)":       {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,-4)":      {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,1E20)":    {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,-1E20)":   {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,1,4)":     {"#VALUE!", "#VALUE!"},
+		"TAKE(A1:C3,\"a\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+		"TAKE(A1:C3,1,\"a\")": {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+		"DROP(A1:C3)":         {"#VALUE!", "DROP requires at least 2 arguments"},
+		"DROP(A1:C3,1,1,1)":   {"#VALUE!", "DROP allows at most 3 arguments"},
+		"DROP(A1:C3,3)":       {"#VALUE!", "#VALUE!"},
+		"DROP(A1:C3,-3)":      {"#VALUE!", "#VALUE!"},
+		"DROP(A1:C3,1E20)":    {"#VALUE!", "#VALUE!"},
+		"DROP(A1:C3,-1E20)":   {"#VALUE!", "#VALUE!"},
+		"DROP(A1:C3,0,3)":     {"#VALUE!", "#VALUE!"},
+		"DROP(A1:C3,\"a\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcMAP(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	for formula, expected := range map[string]string{
+		"INDEX(MAP(A1:C2,LAMBDA(x,x*2)),1,1)":                                 "2",
+		"INDEX(MAP(A1:C2,LAMBDA(x,x*2)),2,3)":                                 "12",
+		"SUM(MAP(A1:C2,LAMBDA(x,x*2)))":                                       "42",
+		"INDEX(MAP({1,2;3,4},{10,20;30,40},LAMBDA(a,b,a+b)),2,2)":             "44",
+		"INDEX(_xlfn.MAP(A1:C2,_xlfn.LAMBDA(_xlpm.x,IF(_xlpm.x>3,1,0))),2,1)": "1",
+		"MAP(5,LAMBDA(x,x+1))":                                                "6",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"MAP(A1:C2)":                         {"#VALUE!", "MAP requires at least 2 arguments"},
+		"MAP(A1:C2,1)":                       {"#VALUE!", "#VALUE!"},
+		"MAP(A1:C2,LAMBDA(a,b,a+b))":         {"#VALUE!", "#VALUE!"},
+		"MAP(A1:C2,{1,2},LAMBDA(a,b,a+b))":   {"#VALUE!", "#VALUE!"},
+		"MAP(A1:C2,{1,2,3},LAMBDA(a,b,a+b))": {"#VALUE!", "#VALUE!"},
+		"MAP({1,\"a\"},LAMBDA(x,ABS(x)))":    {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcREDUCE(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	for formula, expected := range map[string]string{
+		"REDUCE(0,A1:C2,LAMBDA(a,v,a+v))":                                      "21",
+		"REDUCE(1,A1:C2,LAMBDA(a,v,a*v))":                                      "720",
+		"REDUCE(,A1:D2,LAMBDA(a,v,a+v))":                                       "21",
+		"_xlfn.REDUCE(10,A1:C2,_xlfn.LAMBDA(_xlpm.a,_xlpm.v,_xlpm.a+_xlpm.v))": "31",
+		"REDUCE(\"\",{\"a\",\"b\"},LAMBDA(a,v,a&v))":                           "ab",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"REDUCE(0)":                              {"#VALUE!", "REDUCE requires 2 or 3 arguments"},
+		"REDUCE(0,A1:C2,LAMBDA(a,a))":            {"#VALUE!", "#VALUE!"},
+		"REDUCE(0,A1:C2,1)":                      {"#VALUE!", "#VALUE!"},
+		"REDUCE({1,2},A1:C2,LAMBDA(a,v,a+v))":    {"#VALUE!", "#VALUE!"},
+		"REDUCE(0,{1,\"a\"},LAMBDA(a,v,ABS(v)))": {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
+func TestCalcSCAN(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A1", &[]interface{}{1, 2, 3, 4}))
+	for formula, expected := range map[string]string{
+		"INDEX(SCAN(0,A1:A4,LAMBDA(a,v,a+v)),1,1)":                  "1",
+		"INDEX(SCAN(0,A1:A4,LAMBDA(a,v,a+v)),2,1)":                  "3",
+		"INDEX(SCAN(0,A1:A4,LAMBDA(a,v,a+v)),3,1)":                  "6",
+		"INDEX(SCAN(0,A1:A4,LAMBDA(a,v,a+v)),4,1)":                  "10",
+		"SUM(SCAN(0,A1:A4,LAMBDA(a,v,a+v)))":                        "20",
+		"INDEX(SCAN(\"\",{\"a\",\"b\",\"c\"},LAMBDA(a,v,a&v)),1,3)": "abc",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string][]string{
+		"SCAN()":                        {"#VALUE!", "SCAN requires 2 or 3 arguments"},
+		"SCAN(0,A1:A4,LAMBDA(a,b,c,a))": {"#VALUE!", "#VALUE!"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+}
+
 func TestCalcCellResolver(t *testing.T) {
 	f := NewFile()
 	// Test reference a cell multiple times in a formula
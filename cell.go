@@ -13,14 +13,18 @@ package excelize
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/xml"
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/mohae/deepcopy"
 )
 
 // CellType is the type of cell value type.
@@ -60,6 +64,15 @@ var cellTypes = map[string]CellType{
 	"inlineStr": CellTypeInlineString,
 }
 
+var (
+	// autoLinkURLExp defined the pattern of the web address which could be
+	// linked automatically.
+	autoLinkURLExp = regexp.MustCompile(`^(?i)https?://[^\s/$.?#][^\s]*$`)
+	// autoLinkEmailExp defined the pattern of the email address which could
+	// be linked automatically with optional mailto scheme.
+	autoLinkEmailExp = regexp.MustCompile(`^(?i)(mailto:)?[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}$`)
+)
+
 // GetCellValue provides a function to get formatted value from cell by given
 // worksheet name and cell reference in spreadsheet. The return value is
 // converted to the 'string' data type. This function is concurrency safe. If
@@ -68,7 +81,7 @@ var cellTypes = map[string]CellType{
 // values will be the same in a merged range.
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
 	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
-		sst, err := f.sharedStringsReader()
+		sst, err := f.sharedStringsValueReader()
 		if err != nil {
 			return "", true, err
 		}
@@ -95,9 +108,11 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 }
 
 // SetCellValue provides a function to set the value of a cell. This function
-// is concurrency safe. The specified coordinates should not be in the first
-// row of the table, a complex number can be set with string text. The
-// following shows the supported data types:
+// is concurrency safe, the values can be set on the different worksheets from
+// multiple goroutines, and the shared strings table and styles of the
+// workbook are guarded by locks. The specified coordinates should not be in
+// the first row of the table, a complex number can be set with string text.
+// The following shows the supported data types:
 //
 //	int
 //	int8
@@ -117,6 +132,13 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 //	time.Time
 //	bool
 //	nil
+//	fmt.Stringer
+//	encoding.TextMarshaler
+//
+// The value of the type which implements the fmt.Stringer interface will be
+// set as the result of its String method, and the value of the type which
+// implements the encoding.TextMarshaler interface will be set as the result of
+// its MarshalText method.
 //
 // Note that default date format is m/d/yy h:mm of time.Time type value. You
 // can set numbers format by the SetCellStyle function. If you need to set the
@@ -150,6 +172,14 @@ func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
 		err = f.SetCellBool(sheet, cell, v)
 	case nil:
 		err = f.SetCellDefault(sheet, cell, "")
+	case fmt.Stringer:
+		err = f.SetCellStr(sheet, cell, v.String())
+	case encoding.TextMarshaler:
+		var text []byte
+		if text, err = v.MarshalText(); err != nil {
+			return err
+		}
+		err = f.SetCellStr(sheet, cell, string(text))
 	default:
 		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
 	}
@@ -404,6 +434,32 @@ func (f *File) SetCellFloat(sheet, cell string, value float64, precision, bitSiz
 	return f.removeFormula(c, ws, sheet)
 }
 
+// SetCellPercent provides a function to set a floating point value into a
+// cell and apply the percent number format with the given decimal places, the
+// decimal places must be in range 0 to 30. The other style settings of the
+// cell will be kept. For example, set the value 0.256 and show it as "25.6%"
+// for Sheet1!A1:
+//
+//	err := f.SetCellPercent("Sheet1", "A1", 0.256, 1)
+func (f *File) SetCellPercent(sheet, cell string, value float64, decimals int) error {
+	if decimals < 0 || decimals > 30 {
+		return ErrParameterInvalid
+	}
+	if err := f.SetCellFloat(sheet, cell, value, -1, 64); err != nil {
+		return err
+	}
+	return f.updateCellStyle(sheet, cell, func(style *Style) {
+		style.NumFmt, style.DecimalPlaces, style.CustomNumFmt = 9, nil, nil
+		switch decimals {
+		case 0:
+		case 2:
+			style.NumFmt = 10
+		default:
+			style.CustomNumFmt = stringPtr("0." + strings.Repeat("0", decimals) + "%")
+		}
+	})
+}
+
 // setCellFloat prepares cell type and string type cell value by a given float
 // value.
 func setCellFloat(value float64, precision, bitSize int) (t string, v string) {
@@ -435,6 +491,55 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 	return f.removeFormula(c, ws, sheet)
 }
 
+// SetCellTextPrefixed provides a function to set string type value of a cell
+// with the quote prefix style, the spreadsheet application will treat the
+// value as text even it begins with characters like "=" or "+". The other
+// style settings of the cell will be kept. For example:
+//
+//	err := f.SetCellTextPrefixed("Sheet1", "A1", "=SUM(B1:B2)")
+func (f *File) SetCellTextPrefixed(sheet, cell, value string) error {
+	if err := f.SetCellStr(sheet, cell, value); err != nil {
+		return err
+	}
+	return f.updateCellStyle(sheet, cell, func(style *Style) {
+		style.QuotePrefix = true
+	})
+}
+
+// SetCellCheckbox provides a function to set boolean type value of a cell
+// with the checkbox cell control, the value will be displayed as a checked
+// or unchecked checkbox in the spreadsheet application which supports this
+// feature. The other style settings of the cell will be kept. For example:
+//
+//	err := f.SetCellCheckbox("Sheet1", "A1", true)
+func (f *File) SetCellCheckbox(sheet, cell string, checked bool) error {
+	if err := f.SetCellBool(sheet, cell, checked); err != nil {
+		return err
+	}
+	return f.updateCellStyle(sheet, cell, func(style *Style) {
+		style.Checkbox = true
+	})
+}
+
+// updateCellStyle provides a function to apply the changes of the style
+// definition on the current style of the cell by given worksheet name, cell
+// reference and update function.
+func (f *File) updateCellStyle(sheet, cell string, fn func(style *Style)) error {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return err
+	}
+	fn(style)
+	if styleID, err = f.NewStyle(style); err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, cell, cell, styleID)
+}
+
 // setCellString provides a function to set string type to shared string table.
 func (f *File) setCellString(value string) (t, v string, err error) {
 	if utf8.RuneCountInString(value) > TotalCellChars {
@@ -610,6 +715,16 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 			if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
 				return f.formattedValue(&xlsxC{S: c.S, V: f.getFromStringItem(xlsxSI)}, raw, CellTypeSharedString)
 			}
+			if d == nil && f.options.LazySharedStrings {
+				val, ok, err := f.getFromLazyStringItem(xlsxSI)
+				if err != nil {
+					return c.V, err
+				}
+				if ok {
+					return f.formattedValue(&xlsxC{S: c.S, V: val}, raw, CellTypeSharedString)
+				}
+				return f.formattedValue(c, raw, CellTypeSharedString)
+			}
 			d.mu.Lock()
 			defer d.mu.Unlock()
 			if len(d.SI) > xlsxSI {
@@ -626,16 +741,38 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 		return f.formattedValue(c, raw, CellTypeInlineString)
 	default:
 		if isNum, precision, decimal := isNumeric(c.V); isNum && !raw {
-			if precision > 15 {
-				c.V = strconv.FormatFloat(decimal, 'G', 15, 64)
-			} else {
-				c.V = strconv.FormatFloat(decimal, 'f', -1, 64)
+			val := strconv.FormatFloat(decimal, 'f', -1, 64)
+			if floatPrecision := f.getFloatPrecision(c.S); precision > floatPrecision {
+				val = strconv.FormatFloat(decimal, 'G', floatPrecision, 64)
 			}
+			return f.formattedValue(&xlsxC{S: c.S, V: val}, raw, CellTypeNumber)
 		}
 		return f.formattedValue(c, raw, CellTypeNumber)
 	}
 }
 
+// getFloatPrecision returns the maximum number of significant digits for
+// formatting the numeric cell values with the given style index. The
+// FloatPrecision option only applies to the cells without number format or
+// with the General number format, and the other cells use 15 significant
+// digits.
+func (f *File) getFloatPrecision(styleIdx int) int {
+	if f.options == nil || f.options.FloatPrecision < 1 || f.options.FloatPrecision > 17 {
+		return 15
+	}
+	if styleIdx == 0 {
+		return f.options.FloatPrecision
+	}
+	styleSheet, err := f.stylesReader()
+	if err != nil || styleSheet.CellXfs == nil || styleIdx < 0 || styleIdx >= len(styleSheet.CellXfs.Xf) {
+		return f.options.FloatPrecision
+	}
+	if numFmtID := styleSheet.CellXfs.Xf[styleIdx].NumFmtID; numFmtID != nil && *numFmtID != 0 {
+		return 15
+	}
+	return f.options.FloatPrecision
+}
+
 // SetCellDefault provides a function to set string type value of a cell as
 // default format without escaping the cell.
 func (f *File) SetCellDefault(sheet, cell, value string) error {
@@ -914,6 +1051,350 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 	return
 }
 
+// GetSpillRange provides a function to get the anchor cell and the spill range
+// of the dynamic array formula by given worksheet name and cell reference. The
+// isSpill will be true if the cell is the anchor cell of the dynamic array
+// formula or the cell covered by the spilled result of it. The legacy array
+// formulas which without cell metadata will not be treated as spill ranges.
+// For example, get the spill range of the cell A3 in Sheet1:
+//
+//	anchorCell, spillRange, isSpill, err := f.GetSpillRange("Sheet1", "A3")
+func (f *File) GetSpillRange(sheet, cell string) (string, string, bool, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return "", "", false, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return "", "", false, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			if c.Cm == nil || c.F == nil || c.F.T != STCellFormulaTypeArray || c.F.Ref == "" {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(c.F.Ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			if cellInRange([]int{col, row}, coordinates) {
+				return c.R, c.F.Ref, true, nil
+			}
+		}
+	}
+	return "", "", false, err
+}
+
+// GetCellMetadata provides a function to get the value metadata index and the
+// cell metadata index of the cell by given worksheet name and cell reference.
+// The indexes are one-based and point to the value metadata and cell metadata
+// records in the workbook metadata part, the dynamic array formulas use cell
+// metadata and the rich value data types (such as pictures in cells, linked
+// data types) use value metadata. The index will be 0 if the cell doesn't
+// reference any metadata. For example, get the metadata indexes of the cell
+// A1 in Sheet1:
+//
+//	valueMeta, cellMeta, err := f.GetCellMetadata("Sheet1", "A1")
+func (f *File) GetCellMetadata(sheet, cell string) (int, int, error) {
+	var valueMeta, cellMeta int
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		if c.Vm != nil {
+			valueMeta = int(*c.Vm)
+		}
+		if c.Cm != nil {
+			cellMeta = int(*c.Cm)
+		}
+		return "", true, nil
+	})
+	return valueMeta, cellMeta, err
+}
+
+// CopyRange provides a function to copy cells in the range of the source
+// worksheet to the destination worksheet like the paste special of the
+// spreadsheet application, by given source worksheet name, source range
+// reference, destination worksheet name, the top-left cell reference of the
+// destination range and copy options. The copy mode could be one of the
+// following values:
+//
+//	 Mode     | Description
+//	----------+-----------------------------------------------------------------
+//	 all      | Copy the values, formulas and styles of the cells
+//	 values   | Copy the cell values, drop the formulas and keep cached values
+//	 formats  | Copy the cell styles without changing the cell contents
+//	 formulas | Copy the cell formulas and constant values without the styles
+//
+// The relative references in the copied formulas will be adjusted by the
+// offset between the source range and the destination range. For example,
+// copy the formulas in range A1:B2 on Sheet1 to the range begins at D5 on
+// Sheet2:
+//
+//	err := f.CopyRange("Sheet1", "A1:B2", "Sheet2", "D5",
+//	    excelize.CopyOptions{Mode: "formulas"})
+func (f *File) CopyRange(srcSheet, srcRange, dstSheet, destTopLeft string, opts CopyOptions) error {
+	mode := strings.ToLower(opts.Mode)
+	if mode == "" {
+		mode = "all"
+	}
+	if inStrSlice([]string{"all", "values", "formats", "formulas"}, mode, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if !strings.Contains(srcRange, ":") {
+		srcRange += ":" + srcRange
+	}
+	coordinates, err := rangeRefToCoordinates(srcRange)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	col, row, err := CellNameToCoordinates(destTopLeft)
+	if err != nil {
+		return err
+	}
+	dCol, dRow := col-coordinates[0], row-coordinates[1]
+	if coordinates[2]+dCol > MaxColumns {
+		return ErrColumnNumber
+	}
+	if coordinates[3]+dRow > TotalRows {
+		return ErrMaxRows
+	}
+	f.mu.Lock()
+	src, err := f.workSheetReader(srcSheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	dst, err := f.workSheetReader(dstSheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	src.mu.Lock()
+	cells := src.getRangeCells(coordinates)
+	src.mu.Unlock()
+
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+	dst.prepareSheetXML(coordinates[2]+dCol, coordinates[3]+dRow)
+	dst.makeContiguousColumns(coordinates[1]+dRow, coordinates[3]+dRow, coordinates[2]+dCol)
+	for r, rowCells := range cells {
+		for c, sc := range rowCells {
+			cell := &dst.SheetData.Row[coordinates[1]+dRow+r-1].C[coordinates[0]+dCol+c-1]
+			if mode == "all" || mode == "formats" {
+				cell.S = sc.S
+			}
+			if mode == "formats" {
+				continue
+			}
+			if err = f.removeFormula(cell, dst, dstSheet); err != nil {
+				return err
+			}
+			cell.T, cell.V, cell.IS = sc.T, sc.V, nil
+			if sc.IS != nil {
+				is := deepcopy.Copy(*sc.IS).(xlsxSI)
+				cell.IS = &is
+			}
+			if sc.F != nil && mode != "values" {
+				cell.F = &xlsxF{Content: shiftFormula(sc.F.Content, dCol, dRow)}
+				continue
+			}
+			if cell.T == "str" {
+				if cell.T, cell.V, err = f.setCellString(cell.V); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return err
+}
+
+// FillSeries provides a function to fill the cell range with an arithmetic
+// series of numbers or a series of dates by given worksheet name, range
+// reference, start value, step value and optional fill series options. The
+// start and step value could be any integer or floating-point number type for
+// the number series, and the integer values will be filled if both of them are
+// integer types. For the date series, the start value should be a time.Time,
+// and the step value could be a time.Duration, or an integer number with the
+// date unit specified by the DateUnit field of the options, the possible date
+// units are "day", "weekday", "month" and "year", and the default date unit is
+// "day". The dates filled by the month or year unit keep the day of the start
+// date, and will be set to the last day of the month if the day doesn't exist
+// in that month, which is the same as the spreadsheet applications. For
+// multiple columns range, the series will be filled down the first column and
+// continues to the next columns by default, set the ByRow field of the options
+// to fill across the first row and continues to the next rows.
+//
+// For example, fill the cells A1:A10 on Sheet1 with numbers 1 to 10:
+//
+//	err := f.FillSeries("Sheet1", "A1:A10", 1, 1)
+//
+// Fill the cells B1:B12 on Sheet1 with dates by one month step begins with
+// January 31, 2024:
+//
+//	err := f.FillSeries("Sheet1", "B1:B12",
+//	    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 1,
+//	    excelize.FillSeriesOptions{DateUnit: "month"})
+func (f *File) FillSeries(sheet, rangeRef string, start, step interface{}, opts ...FillSeriesOptions) error {
+	var options FillSeriesOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	seriesValue, err := prepareFillSeries(start, step, options.DateUnit)
+	if err != nil {
+		return err
+	}
+	cols, rows := coordinates[2]-coordinates[0]+1, coordinates[3]-coordinates[1]+1
+	for i := 0; i < cols*rows; i++ {
+		col, row := coordinates[0]+i/rows, coordinates[1]+i%rows
+		if options.ByRow {
+			col, row = coordinates[0]+i%cols, coordinates[1]+i/cols
+		}
+		cell, _ := CoordinatesToCellName(col, row)
+		if err = f.SetCellValue(sheet, cell, seriesValue(i)); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// prepareFillSeries returns a function to calculate the value of the series at
+// the given zero-based position by given start value, step value and date unit
+// for the FillSeries function.
+func prepareFillSeries(start, step interface{}, dateUnit string) (func(i int) interface{}, error) {
+	if date, ok := start.(time.Time); ok {
+		if duration, ok := step.(time.Duration); ok {
+			return func(i int) interface{} { return date.Add(time.Duration(i) * duration) }, nil
+		}
+		n, isInt, ok := getFillSeriesNumber(step)
+		if !ok || !isInt {
+			return nil, ErrParameterInvalid
+		}
+		switch strings.ToLower(dateUnit) {
+		case "", "day":
+			return func(i int) interface{} { return date.AddDate(0, 0, i*int(n)) }, nil
+		case "weekday":
+			return func(i int) interface{} { return addWeekdays(date, i*int(n)) }, nil
+		case "month":
+			return func(i int) interface{} { return addMonths(date, i*int(n)) }, nil
+		case "year":
+			return func(i int) interface{} { return addMonths(date, 12*i*int(n)) }, nil
+		}
+		return nil, ErrParameterInvalid
+	}
+	startNum, startIsInt, ok := getFillSeriesNumber(start)
+	if !ok {
+		return nil, ErrParameterInvalid
+	}
+	stepNum, stepIsInt, ok := getFillSeriesNumber(step)
+	if !ok {
+		return nil, ErrParameterInvalid
+	}
+	if startIsInt && stepIsInt {
+		return func(i int) interface{} { return int64(startNum) + int64(i)*int64(stepNum) }, nil
+	}
+	return func(i int) interface{} {
+		val, _ := strconv.ParseFloat(strconv.FormatFloat(startNum+float64(i)*stepNum, 'g', 15, 64), 64)
+		return val
+	}, nil
+}
+
+// getFillSeriesNumber returns the number value of the given value, and whether
+// the value is an integer type number or a number.
+func getFillSeriesNumber(value interface{}) (float64, bool, bool) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	}
+	return 0, false, false
+}
+
+// addMonths returns the date by adding the given number of months to the date,
+// the day of the date will be set to the last day of the month if the day
+// doesn't exist in that month.
+func addMonths(date time.Time, months int) time.Time {
+	y, m, d := date.Date()
+	total := int(m) - 1 + months
+	y, month := y+total/12, total%12
+	if month < 0 {
+		y, month = y-1, month+12
+	}
+	if days := getDaysInMonth(y, month+1); d > days {
+		d = days
+	}
+	return time.Date(y, time.Month(month+1), d, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+}
+
+// addWeekdays returns the date by adding the given number of weekdays to the
+// date, the Saturdays and Sundays will be skipped.
+func addWeekdays(date time.Time, days int) time.Time {
+	isWeekend := func(date time.Time) bool {
+		return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+	}
+	if days == 0 {
+		return date
+	}
+	direction := 1
+	if days < 0 {
+		direction = -1
+	}
+	// move the weekend date back to the adjacent weekday against the
+	// direction, so that it can be moved by whole weeks
+	for isWeekend(date) {
+		date = date.AddDate(0, 0, -direction)
+	}
+	date = date.AddDate(0, 0, days/5*7)
+	for remain := days % 5; remain != 0; {
+		if date = date.AddDate(0, 0, direction); !isWeekend(date) {
+			remain -= direction
+		}
+	}
+	return date
+}
+
+// getRangeCells returns the copies of cells in the given range of the
+// worksheet, the shared formulas will be converted to the normal formulas.
+func (ws *xlsxWorksheet) getRangeCells(coordinates []int) [][]xlsxC {
+	cells := make([][]xlsxC, coordinates[3]-coordinates[1]+1)
+	for i := range cells {
+		cells[i] = make([]xlsxC, coordinates[2]-coordinates[0]+1)
+	}
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			col, row, err := CellNameToCoordinates(c.R)
+			if err != nil || !cellInRange([]int{col, row}, coordinates) {
+				continue
+			}
+			cell := c
+			if c.F != nil {
+				cell.F = &xlsxF{Content: c.F.Content}
+				if c.F.T == STCellFormulaTypeShared && c.F.Ref == "" && c.F.Si != nil {
+					cell.F.Content = getSharedFormula(ws, *c.F.Si, c.R)
+				}
+			}
+			cells[row-coordinates[1]][col-coordinates[0]] = cell
+		}
+	}
+	return cells
+}
+
 // GetCellHyperLink gets a cell hyperlink based on the given worksheet name and
 // cell reference. If the cell has a hyperlink, it will return 'true' and
 // the link address, otherwise it will return 'false' and an empty link
@@ -984,6 +1465,16 @@ type HyperlinkOpts struct {
 // This is another example for "Location":
 //
 //	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet1!A40", "Location")
+//
+// The link type "Drawing" could be used for moving to a drawing object, such
+// as chart, picture or shape in this workbook, the link should be the name of
+// the drawing object with optional worksheet name prefix. Since the
+// spreadsheet application doesn't support hyperlink target to the drawing
+// object directly, the link will be resolved to the top-left cell of the
+// anchor of the drawing object. For example, set hyperlink to the chart
+// "Chart 2" in Sheet2:
+//
+//	err := f.SetCellHyperLink("Sheet1", "A3", "Sheet2!Chart 2", "Drawing")
 func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...HyperlinkOpts) error {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
@@ -1030,6 +1521,19 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 			Ref:      cell,
 			Location: link,
 		}
+	case "Drawing":
+		targetSheet, name := sheet, link
+		if idx := strings.LastIndex(link, "!"); idx != -1 {
+			targetSheet, name = strings.Trim(link[:idx], "'"), link[idx+1:]
+		}
+		anchorCell, err := f.getDrawingObjectCell(targetSheet, name)
+		if err != nil {
+			return err
+		}
+		linkData = xlsxHyperlink{
+			Ref:      cell,
+			Location: escapeSheetName(targetSheet) + "!" + anchorCell,
+		}
 	default:
 		return newInvalidLinkTypeError(linkType)
 	}
@@ -1050,6 +1554,36 @@ func (f *File) SetCellHyperLink(sheet, cell, link, linkType string, opts ...Hype
 	return err
 }
 
+// SetCellValueWithAutoLink provides a function to set the value of a cell
+// like SetCellValue, and create the external hyperlink for the cell like the
+// spreadsheet application does on typing, if the value is a string which
+// looks like a web address begins with "http://" or "https://", or an email
+// address with optional "mailto:" scheme. The email address will be linked
+// with "mailto:" scheme. This function only set the hyperlink of the cell and
+// doesn't change the style of the cell. For example:
+//
+//	err := f.SetCellValueWithAutoLink("Sheet1", "A1", "https://github.com/xuri/excelize")
+func (f *File) SetCellValueWithAutoLink(sheet, cell string, value interface{}) error {
+	if err := f.SetCellValue(sheet, cell, value); err != nil {
+		return err
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	str = strings.TrimSpace(str)
+	if autoLinkURLExp.MatchString(str) {
+		return f.SetCellHyperLink(sheet, cell, str, "External")
+	}
+	if autoLinkEmailExp.MatchString(str) {
+		if !strings.HasPrefix(strings.ToLower(str), "mailto:") {
+			str = "mailto:" + str
+		}
+		return f.SetCellHyperLink(sheet, cell, str, "External")
+	}
+	return nil
+}
+
 // getCellRichText returns rich text of cell by given string item.
 func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 	if si.T != nil {
@@ -1181,8 +1715,8 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 }
 
 // SetCellRichText provides a function to set cell with rich text by given
-// worksheet. For example, set rich text on the A1 cell of the worksheet named
-// Sheet1:
+// worksheet. This function is concurrency safe. For example, set rich text on
+// the A1 cell of the worksheet named Sheet1:
 //
 //	package main
 //
@@ -1304,10 +1838,15 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 //	    }
 //	}
 func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
+		f.mu.Unlock()
 		return err
 	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -1324,6 +1863,10 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	if si.R, err = setRichText(runs); err != nil {
 		return err
 	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
 	for idx, strItem := range sst.SI {
 		if reflect.DeepEqual(strItem, si) {
 			c.T, c.V = "s", strconv.Itoa(idx)
@@ -1356,6 +1899,175 @@ func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
 	return f.setSheetCells(sheet, cell, slice, columns)
 }
 
+// SetCellMatrix writes a two-dimensional slice to the worksheet by given
+// worksheet name, the top-left cell reference of the range and the slice of
+// rows. Each element will be set as the SetCellValue function does, and the
+// inner slices may have different lengths, the trailing cells of the shorter
+// rows will be left untouched. All cells will be written under a single
+// worksheet lock, so the progress callback should not access the worksheet.
+// For example, writes a matrix start with the cell B2 on Sheet1:
+//
+//	err := f.SetCellMatrix("Sheet1", "B2", [][]interface{}{
+//	    {"Name", "Score", "Passed"},
+//	    {"Alice", 95.5, true},
+//	    {"Bob", 58},
+//	})
+func (f *File) SetCellMatrix(sheet, topLeftCell string, data [][]interface{}) error {
+	col, row, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	if row+len(data)-1 > TotalRows {
+		return ErrMaxRows
+	}
+	for _, values := range data {
+		if col+len(values)-1 > MaxColumns {
+			return ErrColumnNumber
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	var date1904 bool
+	wb, err := f.workbookReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	// Prepare the rows of the range at once, avoid growing the worksheet
+	// for each cell of the range.
+	for r, values := range data {
+		if len(values) > 0 {
+			ws.prepareSheetXML(col+len(values)-1, row+r)
+		}
+	}
+	for r, values := range data {
+		for c, value := range values {
+			cell, _ := CoordinatesToCellName(col+c, row+r)
+			if err = f.setCellMatrixValue(ws, sheet, cell, value, date1904); err != nil {
+				return err
+			}
+		}
+		f.reportProgress(r+1, len(data))
+	}
+	return err
+}
+
+// setCellMatrixValue provides a function to set the value of a cell as the
+// SetCellValue function does by given worksheet, cell reference and value
+// without locking the worksheet, the caller should hold the worksheet lock.
+func (f *File) setCellMatrixValue(ws *xlsxWorksheet, sheet, cell string, value interface{}, date1904 bool) error {
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S, c.IS = ws.prepareCellStyle(col, row, c.S), nil
+	var numFmtID int
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		err = setCellIntFunc(c, v)
+	case float32:
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
+	case float64:
+		c.T, c.V = setCellFloat(v, -1, 64)
+	case string:
+		c.T, c.V, err = f.setCellString(v)
+	case []byte:
+		c.T, c.V, err = f.setCellString(string(v))
+	case time.Duration:
+		_, d := setCellDuration(v)
+		c.setCellDefault(d)
+		numFmtID = 21
+	case time.Time:
+		var isNum bool
+		if isNum, err = c.setCellTime(v, date1904); isNum {
+			numFmtID = 22
+		}
+	case bool:
+		c.T, c.V = setCellBool(v)
+	case nil:
+		c.setCellDefault("")
+	case fmt.Stringer:
+		c.T, c.V, err = f.setCellString(v.String())
+	case encoding.TextMarshaler:
+		var text []byte
+		if text, err = v.MarshalText(); err != nil {
+			return err
+		}
+		c.T, c.V, err = f.setCellString(string(text))
+	default:
+		c.T, c.V, err = f.setCellString(fmt.Sprint(value))
+	}
+	if err != nil {
+		return err
+	}
+	if numFmtID != 0 && c.S == 0 {
+		if c.S, err = f.NewStyle(&Style{NumFmt: numFmtID}); err != nil {
+			return err
+		}
+	}
+	return f.removeFormula(c, ws, sheet)
+}
+
+// SetRowsFromMaps writes the records to the worksheet by given worksheet name,
+// a slice of records and the header names. The header row will be written
+// into the first row in the given order, and each record will be written into
+// the subsequent rows, the value of each column is looked up in the record by
+// the header name and set as the SetCellValue function does. The cell will be
+// left blank if the record doesn't contain the header name. For example,
+// writes two records with the columns "Name" and "Age" on Sheet1:
+//
+//	err := f.SetRowsFromMaps("Sheet1", []map[string]interface{}{
+//	    {"Name": "Alice", "Age": 30},
+//	    {"Name": "Bob"},
+//	}, []string{"Name", "Age"})
+func (f *File) SetRowsFromMaps(sheet string, records []map[string]interface{}, headers []string) error {
+	if len(headers) == 0 {
+		return ErrParameterRequired
+	}
+	if len(headers) > MaxColumns {
+		return ErrColumnNumber
+	}
+	if len(records)+1 > TotalRows {
+		return ErrMaxRows
+	}
+	if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+		return err
+	}
+	for r, record := range records {
+		for c, header := range headers {
+			value, ok := record[header]
+			if !ok {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(c+1, r+2)
+			if err := f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+		}
+		f.reportProgress(r+1, len(records))
+	}
+	return nil
+}
+
+// reportProgress provides a function to invoke the progress callback in the
+// options with the number of the processed items and the total number of the
+// items for the bulk operations.
+func (f *File) reportProgress(done, total int) {
+	if f.options.ProgressCallback != nil {
+		f.options.ProgressCallback(done, total)
+	}
+}
+
 // setSheetCells provides a function to set worksheet cells value.
 func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
 	col, row, err := CellNameToCoordinates(cell)
@@ -1662,18 +2374,24 @@ func getSharedFormula(ws *xlsxWorksheet, si int, cell string) string {
 				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
 				dCol := col - sharedCol
 				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return shiftFormula(c.F.Content, dCol, dRow)
 			}
 		}
 	}
 	return ""
 }
 
+// shiftFormula returns the formula with the relative references shifted
+// according to the given column and rows distance.
+func shiftFormula(formula string, dCol, dRow int) string {
+	orig := []byte(formula)
+	res, start := parseSharedFormula(dCol, dRow, orig)
+	if start < len(orig) {
+		res += string(orig[start:])
+	}
+	return res
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
 // consideration absolute references with dollar sign ($)
 func shiftCell(cellID string, dCol, dRow int) string {
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	_ "image/jpeg"
 	"math"
@@ -101,6 +102,72 @@ func TestConcurrency(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestConcurrencyMultipleSheets(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4"}
+	for _, sheet := range sheets[1:] {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	wg := new(sync.WaitGroup)
+	for _, sheet := range sheets {
+		wg.Add(1)
+		go func(sheet string) {
+			defer wg.Done()
+			style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+			assert.NoError(t, err)
+			for row := 1; row <= 100; row++ {
+				// Concurrency set shared string, number, boolean and styles on different worksheets
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("%s-%d", sheet, row)))
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("B%d", row), row))
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("C%d", row), "shared"))
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("D%d", row), row%2 == 0))
+				assert.NoError(t, f.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("A%d", row), style))
+				assert.NoError(t, f.SetCellValue(sheet, fmt.Sprintf("E%d", row), time.Date(2024, 1, row%28+1, 0, 0, 0, 0, time.UTC)))
+				assert.NoError(t, f.SetCellRichText(sheet, fmt.Sprintf("F%d", row), []RichTextRun{{Text: "rich", Font: &Font{Italic: true}}}))
+				assert.NoError(t, f.SetCellFormula(sheet, fmt.Sprintf("G%d", row), fmt.Sprintf("B%d*2", row)))
+				assert.NoError(t, f.SetCellFloat(sheet, fmt.Sprintf("H%d", row), float64(row)/4, 2, 64))
+				val, err := f.GetCellValue(sheet, fmt.Sprintf("A%d", row))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("%s-%d", sheet, row), val)
+			}
+		}(sheet)
+	}
+	wg.Wait()
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for _, sheet := range sheets {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 100)
+		for i, row := range rows {
+			assert.Equal(t, []string{
+				fmt.Sprintf("%s-%d", sheet, i+1), strconv.Itoa(i + 1), "shared",
+				strings.ToUpper(strconv.FormatBool((i+1)%2 == 0)),
+				time.Date(2024, 1, (i+1)%28+1, 0, 0, 0, 0, time.UTC).Format("1/2/06 15:04"), "rich",
+				"", strconv.FormatFloat(float64(i+1)/4, 'f', -1, 64),
+			}, row)
+			formula, err := f.GetCellFormula(sheet, fmt.Sprintf("G%d", i+1))
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("B%d*2", i+1), formula)
+		}
+		styleID, err := f.GetCellStyle(sheet, "A100")
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.True(t, style.Font.Bold)
+	}
+	// Test the same shared string and rich text items are not duplicated
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Len(t, sst.SI, len(sheets)*100+2)
+	assert.NoError(t, f.Close())
+}
+
 func TestCheckCellInRangeRef(t *testing.T) {
 	f := NewFile()
 	expectedTrueCellInRangeRefList := [][2]string{
@@ -177,6 +244,41 @@ func TestSetCellFloat(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, f.SetCellFloat("Sheet:1", "A1", 123.42, -1, 64))
 }
 
+func TestSetCellPercent(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellPercent("Sheet1", "A1", 0.256, 1))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "25.6%", val)
+	val, err = f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.256", val)
+	// Test set percent value with built-in percent number formats
+	for decimals, expected := range map[int]string{0: "26%", 2: "25.60%", 3: "25.600%"} {
+		assert.NoError(t, f.SetCellPercent("Sheet1", "A2", 0.256, decimals))
+		val, err = f.GetCellValue("Sheet1", "A2")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	// Test set percent value keeps the other style settings of the cell
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", styleID))
+	assert.NoError(t, f.SetCellPercent("Sheet1", "A3", 0.5, 0))
+	styleID, err = f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, 9, style.NumFmt)
+	// Test set percent value with invalid decimal places
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPercent("Sheet1", "A1", 0.256, -1))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellPercent("Sheet1", "A1", 0.256, 31))
+	// Test set percent value with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellPercent("Sheet1", "A", 0.256, 1))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellUint(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", uint8(math.MaxUint8)))
@@ -279,6 +381,152 @@ func TestSetCellValue(t *testing.T) {
 	assert.Equal(t, "b", val)
 }
 
+type testStringer struct{ name string }
+
+func (s testStringer) String() string { return "stringer: " + s.name }
+
+type testTextMarshaler struct {
+	text string
+	err  error
+}
+
+func (m testTextMarshaler) MarshalText() ([]byte, error) { return []byte(m.text), m.err }
+
+func TestSetCellValueStringerAndTextMarshaler(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", testStringer{name: "A1"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", &testStringer{name: "A2"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", testTextMarshaler{text: "marshaler"}))
+	for cell, expected := range map[string]string{
+		"A1": "stringer: A1", "A2": "stringer: A2", "A3": "marshaler",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test set cell value with text marshaler error
+	assert.Equal(t, ErrParameterInvalid, f.SetCellValue("Sheet1", "A4", testTextMarshaler{err: ErrParameterInvalid}))
+	// Test set cell value in stream writer
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{testStringer{name: "B1"}, testTextMarshaler{text: "B2"}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A2", []interface{}{testTextMarshaler{err: ErrParameterInvalid}}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"stringer: B1", "B2"}}, rows)
+}
+
+func TestSetCellMatrix(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "D4", "untouched"))
+	assert.NoError(t, f.SetCellMatrix("Sheet1", "B2", [][]interface{}{
+		{"Name", 1, true},
+		{2.5, nil, "C3"},
+		{time.Duration(1e13), []byte("C4")},
+		{time.Date(2026, 10, 17, 12, 30, 0, 0, time.UTC), testStringer{name: "C5"}, uint8(6)},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		nil,
+		{"", "Name", "1", "TRUE"},
+		{"", "2.5", "", "C3"},
+		{"", "02:46:40", "C4", "untouched"},
+		{"", "10/17/26 12:30", "stringer: C5", "6"},
+	}, rows)
+	// Test set matrix with empty rows
+	assert.NoError(t, f.SetCellMatrix("Sheet1", "F1", [][]interface{}{nil, {}, {"F3"}}))
+	val, err := f.GetCellValue("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, "F3", val)
+	// Test set matrix with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellMatrix("Sheet1", "A", nil))
+	// Test set matrix exceeds the worksheet limits
+	assert.Equal(t, ErrMaxRows, f.SetCellMatrix("Sheet1", fmt.Sprintf("A%d", TotalRows), [][]interface{}{{1}, {2}}))
+	assert.Equal(t, ErrColumnNumber, f.SetCellMatrix("Sheet1", "XFD1", [][]interface{}{{1, 2}}))
+	// Test set matrix on not exists worksheet
+	assert.EqualError(t, f.SetCellMatrix("SheetN", "A1", [][]interface{}{{1}}), "sheet SheetN does not exist")
+	// Test set matrix with invalid value
+	assert.Equal(t, ErrParameterInvalid, f.SetCellMatrix("Sheet1", "A1", [][]interface{}{{testTextMarshaler{err: ErrParameterInvalid}}}))
+	// Test set matrix with invalid merged cell
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellMatrix("Sheet1", "A1", [][]interface{}{{1}}))
+	ws.(*xlsxWorksheet).MergeCells = nil
+	// Test set matrix with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMatrix("Sheet1", "A1", [][]interface{}{{1}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test set matrix with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMatrix("Sheet1", "A1", [][]interface{}{{time.Duration(1e13)}}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set matrix with unsupported charset shared string table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellMatrix("Sheet1", "A1", [][]interface{}{{"A1"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetRowsFromMaps(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowsFromMaps("Sheet1", []map[string]interface{}{
+		{"Name": "Alice", "Age": 30, "Passed": true},
+		{"Name": "Bob", "Passed": false, "Unused": "ignored"},
+		{"Age": 25.5},
+	}, []string{"Name", "Age", "Passed"}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Age", "Passed"},
+		{"Alice", "30", "TRUE"},
+		{"Bob", "", "FALSE"},
+		{"", "25.5"},
+	}, rows)
+	// Test the missing keys leave the cells blank
+	cellType, err := f.GetCellType("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	cellType, err = f.GetCellType("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	// Test set rows from maps without headers
+	assert.Equal(t, ErrParameterRequired, f.SetRowsFromMaps("Sheet1", nil, nil))
+	// Test set rows from maps exceeds maximum limit
+	assert.Equal(t, ErrColumnNumber, f.SetRowsFromMaps("Sheet1", nil, make([]string, MaxColumns+1)))
+	assert.Equal(t, ErrMaxRows, f.SetRowsFromMaps("Sheet1", make([]map[string]interface{}, TotalRows), []string{"Name"}))
+	// Test set rows from maps on not exists worksheet
+	assert.EqualError(t, f.SetRowsFromMaps("SheetN", nil, []string{"Name"}), "sheet SheetN does not exist")
+	// Test set rows from maps with invalid value
+	assert.Equal(t, ErrParameterInvalid, f.SetRowsFromMaps("Sheet1", []map[string]interface{}{{"Name": testTextMarshaler{err: ErrParameterInvalid}}}, []string{"Name"}))
+}
+
+func TestBulkOperationsProgressCallback(t *testing.T) {
+	var done, total []int
+	f := NewFile(Options{ProgressCallback: func(d, t int) {
+		done, total = append(done, d), append(total, t)
+	}})
+	records := make([]map[string]interface{}, 100)
+	for i := range records {
+		records[i] = map[string]interface{}{"ID": i + 1}
+	}
+	assert.NoError(t, f.SetRowsFromMaps("Sheet1", records, []string{"ID"}))
+	assert.Len(t, done, len(records))
+	for i := range done {
+		assert.Equal(t, i+1, done[i])
+		assert.Equal(t, len(records), total[i])
+	}
+	done, total = nil, nil
+	assert.NoError(t, f.SetCellMatrix("Sheet1", "C1", [][]interface{}{{1, 2}, {3}, {}}))
+	assert.Equal(t, []int{1, 2, 3}, done)
+	assert.Equal(t, []int{3, 3, 3}, total)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellValues(t *testing.T) {
 	f := NewFile()
 	err := f.SetCellValue("Sheet1", "A1", time.Date(2010, time.December, 31, 0, 0, 0, 0, time.UTC))
@@ -297,6 +545,113 @@ func TestSetCellValues(t *testing.T) {
 	assert.Equal(t, v, "1600-12-31T00:00:00Z")
 }
 
+func TestSetCellTextPrefixed(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	assert.NoError(t, f.SetCellTextPrefixed("Sheet1", "A1", "=SUM(B1:B2)"))
+	// Test set quote prefixed text on the cell already has the quote prefix style
+	assert.NoError(t, f.SetCellTextPrefixed("Sheet1", "A1", "=SUM(B1:B2)"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(B1:B2)", val)
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.QuotePrefix)
+	assert.True(t, style.Font.Bold)
+	// Test set quote prefixed text with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellTextPrefixed("Sheet1", "A", "=A1"))
+	// Test set quote prefixed text with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	assert.Equal(t, newInvalidStyleID(100), f.SetCellTextPrefixed("Sheet1", "A1", "=A1"))
+	// Test set quote prefixed text with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellTextPrefixed("Sheet1", "A1", "=A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellCheckbox(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A2", false))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "TRUE", "A2": "FALSE"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeBool, cellType)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.True(t, style.Checkbox)
+	}
+	_, ok := f.Pkg.Load(defaultXMLPathFeaturePropBag)
+	assert.True(t, ok)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var found bool
+	for _, override := range contentTypes.Overrides {
+		if override.PartName == "/"+defaultXMLPathFeaturePropBag {
+			found = override.ContentType == ContentTypeFeaturePropertyBag
+		}
+	}
+	assert.True(t, found)
+	// Test set checkbox with existing checkbox feature property bags
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "B1", true))
+	content, ok := f.Pkg.Load(defaultXMLPathFeaturePropBag)
+	assert.True(t, ok)
+	assert.Equal(t, xml.Header+templateFeaturePropertyBag, string(content.([]byte)))
+	// Test set checkbox with existing feature property bags without checkbox
+	g := NewFile()
+	g.Pkg.Store(defaultXMLPathFeaturePropBag, []byte(xml.Header+`<FeaturePropertyBags xmlns="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><bag type="Other"/><bag type="XFComplements" extRef="XFComplementsMapperExtRef"><a k="MappedFeaturePropertyBags"><bagId>0</bagId></a></bag></FeaturePropertyBags>`))
+	assert.NoError(t, g.SetCellCheckbox("Sheet1", "A1", true))
+	content, ok = g.Pkg.Load(defaultXMLPathFeaturePropBag)
+	assert.True(t, ok)
+	assert.Equal(t, xml.Header+`<FeaturePropertyBags xmlns="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><bag type="Other"></bag><bag type="XFComplements" extRef="XFComplementsMapperExtRef"><a k="MappedFeaturePropertyBags"><bagId>0</bagId><bagId>4</bagId></a></bag><bag type="Checkbox"></bag><bag type="XFControls"><bagId k="CellControl">2</bagId></bag><bag type="XFComplement"><bagId k="XFControls">3</bagId></bag></FeaturePropertyBags>`, string(content.([]byte)))
+	styles, err := g.stylesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, styles.CellXfs.Xf[len(styles.CellXfs.Xf)-1].ExtLst.Ext, `<xfpb:xfComplement i="1"/>`)
+	// Test set checkbox with unsupported charset feature property bags
+	g.Styles = nil
+	g.Pkg.Store(defaultXMLPathStyles, []byte(xml.Header+templateStyles))
+	g.Pkg.Store(defaultXMLPathFeaturePropBag, MacintoshCyrillicCharset)
+	assert.EqualError(t, g.SetCellCheckbox("Sheet1", "B1", true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, g.Close())
+	// Test set checkbox with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellCheckbox("Sheet1", "A", true))
+	// Test set checkbox with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A1", true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellBool(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellBool("Sheet1", "A", true))
@@ -520,6 +875,70 @@ func TestGetValueFrom(t *testing.T) {
 	assert.Equal(t, "s", value)
 }
 
+func TestGetCellValueFloatPrecision(t *testing.T) {
+	f := NewFile()
+	a, b := 0.1, 0.2
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", a+b))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 123.456789))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 123.456789))
+	numFmt := "0.000000"
+	style, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", style))
+	for _, expected := range []struct {
+		precision int
+		values    []string
+	}{
+		{0, []string{"0.3", "123.456789", "123.456789"}},
+		{15, []string{"0.3", "123.456789", "123.456789"}},
+		{17, []string{"0.30000000000000004", "123.456789", "123.456789"}},
+		{5, []string{"0.3", "123.46", "123.456789"}},
+		{18, []string{"0.3", "123.456789", "123.456789"}},
+	} {
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		f, err := OpenReader(buf, Options{FloatPrecision: expected.precision})
+		assert.NoError(t, err)
+		for i, value := range expected.values {
+			cell := fmt.Sprintf("A%d", i+1)
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, value, val, cell)
+		}
+		// Test get raw cell value without the float precision
+		val, err := f.GetCellValue("Sheet1", "A1", Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "0.30000000000000004", val)
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestGetCellValueSeparators(t *testing.T) {
+	f := NewFile(Options{DecimalSeparator: ",", ThousandsSeparator: "."})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1234.56))
+	style, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.234,56", val)
+	// Test calculate the TEXT function with the specified separators
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "TEXT(A1,\"#,##0.0\")"))
+	val, err = f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.234,6", val)
+	// Test get cell value with the default separators
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1,234.56", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetCellFormula(t *testing.T) {
 	// Test get cell formula on not exist worksheet
 	f := NewFile()
@@ -623,6 +1042,128 @@ func TestOverflowNumericCell(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetSpillRange(t *testing.T) {
+	f := NewFile()
+	formulaType, ref := STCellFormulaTypeArray, "B2:B6"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "_xlfn.SEQUENCE(5)", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	legacyRef := "D1:D2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1:A2*2", FormulaOpts{Type: &formulaType, Ref: &legacyRef}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].Cm = uintPtr(1)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for _, cell := range []string{"B2", "B4", "B6"} {
+		anchorCell, spillRange, isSpill, err := f.GetSpillRange("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, isSpill, cell)
+		assert.Equal(t, "B2", anchorCell, cell)
+		assert.Equal(t, "B2:B6", spillRange, cell)
+	}
+	// Test get spill range on the cells which not in the spill range
+	for _, cell := range []string{"A1", "B1", "B7", "C3", "D1"} {
+		anchorCell, spillRange, isSpill, err := f.GetSpillRange("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, isSpill, cell)
+		assert.Empty(t, anchorCell, cell)
+		assert.Empty(t, spillRange, cell)
+	}
+	// Test get spill range with invalid cell reference
+	_, _, _, err = f.GetSpillRange("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get spill range on not exists worksheet
+	_, _, _, err = f.GetSpillRange("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get spill range with invalid spill range reference
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].F.Ref = "B2"
+	_, _, isSpill, err := f.GetSpillRange("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.False(t, isSpill)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCellMetadata(t *testing.T) {
+	const (
+		contentTypeSheetMetadata        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
+		sourceRelationshipSheetMetadata = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+		xmlPathMetadata                 = "xl/metadata.xml"
+	)
+	f := NewFile()
+	metadata := []byte(xml.Header + `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"><metadataTypes count="1"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/></metadataTypes><futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata></metadata>`)
+	assert.NoError(t, f.setContentTypes("/"+xmlPathMetadata, contentTypeSheetMetadata))
+	f.addRels(f.getWorkbookRelsPath(), sourceRelationshipSheetMetadata, "metadata.xml", "")
+	f.Pkg.Store(xmlPathMetadata, metadata)
+	formulaType, ref := STCellFormulaTypeArray, "A1:A3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "_xlfn.SEQUENCE(3)", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].Cm = uintPtr(1)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// Test edit an unrelated cell of the workbook with dynamic arrays
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Excelize"))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	content, ok := f.Pkg.Load(xmlPathMetadata)
+	assert.True(t, ok)
+	assert.Equal(t, metadata, content)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Overrides, xlsxOverride{PartName: "/" + xmlPathMetadata, ContentType: contentTypeSheetMetadata})
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	var hasMetadataRel bool
+	for _, rel := range rels.Relationships {
+		hasMetadataRel = hasMetadataRel || (rel.Type == sourceRelationshipSheetMetadata && rel.Target == "metadata.xml")
+	}
+	assert.True(t, hasMetadataRel)
+	valueMeta, cellMeta, err := f.GetCellMetadata("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, valueMeta)
+	assert.Equal(t, 1, cellMeta)
+	anchorCell, spillRange, isSpill, err := f.GetSpillRange("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.True(t, isSpill)
+	assert.Equal(t, "A1", anchorCell)
+	assert.Equal(t, "A1:A3", spillRange)
+	// Test get metadata indexes of the cells without metadata
+	for _, cell := range []string{"C1", "B1", "A10"} {
+		valueMeta, cellMeta, err = f.GetCellMetadata("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, valueMeta, cell)
+		assert.Equal(t, 0, cellMeta, cell)
+	}
+	// Test get metadata indexes of the rich value cell
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[2].Vm = uintPtr(2)
+	valueMeta, cellMeta, err = f.GetCellMetadata("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, valueMeta)
+	assert.Equal(t, 0, cellMeta)
+	// Test get metadata indexes with invalid cell reference
+	_, _, err = f.GetCellMetadata("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get metadata indexes on not exists worksheet
+	_, _, err = f.GetCellMetadata("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetCellFormula(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -690,6 +1231,189 @@ func TestSetCellFormula(t *testing.T) {
 	assert.Equal(t, ErrColumnNumber, f.SetCellFormula("Sheet1", "A1", "SUM(XFE1:XFE2)", FormulaOpts{Ref: &ref, Type: &formulaType}))
 }
 
+func TestCopyRange(t *testing.T) {
+	prepare := func() (*File, int) {
+		f := NewFile()
+		_, err := f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, "text"}))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM(A1:B1)+$A$1"))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1&\"A1\""))
+		assert.NoError(t, f.UpdateLinkedValue())
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		ws.(*xlsxWorksheet).SheetData.Row[1].C[0].V = "4"
+		ws.(*xlsxWorksheet).SheetData.Row[1].C[1].V = "1A1"
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B2", style))
+		return f, style
+	}
+	// Test copy range in all mode
+	f, style := prepare()
+	assert.NoError(t, f.SetCellValue("Sheet2", "D4", "value"))
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:C2", "Sheet2", "C3", CopyOptions{}))
+	for cell, expected := range map[string]string{"C3": "1", "D3": "2", "E3": "text", "D4": "1A1"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]string{"C4": "SUM(C3:D3)+$A$1", "D4": "C3&\"A1\"", "C3": ""} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet2", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test copy range in values mode
+	f, _ = prepare()
+	assert.NoError(t, f.SetCellFormula("Sheet2", "B2", "1+1"))
+	assert.NoError(t, f.CopyRange("Sheet1", "A2:B2", "Sheet2", "A2", CopyOptions{Mode: "values"}))
+	for cell, expected := range map[string]string{"A2": "4", "B2": "1A1"} {
+		val, err := f.GetCellValue("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	cellType, err := f.GetCellType("Sheet2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	styleID, err = f.GetCellStyle("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test copy range in formats mode
+	f, style = prepare()
+	assert.NoError(t, f.SetCellValue("Sheet2", "B2", "value"))
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:B2", "Sheet2", "A1", CopyOptions{Mode: "formats"}))
+	for _, cell := range []string{"A1", "B1", "A2", "B2"} {
+		styleID, err := f.GetCellStyle("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+	val, err := f.GetCellValue("Sheet2", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+	val, err = f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test copy range in formulas mode on the same worksheet
+	f, _ = prepare()
+	assert.NoError(t, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "B4", CopyOptions{Mode: "formulas"}))
+	formula, err := f.GetCellFormula("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B4:C4)+$A$1", formula)
+	val, err = f.GetCellValue("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val)
+	styleID, err = f.GetCellStyle("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	result, err := f.CalcCellValue("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	// Test copy range with shared formula
+	f = NewFile()
+	formulaType, ref := STCellFormulaTypeShared, "B1:B3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.CopyRange("Sheet1", "B3", "Sheet1", "D1", CopyOptions{Mode: "formulas"}))
+	formula, err = f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C1*2", formula)
+	// Test copy range with invalid mode
+	assert.Equal(t, ErrParameterInvalid, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "D1", CopyOptions{Mode: "comments"}))
+	// Test copy range with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.CopyRange("Sheet1", "A:B2", "Sheet1", "D1", CopyOptions{}))
+	// Test copy range with invalid destination cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")), f.CopyRange("Sheet1", "A1:B2", "Sheet1", "D", CopyOptions{}))
+	// Test copy range exceeds maximum limit
+	assert.Equal(t, ErrColumnNumber, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "XFD1", CopyOptions{}))
+	assert.Equal(t, ErrMaxRows, f.CopyRange("Sheet1", "A1:B2", "Sheet1", "A1048576", CopyOptions{}))
+	// Test copy range on not exists worksheet
+	assert.EqualError(t, f.CopyRange("SheetN", "A1:B2", "Sheet1", "D1", CopyOptions{}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.CopyRange("Sheet1", "A1:B2", "SheetN", "D1", CopyOptions{}), "sheet SheetN does not exist")
+	// Test copy range with unsupported charset shared strings table
+	f, _ = prepare()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopyRange("Sheet1", "B2", "Sheet2", "A1", CopyOptions{Mode: "values"}), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestFillSeries(t *testing.T) {
+	f := NewFile()
+	// Test fill a column with the number series
+	assert.NoError(t, f.FillSeries("Sheet1", "A10:A1", 1, 1))
+	for row := 1; row <= 10; row++ {
+		val, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", row))
+		assert.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(row), val)
+		cellType, err := f.GetCellType("Sheet1", fmt.Sprintf("A%d", row))
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeUnset, cellType)
+	}
+	// Test fill a column with the date series by one month step
+	assert.NoError(t, f.FillSeries("Sheet1", "B1:B6", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), 1, FillSeriesOptions{DateUnit: "month"}))
+	for row, expected := range []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31", "2024-06-30"} {
+		val, err := f.GetCellValue("Sheet1", fmt.Sprintf("B%d", row+1), Options{RawCellValue: true})
+		assert.NoError(t, err)
+		serial, err := strconv.ParseFloat(val, 64)
+		assert.NoError(t, err)
+		date, err := ExcelDateToTime(serial, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, date.Format("2006-01-02"))
+	}
+	// Test fill a range with the float number series down then across and
+	// across then down
+	assert.NoError(t, f.FillSeries("Sheet1", "C1:D2", 0.1, 0.1))
+	assert.NoError(t, f.FillSeries("Sheet1", "E1:F2", 10, -2.5, FillSeriesOptions{ByRow: true}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.1", "0.3", "10", "7.5"}, rows[0][2:6])
+	assert.Equal(t, []string{"0.2", "0.4", "5", "2.5"}, rows[1][2:6])
+	// Test fill dates by other date units and duration step
+	for _, c := range []struct {
+		start    time.Time
+		step     interface{}
+		unit     string
+		expected []time.Time
+	}{
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 1, "year", []time.Time{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), -1, "month", []time.Time{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), -2, "month", []time.Time{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC), time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), 2, "weekday", []time.Time{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), -6, "weekday", []time.Time{time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), 1, "weekday", []time.Time{time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), -1, "weekday", []time.Time{time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), uint8(7), "Day", []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}},
+		{time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), 6 * time.Hour, "", []time.Time{time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)}},
+	} {
+		seriesValue, err := prepareFillSeries(c.start, c.step, c.unit)
+		assert.NoError(t, err)
+		for i, expected := range c.expected {
+			assert.Equal(t, expected, seriesValue(i), c.unit)
+		}
+	}
+	// Test fill series with invalid parameters
+	for _, c := range []struct {
+		start, step interface{}
+		unit        string
+	}{
+		{time.Now(), 1.5, "day"},
+		{time.Now(), "1", "day"},
+		{time.Now(), 1, "quarter"},
+		{"1", 1, ""},
+		{1, nil, ""},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.FillSeries("Sheet1", "A1:A2", c.start, c.step, FillSeriesOptions{DateUnit: c.unit}))
+	}
+	// Test fill series with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.FillSeries("Sheet1", "A", 1, 1))
+	// Test fill series on not exists worksheet
+	assert.EqualError(t, f.FillSeries("SheetN", "A1", 1, 1), "sheet SheetN does not exist")
+}
+
 func TestGetCellRichText(t *testing.T) {
 	f, theme := NewFile(), 1
 
@@ -1027,6 +1751,96 @@ func TestGetCustomNumFmtCode(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestGetCellValueLazySharedStrings(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	expected, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LazySharedStrings: true})
+	assert.NoError(t, err)
+	// Test get rows, columns and search the worksheet without parsing the
+	// whole shared string table
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected[18][0], cols[0][18])
+	result, err := f.SearchSheet("Sheet1", expected[18][0])
+	assert.NoError(t, err)
+	assert.Contains(t, result, "A19")
+	assert.Nil(t, f.SharedStrings)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LazySharedStrings: true})
+	assert.NoError(t, err)
+	// Test get cell values in reverse order to decode the whole table at once
+	for r := len(expected); r > 0; r-- {
+		for c := len(expected[r-1]); c > 0; c-- {
+			cell, err := CoordinatesToCellName(c, r)
+			assert.NoError(t, err)
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[r-1][c-1], val, cell)
+		}
+	}
+	assert.Nil(t, f.SharedStrings)
+	// Test get cell value with invalid shared string index
+	assert.NoError(t, f.SetCellDefault("Sheet1", "A1", "1000"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].T = "s"
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", val)
+	// Test get cell value after the shared string table has been changed
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "new string"))
+	assert.NotNil(t, f.SharedStrings)
+	assert.Nil(t, f.sharedStringLazy)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "new string", val)
+	val, err = f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, expected[18][0], val)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "1000"
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", val)
+	assert.NoError(t, f.Close())
+
+	// Test get cell value with invalid shared string table
+	f = NewFile(Options{LazySharedStrings: true})
+	f.Pkg.Store(defaultXMLPathSharedStrings, []byte(`<sst><si><t>a</t></si><si><t>b</si></sst>`))
+	assert.NoError(t, f.SetCellDefault("Sheet1", "A1", "1"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].T = "s"
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <t> closed by </si>")
+	assert.Equal(t, []string{"a"}, f.sharedStringLazy)
+	rowsIter, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rowsIter.Next())
+	_, err = rowsIter.Columns()
+	assert.EqualError(t, err, "XML syntax error on line 1: element <t> closed by </si>")
+	assert.NoError(t, rowsIter.Close())
+	colsIter, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, colsIter.Next())
+	_, err = colsIter.Rows()
+	assert.EqualError(t, err, "XML syntax error on line 1: element <t> closed by </si>")
+	_, err = f.SearchSheet("Sheet1", "b")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <t> closed by </si>")
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "0"
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", val)
+	assert.NoError(t, f.Close())
+}
+
 func TestSharedStringsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
 	assert.NoError(t, err)
@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -527,6 +529,11 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
+	for _, series := range opts.Series {
+		if symbol := series.Marker.Symbol; symbol != "" && inStrSlice(supportedChartMarkerSymbols, symbol, true) == -1 {
+			return opts, newInvalidChartMarkerSymbolError(symbol)
+		}
+	}
 	return opts, nil
 }
 
@@ -693,6 +700,7 @@ func (opts *Chart) parseTitle() {
 //	Line
 //	Marker
 //	DataLabelPosition
+//	PointExplosion
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -718,9 +726,13 @@ func (opts *Chart) parseTitle() {
 // can be set are width and color. The range of width is 0.25pt - 999pt. If the
 // value of width is outside the range, the default width of the line is 2pt.
 //
-// Marker: This sets the marker of the line chart and scatter chart. The range
-// of optional field 'Size' is 2-72 (default value is 5). The enumeration value
-// of optional field 'Symbol' are (default value is 'auto'):
+// Marker: This sets the marker of the line chart and scatter chart for each
+// series. The range of optional field 'Size' is 2-72 (default value is 5), if
+// the value of size is outside the range, the default size will be used. The
+// markers of the series will be hidden if the 'Symbol' is 'none'. An error
+// will be returned if the 'Symbol' is not one of the following enumeration
+// values. The enumeration value of optional field 'Symbol' are (default value
+// is 'auto'):
 //
 //	circle
 //	dash
@@ -737,6 +749,12 @@ func (opts *Chart) parseTitle() {
 //
 // DataLabelPosition: This sets the position of the chart series data label.
 //
+// PointExplosion: This sets the explosion percentage of the data points for
+// the pie, 3D pie, doughnut, pie of pie and bar of pie charts, the key of the
+// map is the zero-based index of the data point in the series, and the value
+// is the distance of the data point pulled out from the center of the chart
+// as a percentage of the radius. The 'PointExplosion' property is optional.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
@@ -777,6 +795,38 @@ func (opts *Chart) parseTitle() {
 //
 // zero: Specifies that blank values shall be treated as zero.
 //
+// Specifies the grouping of the bar, column, area and line chart by
+// 'Grouping'. The default grouping is determined by the chart type, and the
+// options that can be set are:
+//
+//	clustered
+//	percentStacked
+//	stacked
+//	standard
+//
+// clustered: Specifies that the chart series are drawn next to each other,
+// which same as 'standard' for the area and line chart.
+//
+// percentStacked: Specifies that the chart series are drawn next to each
+// other and stacked to 100 percent.
+//
+// stacked: Specifies that the chart series are drawn stacked.
+//
+// standard: Specifies that the chart series are drawn on the value axis, which
+// only works for the area and line chart.
+//
+// Specifies the space between the bar or column clusters as a percentage of
+// the bar or column width by 'GapWidth'. The range of 'GapWidth' is 0-500,
+// and the default value is 150 if it isn't supplied or outside the range.
+//
+// Specifies how much the bars or columns in the cluster overlap as a
+// percentage of the bar or column width by 'Overlap', which only works for the
+// 2D bar and column chart. The range of 'Overlap' is -100-100, and the default
+// value is 0 for the clustered chart and 100 for the stacked chart, if it
+// isn't supplied or outside the range. Both 'GapWidth' and 'Overlap' are
+// pointers since zero is a valid setting for them, leave it nil to use the
+// default value.
+//
 // Specifies that each data marker in the series has a different color by
 // 'VaryColors'. The default value is true.
 //
@@ -826,6 +876,8 @@ func (opts *Chart) parseTitle() {
 //	None
 //	MajorGridLines
 //	MinorGridLines
+//	MajorTickMark
+//	MinorTickMark
 //	TickLabelSkip
 //	ReverseOrder
 //	Maximum
@@ -839,6 +891,8 @@ func (opts *Chart) parseTitle() {
 //	None
 //	MajorGridLines
 //	MinorGridLines
+//	MajorTickMark
+//	MinorTickMark
 //	MajorUnit
 //	Secondary
 //	ReverseOrder
@@ -855,6 +909,19 @@ func (opts *Chart) parseTitle() {
 //
 // MinorGridLines: Specifies minor grid lines.
 //
+// MajorTickMark: Specifies the major tick marks of the axis. The
+// 'MajorTickMark' property is optional. The default value is 'none'. The
+// possible values for this property are:
+//
+//	cross
+//	in
+//	none
+//	out
+//
+// MinorTickMark: Specifies the minor tick marks of the axis. The
+// 'MinorTickMark' property is optional, and the possible values are same as
+// the 'MajorTickMark'. The default value is 'none'.
+//
 // MajorUnit: Specifies the distance between major ticks. Shall contain a
 // positive floating-point number. The 'MajorUnit' property is optional. The
 // default value is auto.
@@ -1149,6 +1216,186 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	return err
 }
 
+// GetChartData provides a function to get the data plotted by the chart by
+// given worksheet name and cell reference of the top-left cell of the chart
+// anchor. This function resolves the referenced ranges of the chart series in
+// the workbook, returns the category labels and the numeric values of each
+// series. The categories of the first series which contains the categories
+// will be used as the category labels of the chart. For the scatter and
+// bubble charts, the X values will be returned as the category labels. For
+// example, get the data of the chart anchored at the cell E1 in Sheet1:
+//
+//	data, err := f.GetChartData("Sheet1", "E1")
+func (f *File) GetChartData(sheet, cell string) (ChartData, error) {
+	var data ChartData
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return data, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return data, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return data, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	rID, err := f.getChartRID(col-1, row-1, drawingXML)
+	if err != nil || rID == "" {
+		return data, err
+	}
+	drawRel := f.getDrawingRelationships(drawingRelationships, rID)
+	if drawRel == nil {
+		return data, err
+	}
+	chartXML := strings.TrimPrefix(strings.ReplaceAll(drawRel.Target, "..", "xl"), "/")
+	var chartSpace decodeChartSpace
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(chartXML)))).
+		Decode(&chartSpace); err != nil && err != io.EOF {
+		return data, err
+	}
+	for _, chart := range chartSpace.Chart.PlotArea.Charts {
+		for _, ser := range chart.Ser {
+			var series ChartSeriesData
+			names, err := f.getChartDataRefValues(sheet, ser.Tx, false)
+			if err != nil {
+				return data, err
+			}
+			series.Name = strings.Join(names, " ")
+			cat, val := ser.Cat, ser.Val
+			if ser.XVal != nil || ser.YVal != nil {
+				cat, val = ser.XVal, ser.YVal
+			}
+			if data.Categories == nil {
+				if data.Categories, err = f.getChartDataRefValues(sheet, cat, false); err != nil {
+					return data, err
+				}
+			}
+			values, err := f.getChartDataRefValues(sheet, val, true)
+			if err != nil {
+				return data, err
+			}
+			for _, value := range values {
+				num, _ := strconv.ParseFloat(value, 64)
+				series.Values = append(series.Values, num)
+			}
+			data.Series = append(data.Series, series)
+		}
+	}
+	return data, err
+}
+
+// getChartRID provides a function to get the relationship ID of the chart
+// anchored at the given zero-based coordinates in the drawing part.
+func (f *File) getChartRID(col, row int, drawingXML string) (string, error) {
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return "", err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchor := range wsDr.getCellAnchors() {
+		if anchor.Pic != nil || anchor.GraphicFrame == "" {
+			continue
+		}
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return "", err
+		}
+		if deCellAnchor.GraphicFrame == nil || deCellAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		if anchor.From != nil && anchor.From.Col == col && anchor.From.Row == row ||
+			deCellAnchor.From != nil && deCellAnchor.From.Col == col && deCellAnchor.From.Row == row {
+			return deCellAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID, nil
+		}
+	}
+	return "", nil
+}
+
+// getChartDataRefValues provides a function to get the cell values of the
+// chart series data reference. The cached values of the chart will be
+// returned if the data reference doesn't contain a cell reference, and the
+// formula text will be returned if there are no cached values, such as the
+// literal series name.
+func (f *File) getChartDataRefValues(sheet string, ref *decodeChartDataRef, raw bool) ([]string, error) {
+	var (
+		formula string
+		pts     []*cPt
+		values  []string
+	)
+	if ref == nil {
+		return values, nil
+	}
+	if ref.StrRef != nil {
+		if formula = ref.StrRef.F; ref.StrRef.StrCache != nil {
+			pts = ref.StrRef.StrCache.Pt
+		}
+	}
+	if ref.NumRef != nil {
+		if formula = ref.NumRef.F; ref.NumRef.NumCache != nil {
+			pts = ref.NumRef.NumCache.Pt
+		}
+	}
+	for _, pt := range pts {
+		if pt.V != nil {
+			values = append(values, *pt.V)
+		}
+	}
+	if ref.V != "" {
+		values = append(values, ref.V)
+	}
+	if formula == "" {
+		return values, nil
+	}
+	if !strings.Contains(formula, "!") {
+		if refTo := f.getDefinedNameRefTo(formula, sheet); refTo != "" {
+			formula = refTo
+		}
+	}
+	idx := strings.LastIndex(formula, "!")
+	if idx == -1 {
+		if len(values) == 0 {
+			values = append(values, formula)
+		}
+		return values, nil
+	}
+	values = nil
+	refSheet := strings.ReplaceAll(strings.Trim(formula[:idx], "'"), "''", "'")
+	cells := strings.Split(strings.ReplaceAll(formula[idx+1:], "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[1])
+	if err != nil {
+		return values, err
+	}
+	_ = sortCoordinates(coordinates)
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			cell, _ := CoordinatesToCellName(c, r)
+			var value string
+			if raw {
+				value, err = f.GetCellValue(refSheet, cell, Options{RawCellValue: true})
+			} else {
+				value, err = f.GetCellValue(refSheet, cell)
+			}
+			if err != nil {
+				return values, err
+			}
+			values = append(values, value)
+		}
+	}
+	return values, err
+}
+
 // countCharts provides a function to get chart files count storage in the
 // folder xl/charts.
 func (f *File) countCharts() int {
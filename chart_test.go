@@ -316,6 +316,210 @@ func TestAddChart(t *testing.T) {
 	assert.EqualError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}, Title: []RichTextRun{{Text: "2D Column Chart"}}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddChartSeriesMarker(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{{"X", 1, 2, 3}, {"Y1", 2, 4, 6}, {"Y2", 3, 5, 7}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &data))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Scatter,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Marker: ChartMarker{Symbol: "square", Size: 10}},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Marker: ChartMarker{Symbol: "triangle", Size: 80}},
+		},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Marker: ChartMarker{Symbol: "none", Size: 10}},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		},
+	}))
+	// Test add chart with invalid series marker symbol
+	assert.Equal(t, newInvalidChartMarkerSymbolError("unknown"), f.AddChart("Sheet1", "E40", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Marker: ChartMarker{Symbol: "unknown"}}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSeriesMarker.xlsx")))
+	for chartXML, expected := range map[string][]*cMarker{
+		"xl/charts/chart1.xml": {
+			{Symbol: &attrValString{Val: stringPtr("square")}, Size: &attrValInt{Val: intPtr(10)}},
+			{Symbol: &attrValString{Val: stringPtr("triangle")}, Size: &attrValInt{Val: intPtr(5)}},
+		},
+		"xl/charts/chart2.xml": {
+			{Symbol: &attrValString{Val: stringPtr("none")}},
+			{Size: &attrValInt{Val: intPtr(5)}},
+		},
+	} {
+		content, ok := f.Pkg.Load(chartXML)
+		assert.True(t, ok)
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		plotArea := chartSpace.Chart.PlotArea
+		charts := plotArea.ScatterChart
+		if charts == nil {
+			charts = plotArea.LineChart
+		}
+		for i, ser := range *charts.Ser {
+			assert.Equal(t, expected[i].Symbol, ser.Marker.Symbol, chartXML)
+			assert.Equal(t, expected[i].Size, ser.Marker.Size, chartXML)
+		}
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartAxisTickMarks(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{{"X", 1, 2, 3}, {"Y", 2, 4, 6}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &data))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+		XAxis:  ChartAxis{MajorTickMark: "out", MinorTickMark: "cross"},
+		YAxis:  ChartAxis{MajorGridLines: true, MinorGridLines: false, MajorTickMark: "out", MinorTickMark: "unknown"},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartAxisTickMarks.xlsx")))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	catAx, valAx := chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "out", *catAx.MajorTickMark.Val)
+	assert.Equal(t, "cross", *catAx.MinorTickMark.Val)
+	assert.Nil(t, catAx.MajorGridlines)
+	assert.Nil(t, catAx.MinorGridlines)
+	assert.Equal(t, "out", *valAx.MajorTickMark.Val)
+	assert.Equal(t, "none", *valAx.MinorTickMark.Val)
+	assert.NotNil(t, valAx.MajorGridlines)
+	assert.Nil(t, valAx.MinorGridlines)
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartGrouping(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{{"X", 1, 2, 3}, {"Y1", 2, 4, 6}, {"Y2", 3, 5, 7}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &data))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	for i, c := range []struct {
+		opts     *Chart
+		grouping string
+		overlap  *attrValInt
+	}{
+		{opts: &Chart{Type: Area, Series: series, Grouping: "percentStacked"}, grouping: "percentStacked"},
+		{opts: &Chart{Type: Area, Series: series, Grouping: "clustered"}, grouping: "standard"},
+		{opts: &Chart{Type: Line, Series: series, Grouping: "stacked"}, grouping: "stacked"},
+		{opts: &Chart{Type: Line3D, Series: series, Grouping: "percentStacked"}, grouping: "percentStacked"},
+		{opts: &Chart{Type: Col, Series: series, Grouping: "stacked"}, grouping: "stacked", overlap: &attrValInt{Val: intPtr(100)}},
+		{opts: &Chart{Type: BarStacked, Series: series, Grouping: "clustered"}, grouping: "clustered"},
+		{opts: &Chart{Type: BarStacked, Series: series, Grouping: "standard"}, grouping: "stacked", overlap: &attrValInt{Val: intPtr(100)}},
+		{opts: &Chart{Type: Col3DClustered, Series: series, Grouping: "percentStacked"}, grouping: "percentStacked"},
+		{opts: &Chart{Type: AreaStacked, Series: series}, grouping: "stacked"},
+	} {
+		assert.NoError(t, f.AddChart("Sheet1", fmt.Sprintf("E%d", i*20+1), c.opts))
+		content, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", i+1))
+		assert.True(t, ok)
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		plotArea := chartSpace.Chart.PlotArea
+		for _, charts := range []*cCharts{plotArea.AreaChart, plotArea.LineChart, plotArea.Line3DChart, plotArea.BarChart, plotArea.Bar3DChart} {
+			if charts != nil {
+				assert.Equal(t, c.grouping, *charts.Grouping.Val)
+				assert.Equal(t, c.overlap, charts.Overlap)
+			}
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartGrouping.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartGapWidthAndOverlap(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{{"X", 1, 2, 3}, {"Y1", 2, 4, 6}, {"Y2", 3, 5, 7}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &data))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	gapWidth, invalidGapWidth, negativeGapWidth, overlap, invalidOverlap := 50, 501, -1, 20, -101
+	for i, c := range []struct {
+		opts              *Chart
+		gapWidth, overlap *attrValInt
+	}{
+		{opts: &Chart{Type: Col, Series: series, GapWidth: &gapWidth, Overlap: &overlap}, gapWidth: &attrValInt{Val: intPtr(50)}, overlap: &attrValInt{Val: intPtr(20)}},
+		{opts: &Chart{Type: BarStacked, Series: series, GapWidth: &invalidGapWidth, Overlap: &invalidOverlap}, overlap: &attrValInt{Val: intPtr(100)}},
+		{opts: &Chart{Type: Col3DClustered, Series: series, GapWidth: &gapWidth, Overlap: &overlap}, gapWidth: &attrValInt{Val: intPtr(50)}},
+		{opts: &Chart{Type: Area, Series: series, GapWidth: &gapWidth, Overlap: &overlap}},
+		{opts: &Chart{Type: Bar, Series: series, GapWidth: &negativeGapWidth}},
+	} {
+		assert.NoError(t, f.AddChart("Sheet1", fmt.Sprintf("E%d", i*20+1), c.opts))
+		content, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", i+1))
+		assert.True(t, ok)
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		plotArea := chartSpace.Chart.PlotArea
+		for _, charts := range []*cCharts{plotArea.AreaChart, plotArea.BarChart, plotArea.Bar3DChart} {
+			if charts != nil {
+				assert.Equal(t, c.gapWidth, charts.GapWidth)
+				assert.Equal(t, c.overlap, charts.Overlap)
+			}
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartGapWidthAndOverlap.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartPointExplosion(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{{"X", "A", "B", "C"}, {"Y", 10, 40, 20}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &data))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", PointExplosion: map[int]uint{1: 25}}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Pie, Series: series}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<dPt><idx val="1"></idx><bubble3D val="0"></bubble3D><explosion val="25"></explosion></dPt>`)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	dPt := (*chartSpace.Chart.PlotArea.PieChart.Ser)[0].DPt
+	assert.Len(t, dPt, 2)
+	assert.Equal(t, 0, *dPt[0].IDx.Val)
+	assert.Nil(t, dPt[0].Explosion)
+	assert.Equal(t, 1, *dPt[1].IDx.Val)
+	assert.Equal(t, 25, *dPt[1].Explosion.Val)
+	// Test add charts with multiple exploded data points
+	series[0].PointExplosion = map[int]uint{2: 10, 0: 30, -1: 20}
+	for i, chartType := range []ChartType{Doughnut, PieOfPie} {
+		assert.NoError(t, f.AddChart("Sheet1", fmt.Sprintf("E%d", i*20+20), &Chart{Type: chartType, Series: series}))
+		content, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", i+2))
+		assert.True(t, ok)
+		var chartSpace xlsxChartSpace
+		assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+		charts := chartSpace.Chart.PlotArea.DoughnutChart
+		if chartType == PieOfPie {
+			charts = chartSpace.Chart.PlotArea.OfPieChart
+		}
+		dPt := (*charts.Ser)[0].DPt
+		assert.Len(t, dPt, 2)
+		assert.Equal(t, 0, *dPt[0].IDx.Val)
+		assert.Equal(t, 30, *dPt[0].Explosion.Val)
+		assert.Equal(t, 2, *dPt[1].IDx.Val)
+		assert.Equal(t, 10, *dPt[1].Explosion.Val)
+	}
+	// Test add chart with point explosion on unsupported chart type
+	assert.NoError(t, f.AddChart("Sheet1", "E60", &Chart{Type: Col, Series: series}))
+	content, ok = f.Pkg.Load("xl/charts/chart4.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<explosion")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartPointExplosion.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestAddChartSheet(t *testing.T) {
 	categories := map[string]string{"A2": "Small", "A3": "Normal", "A4": "Large", "B1": "Apple", "C1": "Orange", "D1": "Pear"}
 	values := map[string]int{"B2": 2, "C2": 3, "D2": 3, "B3": 5, "C3": 2, "D3": 4, "B4": 6, "C4": 7, "D4": 8}
@@ -408,6 +612,77 @@ func TestDeleteChart(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetChartData(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"},
+		{"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4},
+		{"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Fruits", RefersTo: "Sheet1!$B$1:$D$1"}))
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Fruits", Values: "Sheet1!$B$2:$D$2"},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+			{Name: "Large", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+		},
+	}))
+	expected := ChartData{
+		Categories: []string{"Apple", "Orange", "Pear"},
+		Series: []ChartSeriesData{
+			{Name: "Small", Values: []float64{2, 3, 3}},
+			{Name: "Normal", Values: []float64{5, 2, 4}},
+			{Name: "Large", Values: []float64{6, 7, 8}},
+		},
+	}
+	data, err := f.GetChartData("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, data)
+	// Test get chart data on the cell without chart
+	data, err = f.GetChartData("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartData{}, data)
+	// Test get chart data after save and reopen the workbook
+	file := filepath.Join("test", "TestGetChartData.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	data, err = f.GetChartData("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, data)
+	// Test get chart data with invalid cell reference
+	_, err = f.GetChartData("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get chart data on not exists worksheet
+	_, err = f.GetChartData("SheetN", "E1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get chart data with invalid series reference
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:chart><c:plotArea><c:barChart><c:ser><c:val><c:numRef><c:f>Sheet1!$B$2:$D</c:f></c:numRef></c:val></c:ser></c:barChart></c:plotArea></c:chart></c:chartSpace>`))
+	_, err = f.GetChartData("Sheet1", "E1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")).Error())
+	// Test get chart data with the cached values
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:chart><c:plotArea><c:scatterChart><c:ser><c:tx><c:v>Series</c:v></c:tx><c:xVal><c:strRef><c:strCache><c:pt idx="0"><c:v>A</c:v></c:pt></c:strCache></c:strRef></c:xVal><c:yVal><c:numRef><c:numCache><c:pt idx="0"><c:v>1.5</c:v></c:pt></c:numCache></c:numRef></c:yVal></c:ser></c:scatterChart></c:plotArea></c:chart></c:chartSpace>`))
+	data, err = f.GetChartData("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartData{Categories: []string{"A"}, Series: []ChartSeriesData{{Name: "Series", Values: []float64{1.5}}}}, data)
+	// Test get chart data with unsupported charset
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartData("Sheet1", "E1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get chart data on no chart worksheet
+	data, err = NewFile().GetChartData("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartData{}, data)
+}
+
 func TestChartWithLogarithmicBase(t *testing.T) {
 	// Create test XLSX file with data
 	f := NewFile()
@@ -93,7 +93,7 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 		return rowIterator.cells, rowIterator.err
 	}
 	cols.rawCellValue = cols.f.getOptions(opts...).RawCellValue
-	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
+	if cols.sst, rowIterator.err = cols.f.sharedStringsValueReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
 	decoder := cols.f.xmlNewDecoder(bytes.NewReader(cols.sheetXML))
@@ -182,7 +182,10 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 		if rowIterator.cellCol == cols.curCol {
 			colCell := xlsxC{}
 			_ = decoder.DecodeElement(&colCell, xmlElement)
-			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			val, err := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			if rowIterator.err = err; err != nil {
+				return
+			}
 			rowIterator.cells = append(rowIterator.cells, val)
 		}
 	}
@@ -43,6 +43,7 @@ var (
 	difSect                     = -4
 	endOfChain                  = -2
 	fatSect                     = -3
+	fileSharingSpinCount        = 1e5
 	iterCount                   = 50000
 	packageEncryptionChunkSize  = 4096
 	packageOffset               = 8 // First 8 bytes are the size of the stream
@@ -21,6 +21,7 @@ const (
 	dayNanoseconds = 24 * time.Hour
 	maxDuration    = 290 * 364 * dayNanoseconds
 	roundEpsilon   = 1e-9
+	date1904Offset = 1462.0
 )
 
 var (
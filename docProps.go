@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"strings"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -195,7 +196,33 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 		Decode(core); err != nil && err != io.EOF {
 		return err
 	}
-	newProps = &xlsxCoreProperties{
+	newProps = newCoreProperties(core)
+	fields = []string{
+		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
+		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
+	}
+	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
+	for _, field = range fields {
+		if val = immutable.FieldByName(field).String(); val != "" {
+			mutable.FieldByName(field).SetString(val)
+		}
+	}
+	if docProperties.Created != "" {
+		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	}
+	if docProperties.Modified != "" {
+		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	}
+	output, err = xml.Marshal(newProps)
+	f.saveFileList(defaultXMLPathDocPropsCore, output)
+
+	return err
+}
+
+// newCoreProperties provides a function to create the document core
+// properties for serialization by given deserialized core properties.
+func newCoreProperties(core *decodeCoreProperties) *xlsxCoreProperties {
+	newProps := &xlsxCoreProperties{
 		Dc:             NameSpaceDublinCore,
 		Dcterms:        NameSpaceDublinCoreTerms,
 		Dcmitype:       NameSpaceDublinCoreMetadataInitiative,
@@ -219,26 +246,7 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 	if core.Modified != nil {
 		newProps.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
 	}
-	fields = []string{
-		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
-		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
-	}
-	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
-	for _, field = range fields {
-		if val = immutable.FieldByName(field).String(); val != "" {
-			mutable.FieldByName(field).SetString(val)
-		}
-	}
-	if docProperties.Created != "" {
-		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
-	}
-	if docProperties.Modified != "" {
-		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
-	}
-	output, err = xml.Marshal(newProps)
-	f.saveFileList(defaultXMLPathDocPropsCore, output)
-
-	return err
+	return newProps
 }
 
 // GetDocProps provides a function to get document core properties.
@@ -271,3 +279,239 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// GetPersonalInformation provides a function to get the personal and
+// identifying metadata of the workbook, includes the creator and last
+// modified by user in the core properties, the company and manager in the
+// application properties, the distinct authors of the comments in all
+// worksheets, the display names in the person list of the threaded comments,
+// and the names of the custom properties. It helps to decide whether the
+// workbook needs to be cleaned up with RemovePersonalInformation before
+// sharing.
+func (f *File) GetPersonalInformation() (PersonalInformation, error) {
+	var info PersonalInformation
+	core := new(decodeCoreProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
+		Decode(core); err != nil && err != io.EOF {
+		return info, err
+	}
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return info, err
+	}
+	info.Creator, info.LastModifiedBy = core.Creator, core.LastModifiedBy
+	info.Company, info.Manager = app.Company, app.Manager
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
+		if err != nil {
+			return info, err
+		}
+		if cmts == nil {
+			continue
+		}
+		for _, author := range cmts.Authors.Author {
+			if inStrSlice(info.CommentAuthors, author, true) == -1 {
+				info.CommentAuthors = append(info.CommentAuthors, author)
+			}
+		}
+	}
+	personList, err := f.personListReader()
+	if err != nil {
+		return info, err
+	}
+	if personList != nil {
+		for _, person := range personList.Person {
+			if inStrSlice(info.Persons, person.DisplayName, true) == -1 {
+				info.Persons = append(info.Persons, person.DisplayName)
+			}
+		}
+	}
+	customProps, err := f.customPropsReader()
+	if err != nil || customProps == nil {
+		return info, err
+	}
+	for _, prop := range customProps.Property {
+		info.CustomProperties = append(info.CustomProperties, prop.Name)
+	}
+	return info, err
+}
+
+// RemovePersonalInformation provides a function to remove the personal and
+// identifying metadata from the workbook. It clears the creator and last
+// modified by user in the core properties and the company and manager in the
+// application properties, replaces the authors of the comments in all
+// worksheets and the display names in the person list of the threaded
+// comments with the anonymous author "Author", clears the user and provider
+// of the persons, and removes the custom properties part. The person list is
+// kept since the threaded comments refer to the persons in it. The cell
+// values, formulas and the text of the comments are kept. For example:
+//
+//	err := f.RemovePersonalInformation()
+func (f *File) RemovePersonalInformation() error {
+	core := new(decodeCoreProperties)
+	err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
+		Decode(core)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if err == nil {
+		core.Creator, core.LastModifiedBy = "", ""
+		output, _ := xml.Marshal(newCoreProperties(core))
+		f.saveFileList(defaultXMLPathDocPropsCore, output)
+	}
+	app := new(xlsxProperties)
+	err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if err == nil {
+		app.Company, app.Manager = "", ""
+		app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+		output, _ := xml.Marshal(app)
+		f.saveFileList(defaultXMLPathDocPropsApp, output)
+	}
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
+		if err != nil {
+			return err
+		}
+		if cmts == nil {
+			continue
+		}
+		cmts.Authors.Author = []string{"Author"}
+		for i := range cmts.CommentList.Comment {
+			cmts.CommentList.Comment[i].AuthorID = 0
+		}
+	}
+	if err = f.anonymizePersonList(); err != nil {
+		return err
+	}
+	return f.deleteCustomProps()
+}
+
+// getPersonListPath provides a function to get the path of the person list
+// part of the threaded comments in the workbook, returns empty string if the
+// workbook doesn't contain the person list.
+func (f *File) getPersonListPath() (string, error) {
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipPerson {
+			return f.getWorksheetPath(rel.Target), err
+		}
+	}
+	return "", err
+}
+
+// personListReader provides a function to get the pointer to the structure
+// after deserialization of the person list part of the threaded comments, it
+// returns nil if the workbook doesn't contain the person list.
+func (f *File) personListReader() (*xlsxPersonList, error) {
+	path, err := f.getPersonListPath()
+	if err != nil || path == "" {
+		return nil, err
+	}
+	content, ok := f.Pkg.Load(path)
+	if !ok || content == nil {
+		return nil, nil
+	}
+	personList := new(xlsxPersonList)
+	if err = f.xmlNewDecoder(bytes.NewReader(content.([]byte))).
+		Decode(personList); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return personList, nil
+}
+
+// anonymizePersonList provides a function to replace the display names in the
+// person list of the threaded comments with the anonymous author, and clear
+// the user and provider identifiers of the persons. The IDs of the persons
+// are kept for the threaded comments referring to them.
+func (f *File) anonymizePersonList() error {
+	personList, err := f.personListReader()
+	if err != nil || personList == nil {
+		return err
+	}
+	for i := range personList.Person {
+		personList.Person[i].DisplayName = "Author"
+		personList.Person[i].UserID, personList.Person[i].ProviderID = "", "None"
+	}
+	path, _ := f.getPersonListPath()
+	output, _ := xml.Marshal(personList)
+	f.saveFileList(path, output)
+	return err
+}
+
+// getCustomPropsPath provides a function to get the path of the custom
+// properties part in the package, returns empty string if the package
+// doesn't contain the custom properties.
+func (f *File) getCustomPropsPath() (string, error) {
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomProperties {
+			return strings.TrimPrefix(rel.Target, "/"), err
+		}
+	}
+	return "", err
+}
+
+// customPropsReader provides a function to get the pointer to the structure
+// after deserialization of the custom properties part, it returns nil if the
+// package doesn't contain the custom properties.
+func (f *File) customPropsReader() (*decodeCustomProperties, error) {
+	path, err := f.getCustomPropsPath()
+	if err != nil || path == "" {
+		return nil, err
+	}
+	content, ok := f.Pkg.Load(path)
+	if !ok || content == nil {
+		return nil, nil
+	}
+	customProps := new(decodeCustomProperties)
+	if err = f.xmlNewDecoder(bytes.NewReader(content.([]byte))).
+		Decode(customProps); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return customProps, nil
+}
+
+// deleteCustomProps provides a function to delete the custom properties part,
+// and remove the relationship and content type of it.
+func (f *File) deleteCustomProps() error {
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil || rels == nil {
+		return err
+	}
+	var targets []string
+	rels.mu.Lock()
+	relationships := rels.Relationships[:0]
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomProperties {
+			targets = append(targets, strings.TrimPrefix(rel.Target, "/"))
+			continue
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	rels.mu.Unlock()
+	for _, path := range targets {
+		f.Pkg.Delete(path)
+		if err = f.removeContentTypesPart(ContentTypeCustomProperties, "/"+path); err != nil {
+			return err
+		}
+	}
+	return err
+}
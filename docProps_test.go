@@ -116,3 +116,141 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestPersonalInformation(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Go Excelize", LastModifiedBy: "Go Author", Title: "Test Title"}))
+	assert.NoError(t, f.SetAppProps(&AppProperties{Application: "Microsoft Excel", Company: "Company Name"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Data"))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment 1"}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B1", Author: "Go Author", Text: "Comment 2"}))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "A1", Author: "Excelize", Text: "Comment 3"}))
+	f.Pkg.Store("xl/persons/person.xml", []byte(`<personList xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><person displayName="Excelize" id="{00000000-0000-0000-0000-000000000001}" userId="excelize@example.com" providerId="None"/></personList>`))
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/xl/persons/person.xml", ContentType: ContentTypePerson})
+	f.Pkg.Store("docProps/custom.xml", []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Department"><vt:lpwstr>Finance</vt:lpwstr></property></Properties>`))
+	f.addRels("_rels/.rels", SourceRelationshipCustomProperties, "docProps/custom.xml", "")
+	content.Overrides = append(content.Overrides, xlsxOverride{PartName: "/docProps/custom.xml", ContentType: ContentTypeCustomProperties})
+	file := filepath.Join("test", "TestPersonalInformation.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	info, err := f.GetPersonalInformation()
+	assert.NoError(t, err)
+	assert.Equal(t, PersonalInformation{
+		Creator:          "Go Excelize",
+		LastModifiedBy:   "Go Author",
+		Company:          "Company Name",
+		CommentAuthors:   []string{"Excelize", "Go Author"},
+		Persons:          []string{"Excelize"},
+		CustomProperties: []string{"Department"},
+	}, info)
+	assert.NoError(t, f.RemovePersonalInformation())
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	info, err = f.GetPersonalInformation()
+	assert.NoError(t, err)
+	assert.Equal(t, PersonalInformation{CommentAuthors: []string{"Author"}, Persons: []string{"Author"}}, info)
+	// Test the person list has been kept for the threaded comments
+	personList, err := f.personListReader()
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxPerson{{DisplayName: "Author", ID: "{00000000-0000-0000-0000-000000000001}", ProviderID: "None"}}, personList.Person)
+	// Test the custom properties has been removed
+	_, ok := f.Pkg.Load("docProps/custom.xml")
+	assert.False(t, ok)
+	rels, err := f.relsReader("_rels/.rels")
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, SourceRelationshipCustomProperties, rel.Type)
+	}
+	content, err = f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range content.Overrides {
+		assert.NotEqual(t, ContentTypeCustomProperties, override.ContentType)
+	}
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Title", props.Title)
+	appProps, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Microsoft Excel", appProps.Application)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Data", val)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "Author", comments[0].Author)
+		assert.Equal(t, "Comment 1", comments[0].Text)
+		assert.Equal(t, "Author", comments[1].Author)
+		assert.Equal(t, "Comment 2", comments[1].Text)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test remove personal information without document properties
+	f = NewFile()
+	f.Pkg.Delete(defaultXMLPathDocPropsCore)
+	f.Pkg.Delete(defaultXMLPathDocPropsApp)
+	assert.NoError(t, f.RemovePersonalInformation())
+	_, ok = f.Pkg.Load(defaultXMLPathDocPropsCore)
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.False(t, ok)
+
+	// Test get and remove personal information with unsupported charset
+	for _, path := range []string{defaultXMLPathDocPropsCore, defaultXMLPathDocPropsApp, "xl/comments1.xml"} {
+		f = NewFile()
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+		f.Comments["xl/comments1.xml"] = nil
+		f.Pkg.Store(path, MacintoshCyrillicCharset)
+		_, err = f.GetPersonalInformation()
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		f.Comments["xl/comments1.xml"] = nil
+		assert.EqualError(t, f.RemovePersonalInformation(), "XML syntax error on line 1: invalid UTF-8")
+	}
+	f = NewFile()
+	f.Relationships.Delete(f.getWorkbookRelsPath())
+	f.Pkg.Store(f.getWorkbookRelsPath(), MacintoshCyrillicCharset)
+	_, err = f.GetPersonalInformation()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.RemovePersonalInformation(), "XML syntax error on line 1: invalid UTF-8")
+	// Test get and remove personal information with unsupported charset person list and custom properties
+	for _, rel := range [][]string{
+		{f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "xl/persons/person.xml"},
+		{"_rels/.rels", SourceRelationshipCustomProperties, "docProps/custom.xml", "docProps/custom.xml"},
+	} {
+		f = NewFile()
+		f.addRels(rel[0], rel[1], rel[2], "")
+		f.Pkg.Store(rel[3], MacintoshCyrillicCharset)
+		_, err = f.GetPersonalInformation()
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	}
+	assert.NoError(t, f.RemovePersonalInformation())
+	_, ok = f.Pkg.Load("docProps/custom.xml")
+	assert.False(t, ok)
+	f = NewFile()
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "persons/person.xml", "")
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInformation(), "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.Relationships.Delete("_rels/.rels")
+	f.Pkg.Store("_rels/.rels", MacintoshCyrillicCharset)
+	_, err = f.GetPersonalInformation()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.RemovePersonalInformation(), "XML syntax error on line 1: invalid UTF-8")
+	// Test remove personal information with unsupported charset content types
+	f = NewFile()
+	f.addRels("_rels/.rels", SourceRelationshipCustomProperties, "docProps/custom.xml", "")
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemovePersonalInformation(), "XML syntax error on line 1: invalid UTF-8")
+}
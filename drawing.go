@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -191,6 +192,26 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 	f.saveFileList(media, chart)
 }
 
+// drawChartGrouping provides a function to draw the c:grouping element by
+// given format sets. The grouping of the chart type will be used if the
+// grouping option is empty or unsupported by the chart type.
+func (f *File) drawChartGrouping(opts *Chart) *attrValString {
+	grouping := plotAreaChartGrouping[opts.Type]
+	if _, ok := plotAreaChartBarDir[opts.Type]; ok {
+		if inStrSlice([]string{"clustered", "percentStacked", "stacked"}, opts.Grouping, true) != -1 {
+			grouping = opts.Grouping
+		}
+		return &attrValString{Val: stringPtr(grouping)}
+	}
+	if opts.Grouping == "clustered" {
+		grouping = "standard"
+	}
+	if inStrSlice([]string{"percentStacked", "stacked", "standard"}, opts.Grouping, true) != -1 {
+		grouping = opts.Grouping
+	}
+	return &attrValString{Val: stringPtr(grouping)}
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
@@ -198,24 +219,29 @@ func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
 		BarDir: &attrValString{
 			Val: stringPtr("col"),
 		},
-		Grouping: &attrValString{
-			Val: stringPtr(plotAreaChartGrouping[opts.Type]),
-		},
+		Grouping: f.drawChartGrouping(opts),
 		VaryColors: &attrValBool{
 			Val: opts.VaryColors,
 		},
-		Ser:     f.drawChartSeries(opts),
-		Shape:   f.drawChartShape(opts),
-		DLbls:   f.drawChartDLbls(opts),
-		AxID:    f.genAxID(opts),
-		Overlap: &attrValInt{Val: intPtr(100)},
+		Ser:   f.drawChartSeries(opts),
+		Shape: f.drawChartShape(opts),
+		DLbls: f.drawChartDLbls(opts),
+		AxID:  f.genAxID(opts),
 	}
 	var ok bool
 	if *c.BarDir.Val, ok = plotAreaChartBarDir[opts.Type]; !ok {
 		c.BarDir = nil
 	}
-	if *c.Overlap.Val, ok = plotAreaChartOverlap[opts.Type]; !ok {
-		c.Overlap = nil
+	if opts.GapWidth != nil && 0 <= *opts.GapWidth && *opts.GapWidth <= 500 && c.BarDir != nil {
+		c.GapWidth = &attrValInt{Val: intPtr(*opts.GapWidth)}
+	}
+	if _, ok = plotAreaChartOverlap[opts.Type]; ok || opts.Type == Bar || opts.Type == Col {
+		if grouping := *c.Grouping.Val; grouping == "stacked" || grouping == "percentStacked" {
+			c.Overlap = &attrValInt{Val: intPtr(100)}
+		}
+		if opts.Overlap != nil && -100 <= *opts.Overlap && *opts.Overlap <= 100 {
+			c.Overlap = &attrValInt{Val: intPtr(*opts.Overlap)}
+		}
 	}
 	catAx := f.drawPlotAreaCatAx(opts)
 	valAx := f.drawPlotAreaValAx(opts)
@@ -458,9 +484,7 @@ func (f *File) drawDoughnutChart(opts *Chart) *cPlotArea {
 func (f *File) drawLineChart(opts *Chart) *cPlotArea {
 	return &cPlotArea{
 		LineChart: &cCharts{
-			Grouping: &attrValString{
-				Val: stringPtr(plotAreaChartGrouping[opts.Type]),
-			},
+			Grouping: f.drawChartGrouping(opts),
 			VaryColors: &attrValBool{
 				Val: boolPtr(false),
 			},
@@ -478,9 +502,7 @@ func (f *File) drawLineChart(opts *Chart) *cPlotArea {
 func (f *File) drawLine3DChart(opts *Chart) *cPlotArea {
 	return &cPlotArea{
 		Line3DChart: &cCharts{
-			Grouping: &attrValString{
-				Val: stringPtr(plotAreaChartGrouping[opts.Type]),
-			},
+			Grouping: f.drawChartGrouping(opts),
 			VaryColors: &attrValBool{
 				Val: boolPtr(false),
 			},
@@ -801,7 +823,33 @@ func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
 		},
 	}}
 	chartSeriesDPt := map[ChartType][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[opts.Type]
+	dpt = chartSeriesDPt[opts.Type]
+	if _, ok := map[ChartType]bool{
+		Pie: true, Pie3D: true, Doughnut: true, PieOfPie: true, BarOfPie: true,
+	}[opts.Type]; !ok {
+		return dpt
+	}
+	points := make([]int, 0, len(opts.Series[i].PointExplosion))
+	for point := range opts.Series[i].PointExplosion {
+		if point >= 0 {
+			points = append(points, point)
+		}
+	}
+	sort.Ints(points)
+	for _, point := range points {
+		explosion := &attrValInt{Val: intPtr(int(opts.Series[i].PointExplosion[point]))}
+		if len(dpt) > 0 && *dpt[0].IDx.Val == point {
+			dpt[0].Explosion = explosion
+			continue
+		}
+		dpt = append(dpt, &cDPt{
+			IDx:       &attrValInt{Val: intPtr(point)},
+			Bubble3D:  &attrValBool{Val: boolPtr(false)},
+			Explosion: explosion,
+		})
+	}
+	sort.Slice(dpt, func(i, j int) bool { return *dpt[i].IDx.Val < *dpt[j].IDx.Val })
+	return dpt
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
@@ -845,9 +893,14 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 	if symbol := stringPtr(opts.Series[i].Marker.Symbol); *symbol != "" {
 		marker.Symbol = &attrValString{Val: symbol}
 	}
-	if size := intPtr(opts.Series[i].Marker.Size); *size != 0 {
+	if size := intPtr(opts.Series[i].Marker.Size); 2 <= *size && *size <= 72 {
 		marker.Size = &attrValInt{Val: size}
 	}
+	if marker.Symbol != nil && *marker.Symbol.Val == "none" {
+		marker.Size = nil
+		chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
+		return chartSeriesMarker[opts.Type]
+	}
 	if i < 6 {
 		marker.SpPr = &cSpPr{
 			SolidFill: &aSolidFill{
@@ -1022,6 +1075,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 	if opts.XAxis.MinorGridLines {
 		axs[0].MinorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
 	}
+	drawChartTickMarks(axs[0], &opts.XAxis)
 	if opts.XAxis.TickLabelSkip != 0 {
 		axs[0].TickLblSkip = &attrValInt{Val: intPtr(opts.XAxis.TickLabelSkip)}
 	}
@@ -1098,6 +1152,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	if opts.YAxis.MinorGridLines {
 		axs[0].MinorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
 	}
+	drawChartTickMarks(axs[0], &opts.YAxis)
 	if pos, ok := valTickLblPos[opts.Type]; ok {
 		axs[0].TickLblPos.Val = stringPtr(pos)
 	}
@@ -1127,6 +1182,17 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	return axs
 }
 
+// drawChartTickMarks provides a function to set the major and minor tick marks
+// of the c:catAx or c:valAx element by given axis format sets.
+func drawChartTickMarks(axs *cAxs, axis *ChartAxis) {
+	if inStrSlice(supportedChartTickMarks, axis.MajorTickMark, true) != -1 {
+		axs.MajorTickMark = &attrValString{Val: stringPtr(axis.MajorTickMark)}
+	}
+	if inStrSlice(supportedChartTickMarks, axis.MinorTickMark, true) != -1 {
+		axs.MinorTickMark = &attrValString{Val: stringPtr(axis.MinorTickMark)}
+	}
+}
+
 // drawPlotAreaSerAx provides a function to draw the c:serAx element.
 func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 	maxVal := &attrValFloat{Val: opts.YAxis.Maximum}
@@ -1349,6 +1415,86 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2, nil
 }
 
+// getDrawingObjectCell provides a function to get the top-left cell reference
+// of the anchor of the drawing object, such as chart, picture or shape by
+// given worksheet name and the name of drawing object.
+func (f *File) getDrawingObjectCell(sheet, name string) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	if ws.Drawing == nil {
+		return "", newNoExistDrawingObjectError(name)
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return "", err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchor := range wsDr.getCellAnchors() {
+		var cNvPrName string
+		from := anchor.From
+		if anchor.Pic != nil {
+			cNvPrName = anchor.Pic.NvPicPr.CNvPr.Name
+		}
+		if anchor.Sp != nil && anchor.Sp.NvSpPr != nil && anchor.Sp.NvSpPr.CNvPr != nil {
+			cNvPrName = anchor.Sp.NvSpPr.CNvPr.Name
+		}
+		if anchor.GraphicFrame != "" {
+			deCellAnchor := new(decodeCellAnchor)
+			_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+			if deCellAnchor.From != nil {
+				from = &xlsxFrom{Col: deCellAnchor.From.Col, Row: deCellAnchor.From.Row}
+			}
+			for _, cNvPr := range []*decodeCNvPr{
+				deCellAnchor.GraphicFrame.getCNvPr(), deCellAnchor.Pic.getCNvPr(), deCellAnchor.Sp.getCNvPr(),
+			} {
+				if cNvPr != nil {
+					cNvPrName = cNvPr.Name
+				}
+			}
+		}
+		if from != nil && cNvPrName == name {
+			return CoordinatesToCellName(from.Col+1, from.Row+1)
+		}
+	}
+	return "", newNoExistDrawingObjectError(name)
+}
+
+// getCellAnchors returns the two cell anchors and one cell anchors of the
+// drawing.
+func (wsDr *xlsxWsDr) getCellAnchors() []*xdrCellAnchor {
+	anchors := make([]*xdrCellAnchor, 0, len(wsDr.TwoCellAnchor)+len(wsDr.OneCellAnchor))
+	return append(append(anchors, wsDr.TwoCellAnchor...), wsDr.OneCellAnchor...)
+}
+
+// getCNvPr returns the non-visual drawing properties of the graphic frame.
+func (g *decodeGraphicFrame) getCNvPr() *decodeCNvPr {
+	if g == nil {
+		return nil
+	}
+	return g.NvGraphicFramePr.CNvPr
+}
+
+// getCNvPr returns the non-visual drawing properties of the picture.
+func (p *decodePic) getCNvPr() *decodeCNvPr {
+	if p == nil {
+		return nil
+	}
+	return &p.NvPicPr.CNvPr
+}
+
+// getCNvPr returns the non-visual drawing properties of the shape.
+func (sp *decodeSp) getCNvPr() *decodeCNvPr {
+	if sp == nil || sp.NvSpPr == nil {
+		return nil
+	}
+	return sp.NvSpPr.CNvPr
+}
+
 // addDrawingChart provides a function to add chart graphic frame by given
 // sheet, drawingXML, cell, width, height, relationship index and format sets.
 func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
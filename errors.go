@@ -28,6 +28,9 @@ var (
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrCodeNameDuplicate defined the error message on the same code name
+	// already exists in the workbook.
+	ErrCodeNameDuplicate = errors.New("the same code name already exists")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
@@ -97,6 +100,9 @@ var (
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
+	// ErrReadingOrder defined the error message on receive an invalid reading
+	// order of the cell alignment.
+	ErrReadingOrder = errors.New("the reading order must be 0, 1 or 2")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
@@ -114,6 +120,9 @@ var (
 	// ErrSheetNameSingleQuote defined the error message on the first or last
 	// character of the sheet name was a single quote.
 	ErrSheetNameSingleQuote = errors.New("the first or last character of the sheet name can not be a single quote")
+	// ErrSortMergedCells defined the error message on sorting the range which
+	// contains merged cells.
+	ErrSortMergedCells = errors.New("the range to be sorted can not contain merged cells")
 	// ErrSparkline defined the error message on receive the invalid sparkline
 	// parameters.
 	ErrSparkline = errors.New("must have the same number of 'Location' and 'Range' parameters")
@@ -135,6 +144,9 @@ var (
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrTableColumnType defined the error message on receive the invalid
+	// table column data type.
+	ErrTableColumnType = errors.New("the table column data type must be one of text, number, date or boolean")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
@@ -170,6 +182,18 @@ var (
 	ErrWorkbookPassword = errors.New("the supplied open workbook password is not correct")
 )
 
+// ErrCalcCircularReference defined an error of the formula refers to its own
+// cell, either directly or indirectly, on calculating the cell value without
+// iterative calculation.
+type ErrCalcCircularReference struct {
+	Cell string
+}
+
+// Error returns the error message on the formula circular reference.
+func (err ErrCalcCircularReference) Error() string {
+	return fmt.Sprintf("circular reference detected at cell %s", err.Cell)
+}
+
 // ErrSheetNotExist defined an error of sheet that does not exist.
 type ErrSheetNotExist struct {
 	SheetName string
@@ -222,12 +246,30 @@ func newInvalidCellNameError(cell string) error {
 	return fmt.Errorf("invalid cell name %q", cell)
 }
 
+// newInvalidChartMarkerSymbolError defined the error message on receiving the
+// invalid chart series marker symbol.
+func newInvalidChartMarkerSymbolError(symbol string) error {
+	return fmt.Errorf("invalid chart series marker symbol %q", symbol)
+}
+
+// newInvalidColorError defined the error message on receiving the invalid
+// RGB hex color code.
+func newInvalidColorError(color string) error {
+	return fmt.Errorf("invalid color %q, the color should be a RGB hex color code", color)
+}
+
 // newInvalidColumnNameError defined the error message on receiving the
 // invalid column name.
 func newInvalidColumnNameError(col string) error {
 	return fmt.Errorf("invalid column name %q", col)
 }
 
+// newInvalidEmbeddedFontError defined the error message on receiving the
+// embedded font part which is not a valid TrueType or OpenType font.
+func newInvalidEmbeddedFontError(partName string) error {
+	return fmt.Errorf("invalid embedded font %q, the font name table not found", partName)
+}
+
 // newInvalidExcelDateError defined the error message on receiving the data
 // with negative values.
 func newInvalidExcelDateError(dateValue float64) error {
@@ -264,6 +306,30 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newNoExistCommentError defined the error message on receiving the cell
+// reference which doesn't have a comment.
+func newNoExistCommentError(cell string) error {
+	return fmt.Errorf("comment in cell %s does not exist", cell)
+}
+
+// newNoExistDrawingObjectError defined the error message on receiving the
+// non existing drawing object name.
+func newNoExistDrawingObjectError(name string) error {
+	return fmt.Errorf("drawing object %s does not exist", name)
+}
+
+// newNoExistSparklineError defined the error message on receiving the cell
+// reference which doesn't have a sparkline.
+func newNoExistSparklineError(cell string) error {
+	return fmt.Errorf("sparkline in cell %s does not exist", cell)
+}
+
+// newNoExistTableColumnError defined the error message on receiving the non
+// existing table column name.
+func newNoExistTableColumnError(name string) error {
+	return fmt.Errorf("table column %s does not exist", name)
+}
+
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
@@ -300,6 +366,12 @@ func newUnknownFilterTokenError(token string) error {
 	return fmt.Errorf("unknown operator: %s", token)
 }
 
+// newUnsupportedThemeError defined the error message on receiving the built-in
+// theme name are unsupported.
+func newUnsupportedThemeError(name string) error {
+	return fmt.Errorf("unsupported built-in theme %s", name)
+}
+
 // newUnsupportedChartType defined the error message on receiving the chart
 // type are unsupported.
 func newUnsupportedChartType(chartType ChartType) error {
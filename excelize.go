@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -29,15 +30,20 @@ import (
 // File define a populated spreadsheet file struct.
 type File struct {
 	mu               sync.Mutex
+	calcRand         *rand.Rand
+	calcRandSeed     int64
 	checked          sync.Map
 	formulaChecked   bool
 	options          *Options
 	sharedStringItem [][]uint
+	sharedStringLazy []string
+	sharedStringDec  *xml.Decoder
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
 	sheetMap         map[string]string
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
+	lazyFiles        sync.Map
 	xmlAttr          sync.Map
 	CalcChain        *xlsxCalcChain
 	CharsetReader    charsetTranscoderFn
@@ -68,6 +74,12 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // MaxCalcIterations specifies the maximum iterations for iterative
 // calculation, the default value is 0.
 //
+// RandSeed specifies the seed of the random number generator used by the
+// RAND, RANDARRAY and RANDBETWEEN formula functions on calculating the cell
+// value, the current time will be used as the seed if this value is 0. The
+// random number generator will be seeded once for the workbook, so each cell
+// calculation gets a different sequence of random numbers.
+//
 // Password specifies the password of the spreadsheet in plain text.
 //
 // RawCellValue specifies if apply the number format for the cell value or get
@@ -97,16 +109,68 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// LazySharedStrings specifies if defer parsing the shared string table until
+// it's needed. When enabled, reading a shared string cell value by the
+// GetCellValue, GetRows, GetCols, Rows, Cols and SearchSheet functions only
+// decodes the shared string items up to the requested index, and the decoded
+// items will be cached for subsequent reads. The shared string table will be
+// fully parsed once the shared string table needs to be changed.
+//
+// LazyWorksheets specifies if defer unzipping the worksheet parts until the
+// worksheet is accessed at the first time. When enabled, only the worksheets
+// which have been accessed will be extracted and parsed into memory, and the
+// parsed worksheet will be cached for subsequent accesses. The untouched
+// worksheets will be copied from the original file as-is on saving.
+//
+// ClearVolatileCache specifies if clear the cached values of the formula cells
+// which use volatile functions (CELL, INDIRECT, INFO, NOW, OFFSET, RAND,
+// RANDARRAY, RANDBETWEEN and TODAY) on saving the spreadsheet, and set the
+// workbook to perform a full calculation when the workbook is opened. This
+// option can be used to generate the identical files for the same contents.
+//
+// FloatPrecision specifies the maximum number of significant digits used to
+// format the numeric cell values without number format or with the General
+// number format when reading the cell values, the other numeric cell values
+// are rounded to 15 significant digits before applying the number format. The
+// valid range is 1 to 17, and the default value is 15 which is the same as the
+// spreadsheet applications, so that the value such as the result of 0.1+0.2
+// will be read as 0.3 instead of 0.30000000000000004. Using 17 to get the
+// exact floating-point value.
+//
+// DecimalSeparator specifies the decimal separator used for displaying the
+// numeric cell values with number format when reading the cell values and
+// calculating the TEXT formula function, the default value is ".".
+//
+// ThousandsSeparator specifies the thousands separator used for displaying the
+// numeric cell values with number format when reading the cell values and
+// calculating the TEXT formula function, the default value is ",". For
+// example, using the "," as decimal separator and the "." as thousands
+// separator to read the cell value under the German locale.
+//
+// ProgressCallback specifies the function to be called for reporting the
+// progress of the bulk operations, such as the SetCellMatrix and the
+// SetRowsFromMaps functions. The callback will be invoked after each row has
+// been written with the number of the written rows and the total number of the
+// rows.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations  uint
+	RandSeed           int64
+	Password           string
+	RawCellValue       bool
+	UnzipSizeLimit     int64
+	UnzipXMLSizeLimit  int64
+	ShortDatePattern   string
+	LongDatePattern    string
+	LongTimePattern    string
+	CultureInfo        CultureName
+	LazySharedStrings  bool
+	LazyWorksheets     bool
+	ClearVolatileCache bool
+	FloatPrecision     int
+	DecimalSeparator   string
+	ThousandsSeparator string
+	ProgressCallback   func(done, total int)
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -140,6 +204,7 @@ func newFile() *File {
 		checked:          sync.Map{},
 		sheetMap:         make(map[string]string),
 		tempFiles:        sync.Map{},
+		lazyFiles:        sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
 		sharedStringsMap: make(map[string]int),
@@ -253,6 +318,17 @@ func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
 	return err
 }
 
+// isWorksheetPath returns whether the given sheet part path is a worksheet,
+// not a chart sheet, dialog sheet or macro sheet.
+func isWorksheetPath(name string) bool {
+	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
+		if strings.HasPrefix(name, sheetType) {
+			return false
+		}
+	}
+	return true
+}
+
 // workSheetReader provides a function to get the pointer to the structure
 // after deserialization by given worksheet name.
 func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
@@ -271,11 +347,12 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		ws = worksheet.(*xlsxWorksheet)
 		return
 	}
-	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
-		if strings.HasPrefix(name, sheetType) {
-			err = newNotWorksheetError(sheet)
-			return
-		}
+	if !isWorksheetPath(name) {
+		err = newNotWorksheetError(sheet)
+		return
+	}
+	if _, err = f.loadLazyFile(name); err != nil {
+		return
 	}
 	ws = new(xlsxWorksheet)
 	if attrs, ok := f.xmlAttr.Load(name); !ok {
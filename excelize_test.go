@@ -329,6 +329,90 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenReaderLazyWorksheets(t *testing.T) {
+	f := NewFile()
+	for i := 2; i <= 5; i++ {
+		_, err := f.NewSheet(fmt.Sprintf("Sheet%d", i))
+		assert.NoError(t, err)
+	}
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, f.SetCellValue(fmt.Sprintf("Sheet%d", i), "A1", i))
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{LazyWorksheets: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, f.SheetCount)
+	assert.Len(t, f.GetSheetList(), 5)
+	val, err := f.GetCellValue("Sheet3", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	// Test only the accessed worksheet has been materialized
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("xl/worksheets/sheet%d.xml", i)
+		_, parsed := f.Sheet.Load(name)
+		_, unzipped := f.Pkg.Load(name)
+		_, deferred := f.lazyFiles.Load(name)
+		assert.Equal(t, i == 3, parsed, name)
+		assert.Equal(t, i == 3, unzipped, name)
+		assert.Equal(t, i != 3, deferred, name)
+	}
+	assert.NoError(t, f.SetCellValue("Sheet3", "A2", "updated"))
+	assert.NoError(t, f.DeleteSheet("Sheet4"))
+	sw, err := f.NewStreamWriter("Sheet5")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"stream"}))
+	assert.NoError(t, sw.Flush())
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test the untouched worksheets have been preserved on save
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Sheet3", "Sheet5"}, f.GetSheetList())
+	for cell, expected := range map[string]string{
+		"Sheet1!A1": "1", "Sheet2!A1": "2", "Sheet3!A1": "3", "Sheet3!A2": "updated", "Sheet5!A1": "stream",
+	} {
+		ref := strings.Split(cell, "!")
+		val, err := f.GetCellValue(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test read the deferred worksheet with corrupted content
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", strings.Repeat("data", 100)))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	data := buf.Bytes()
+	for _, file := range zr.File {
+		if file.Name == "xl/worksheets/sheet2.xml" {
+			offset, err := file.DataOffset()
+			assert.NoError(t, err)
+			for i := offset; i < offset+int64(file.CompressedSize64); i++ {
+				data[i] = 0xFF
+			}
+		}
+	}
+	f, err = OpenReader(bytes.NewReader(data), Options{LazyWorksheets: true})
+	assert.NoError(t, err)
+	_, err = f.GetCellValue("Sheet2", "A1")
+	assert.Error(t, err)
+	_, err = f.Rows("Sheet2")
+	assert.Error(t, err)
+	_, deferred := f.lazyFiles.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, deferred)
+	assert.NoError(t, f.Close())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
@@ -433,6 +517,96 @@ func TestSetCellHyperLink(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSetCellHyperLinkToDrawing(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChart("Sheet 2", "E5", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "'Sheet 2'!$A$1", Categories: "'Sheet 2'!$B$1:$D$1", Values: "'Sheet 2'!$B$2:$D$2"}},
+	}))
+	assert.NoError(t, f.AddPicture("Sheet 2", "H20", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddShape("Sheet 2", &Shape{Cell: "C30", Type: "rect"}))
+	for link, expected := range map[string]string{
+		"'Sheet 2'!Chart 2": "'Sheet 2'!E5",
+		"Sheet 2!Picture 3": "'Sheet 2'!H20",
+		"Sheet 2!Shape 4":   "'Sheet 2'!C30",
+	} {
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", link, "Drawing"))
+		_, target, err := f.GetCellHyperLink("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, target, link)
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Test set hyperlink to the drawing object after reopen the workbook
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet 2!Chart 2", "Drawing"))
+	_, target, err := f.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet 2'!E5", target)
+	assert.NoError(t, f.SetCellHyperLink("Sheet 2", "A3", "Shape 4", "Drawing"))
+	_, target, err = f.GetCellHyperLink("Sheet 2", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sheet 2'!C30", target)
+	// Test set hyperlink to not exists drawing object
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet 2!Chart 1", "Drawing"), "drawing object Chart 1 does not exist")
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A2", "Chart 2", "Drawing"), "drawing object Chart 2 does not exist")
+	// Test set hyperlink to drawing object on not exists worksheet
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A2", "SheetN!Chart 2", "Drawing"), "sheet SheetN does not exist")
+	// Test set hyperlink to drawing object with unsupported charset drawing
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellHyperLink("Sheet1", "A2", "Sheet 2!Chart 2", "Drawing"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellValueWithAutoLink(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "https://github.com/xuri/excelize",
+		"A2": "xuri.me@gmail.com",
+		"A3": "MAILTO:xuri.me@gmail.com",
+		"A4": "www.github.com",
+		"A5": "https://",
+		"A6": "Visit https://github.com",
+		"A7": 100,
+	} {
+		assert.NoError(t, f.SetCellValueWithAutoLink("Sheet1", cell, value))
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML("xl/worksheets/_rels/sheet1.xml.rels")), `Target="https://github.com/xuri/excelize" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" TargetMode="External"`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]string{
+		"A1": {"https://github.com/xuri/excelize", "https://github.com/xuri/excelize"},
+		"A2": {"xuri.me@gmail.com", "mailto:xuri.me@gmail.com"},
+		"A3": {"MAILTO:xuri.me@gmail.com", "MAILTO:xuri.me@gmail.com"},
+		"A4": {"www.github.com", ""},
+		"A5": {"https://", ""},
+		"A6": {"Visit https://github.com", ""},
+		"A7": {"100", ""},
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], val, cell)
+		link, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1] != "", link, cell)
+		assert.Equal(t, expected[1], target, cell)
+	}
+	// Test set cell value with auto link on not exists worksheet
+	assert.EqualError(t, f.SetCellValueWithAutoLink("SheetN", "A1", "https://github.com"), "sheet SheetN does not exist")
+	// Test set cell value with auto link with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValueWithAutoLink("Sheet1", "A", "https://github.com"))
+	assert.NoError(t, f.Close())
+}
+
 func TestGetCellHyperLink(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -1013,6 +1187,110 @@ func TestCopySheetError(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetError.xlsx")))
 }
 
+func TestDuplicateSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetName("Sheet1", "Source Data"))
+	for idx, row := range [][]interface{}{{nil, "Apple", "Orange"}, {"Small", 2, 3}, {"Normal", 5, 2}} {
+		assert.NoError(t, f.SetSheetRow("Source Data", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Source Data", "A1", "C1", style))
+	assert.NoError(t, f.MergeCell("Source Data", "A5", "B5"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "D1:D3"
+	assert.NoError(t, dv.SetRange(1, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Source Data", dv))
+	assert.NoError(t, f.AddPicture("Source Data", "F1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Source Data", "F10", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "'Source Data'!$A$2", Categories: "'Source Data'!$B$1:$C$1", Values: "'Source Data'!$B$2:$C$2"},
+			{Name: "'Source Data'!$A$3", Categories: "'Source Data'!$B$1:$C$1", Values: "'Source Data'!$B$3:$C$3"},
+		},
+	}))
+	assert.NoError(t, f.AddComment("Source Data", Comment{Cell: "A2", Author: "Excelize", Text: "Comment"}))
+	assert.NoError(t, f.AddTable("Source Data", &Table{Range: "H1:I3"}))
+	index, err := f.DuplicateSheet("Source Data", "Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	// Test the duplicated worksheet doesn't share parts with the source worksheet
+	sourceRels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	sheetRels, err := f.relsReader("xl/worksheets/_rels/sheet2.xml.rels")
+	assert.NoError(t, err)
+	for _, sourceRel := range sourceRels.Relationships {
+		for _, rel := range sheetRels.Relationships {
+			assert.NotEqual(t, sourceRel.Target, rel.Target)
+		}
+	}
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "B2", Author: "Excelize", Text: "Copy"}))
+	// Test the duplicated worksheet is independent of the source worksheet
+	assert.NoError(t, f.SetCellValue("Sheet2", "B2", 10))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Source Data": "2", "Sheet2": "10"} {
+		val, err := f.GetCellValue(sheet, "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	styleID, err := f.GetCellStyle("Sheet2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	dvs, err := f.GetDataValidations("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	pics, err := f.GetPictures("Sheet2", "F1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	for sheet, expected := range map[string]int{"Source Data": 1, "Sheet2": 2} {
+		comments, err := f.GetComments(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, comments, expected)
+	}
+	tables, err := f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	// Test the duplicated chart references the new worksheet
+	assert.Equal(t, 2, f.countCharts())
+	assert.Equal(t, 2, f.countDrawings())
+	target := f.getSheetRelationshipsTargetByID("Sheet2", "rId1")
+	assert.Equal(t, "../drawings/drawing2.xml", target)
+	assert.Contains(t, string(f.readXML("xl/charts/chart2.xml")), "<f>Sheet2!$B$2:$C$2</f>")
+	assert.NotContains(t, string(f.readXML("xl/charts/chart2.xml")), "Source Data")
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), "<f>&#39;Source Data&#39;!$B$2:$C$2</f>")
+	// Test duplicate worksheet with the name of an existing sheet
+	_, err = f.DuplicateSheet("Source Data", "SHEET2")
+	assert.Equal(t, ErrExistsSheet, err)
+	// Test duplicate worksheet with invalid sheet name
+	_, err = f.DuplicateSheet("Source Data", "Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = f.DuplicateSheet("Sheet:1", "Sheet3")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test duplicate worksheet on not exists worksheet
+	_, err = f.DuplicateSheet("SheetN", "Sheet3")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test duplicate chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet2!$B$2:$C$2"}}}))
+	_, err = f.DuplicateSheet("Chart1", "Sheet3")
+	assert.Equal(t, newNotWorksheetError("Chart1"), err)
+	assert.NoError(t, f.Close())
+	// Test duplicate worksheet with unsupported charset drawing
+	f = NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.DuplicateSheet("Sheet1", "Sheet2")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments("sheet0"))
@@ -1386,6 +1664,63 @@ func TestProtectSheet(t *testing.T) {
 	assert.EqualError(t, f.ProtectSheet("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestGetSheetProtection(t *testing.T) {
+	f := NewFile()
+	sheetName := f.GetSheetName(0)
+	// Test get protection settings of the unprotected worksheet
+	opts, err := f.GetSheetProtection(sheetName)
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+	// Test allow sorting and using auto filter while locking everything else
+	expected := &SheetProtectionOptions{
+		AlgorithmName: "SHA-512",
+		AutoFilter:    true,
+		Sort:          true,
+	}
+	assert.NoError(t, f.ProtectSheet(sheetName, &SheetProtectionOptions{
+		AlgorithmName: "SHA-512",
+		Password:      "password",
+		AutoFilter:    true,
+		Sort:          true,
+	}))
+	opts, err = f.GetSheetProtection(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	file := filepath.Join("test", "TestGetSheetProtection.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	opts, err = f.GetSheetProtection(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	assert.NoError(t, f.UnprotectSheet(sheetName, "password"))
+	opts, err = f.GetSheetProtection(sheetName)
+	assert.NoError(t, err)
+	assert.Nil(t, opts)
+	assert.NoError(t, f.Close())
+
+	// Test get protection settings with omitted attributes
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/><sheetProtection sheet="1" objects="1" scenarios="1" selectLockedCells="1" sort="0"/></worksheet>`))
+	f.checked = sync.Map{}
+	opts, err = f.GetSheetProtection(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, &SheetProtectionOptions{SelectUnlockedCells: true, Sort: true}, opts)
+	// Test get protection settings with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetSheetProtection(sheetName)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get protection settings on not exists worksheet
+	_, err = f.GetSheetProtection("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestUnprotectSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -1476,6 +1811,56 @@ func TestUnprotectWorkbook(t *testing.T) {
 	assert.EqualError(t, f.UnprotectWorkbook(), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetWorkbookProtection(t *testing.T) {
+	f := NewFile()
+	// Test get workbook protection settings of unprotected workbook
+	opts, err := f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookProtectionOptions{}, opts)
+	// Test get workbook protection settings with default hash algorithm
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
+		Password:      "password",
+		LockStructure: true,
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookProtectionOptions{
+		AlgorithmName: "SHA-512",
+		SpinCount:     int(workbookProtectionSpinCount),
+		LockStructure: true,
+	}, opts)
+	// Test get workbook protection settings with custom spin count
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{
+		AlgorithmName: "SHA-256",
+		Password:      "password",
+		SpinCount:     1000,
+		LockWindows:   true,
+	}))
+	opts, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookProtectionOptions{
+		AlgorithmName: "SHA-256",
+		SpinCount:     1000,
+		LockWindows:   true,
+	}, opts)
+	assert.NoError(t, f.UnprotectWorkbook("password"))
+	// Test get workbook protection settings without password
+	assert.NoError(t, f.ProtectWorkbook(&WorkbookProtectionOptions{LockStructure: true, LockWindows: true}))
+	opts, err = f.GetWorkbookProtection()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookProtectionOptions{LockStructure: true, LockWindows: true}, opts)
+	// Test get workbook protection settings with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookProtection()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetDefaultTimeStyle(t *testing.T) {
 	f := NewFile()
 	// Test set default time style on not exists worksheet.
@@ -1699,3 +2084,34 @@ func BenchmarkOpenFile(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkOpenFileLazySharedStrings(b *testing.B) {
+	f := NewFile()
+	for row := 1; row <= 20000; row++ {
+		if err := f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{
+			fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), fmt.Sprintf("C%d", row),
+		}); err != nil {
+			b.Error(err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		b.Error(err)
+	}
+	for _, lazy := range []bool{false, true} {
+		b.Run(fmt.Sprintf("LazySharedStrings=%t", lazy), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f, err := OpenReader(bytes.NewReader(buf.Bytes()), Options{LazySharedStrings: lazy})
+				if err != nil {
+					b.Error(err)
+				}
+				if _, err := f.GetCellValue("Sheet1", "B2"); err != nil {
+					b.Error(err)
+				}
+				if err := f.Close(); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+}
@@ -173,6 +173,14 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
+	if f.options != nil && f.options.ClearVolatileCache {
+		if err := f.clearVolatileCache(); err != nil {
+			return err
+		}
+	}
+	if err := f.strictToTransitional(); err != nil {
+		return err
+	}
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -236,5 +244,46 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 		}
 		_, err = fi.Write(f.readBytes(path))
 	}
+	if err != nil {
+		return err
+	}
+	return f.writeLazyFilesToZip(zw)
+}
+
+// writeLazyFilesToZip provides a function to copy the worksheet parts which
+// have not been unzipped from the original file into the zip writer.
+func (f *File) writeLazyFilesToZip(zw *zip.Writer) error {
+	var (
+		err       error
+		lazyFiles []string
+	)
+	f.lazyFiles.Range(func(path, file interface{}) bool {
+		if _, ok := f.Pkg.Load(path); ok {
+			return true
+		}
+		lazyFiles = append(lazyFiles, path.(string))
+		return true
+	})
+	sort.Strings(lazyFiles)
+	for _, path := range lazyFiles {
+		var (
+			fi io.Writer
+			rc io.ReadCloser
+		)
+		file, _ := f.lazyFiles.Load(path)
+		if fi, err = zw.Create(path); err != nil {
+			return err
+		}
+		if rc, err = file.(*zip.File).Open(); err != nil {
+			return err
+		}
+		if _, err = io.Copy(fi, rc); err != nil {
+			_ = rc.Close()
+			return err
+		}
+		if err = rc.Close(); err != nil {
+			return err
+		}
+	}
 	return err
 }
@@ -90,6 +90,69 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToClearVolatileCache(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "NOW()"))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "1+1"))
+		formulaType, ref := STCellFormulaTypeShared, "C1:C2"
+		assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "TODAY()+B1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		for _, r := range ws.(*xlsxWorksheet).SheetData.Row {
+			for c := range r.C {
+				r.C[c].V = "45000.5"
+			}
+		}
+		return f
+	}
+	f := prepare()
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	var expected bytes.Buffer
+	f = prepare()
+	_, err = f.WriteTo(&expected, Options{ClearVolatileCache: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, buf.Bytes(), expected.Bytes())
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(bytes.NewReader(expected.Bytes()))
+	assert.NoError(t, err)
+	for cell, value := range map[string]string{"A1": "", "B1": "45000.5", "C1": "", "C2": ""} {
+		result, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, value, result, cell)
+	}
+	calcProps, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.True(t, *calcProps.FullCalcOnLoad)
+	assert.NoError(t, f.Close())
+	// Test save with clear volatile cache produces identical bytes
+	var actual bytes.Buffer
+	f = prepare()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].V = "45001.25"
+	_, err = f.WriteTo(&actual, Options{ClearVolatileCache: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), actual.Bytes())
+	assert.NoError(t, f.Close())
+	// Test save with clear volatile cache with unsupported charset
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.WriteTo(bufio.NewWriter(&actual), Options{ClearVolatileCache: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.WriteTo(bufio.NewWriter(&actual), Options{ClearVolatileCache: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
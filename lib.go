@@ -68,6 +68,10 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 					continue
 				}
 			}
+			if f.options.LazyWorksheets && !v.FileInfo().IsDir() {
+				f.lazyFiles.Store(fileName, v)
+				continue
+			}
 		}
 		if fileList[fileName], err = readFile(v); err != nil {
 			return nil, 0, err
@@ -104,9 +108,29 @@ func (f *File) readXML(name string) []byte {
 	if content, ok := f.streams[name]; ok {
 		return content.rawData.buf.Bytes()
 	}
+	if content, err := f.loadLazyFile(name); err == nil && content != nil {
+		return content
+	}
 	return []byte{}
 }
 
+// loadLazyFile provides a function to unzip the deferred worksheet part by
+// given path. The part will be kept deferred and the error will be returned
+// if it could not be read from the original file.
+func (f *File) loadLazyFile(name string) ([]byte, error) {
+	file, ok := f.lazyFiles.Load(name)
+	if !ok {
+		return nil, nil
+	}
+	content, err := readFile(file.(*zip.File))
+	if err != nil {
+		return nil, err
+	}
+	f.Pkg.Store(name, content)
+	f.lazyFiles.Delete(name)
+	return content, nil
+}
+
 // readBytes read file as bytes by given path.
 func (f *File) readBytes(name string) []byte {
 	content := f.readXML(name)
@@ -555,23 +579,58 @@ func (ext *xlsxExt) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	return nil
 }
 
+// UnmarshalXML decodes the sheet protection element and applies the default
+// values defined by the ECMA-376 for the attributes which are omitted, the
+// attributes of the sheet, objects, scenarios, select locked cells and select
+// unlocked cells default to false, and all the others default to true.
+func (sp *xlsxSheetProtection) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type sheetProtection xlsxSheetProtection
+	p := sheetProtection{
+		FormatCells: true, FormatColumns: true, FormatRows: true,
+		InsertColumns: true, InsertRows: true, InsertHyperlinks: true,
+		DeleteColumns: true, DeleteRows: true, Sort: true,
+		AutoFilter: true, PivotTables: true,
+	}
+	if err := d.DecodeElement(&p, &start); err != nil {
+		return err
+	}
+	*sp = xlsxSheetProtection(p)
+	return nil
+}
+
+// UnmarshalXML decodes the client data element of the drawing object and
+// applies the default values defined by the ECMA-376 for the attributes which
+// are omitted, the locks with sheet and prints with sheet attributes default
+// to true.
+func (cd *xdrClientData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type clientData xdrClientData
+	c := clientData{FLocksWithSheet: true, FPrintsWithSheet: true}
+	if err := d.DecodeElement(&c, &start); err != nil {
+		return err
+	}
+	*cd = xdrClientData(c)
+	return nil
+}
+
 // namespaceStrictToTransitional provides a method to convert Strict and
-// Transitional namespaces.
+// Transitional namespaces. The specific relationship types will be converted
+// before the common relationships namespace prefix.
 func namespaceStrictToTransitional(content []byte) []byte {
-	namespaceTranslationDic := map[string]string{
-		StrictNameSpaceDocumentPropertiesVariantTypes: NameSpaceDocumentPropertiesVariantTypes.Value,
-		StrictNameSpaceDrawingMLMain:                  NameSpaceDrawingMLMain,
-		StrictNameSpaceExtendedProperties:             NameSpaceExtendedProperties,
-		StrictNameSpaceSpreadSheet:                    NameSpaceSpreadSheet.Value,
-		StrictSourceRelationship:                      SourceRelationship.Value,
-		StrictSourceRelationshipChart:                 SourceRelationshipChart,
-		StrictSourceRelationshipComments:              SourceRelationshipComments,
-		StrictSourceRelationshipExtendProperties:      SourceRelationshipExtendProperties,
-		StrictSourceRelationshipImage:                 SourceRelationshipImage,
-		StrictSourceRelationshipOfficeDocument:        SourceRelationshipOfficeDocument,
-	}
-	for s, n := range namespaceTranslationDic {
-		content = bytesReplace(content, []byte(s), []byte(n), -1)
+	for _, namespace := range [][2]string{
+		{StrictNameSpaceDocumentPropertiesVariantTypes, NameSpaceDocumentPropertiesVariantTypes.Value},
+		{StrictNameSpaceDrawingMLChart, NameSpaceDrawingMLChart.Value},
+		{StrictNameSpaceDrawingMLMain, NameSpaceDrawingMLMain},
+		{StrictNameSpaceDrawingMLSpreadSheet, NameSpaceDrawingMLSpreadSheet.Value},
+		{StrictNameSpaceExtendedProperties, NameSpaceExtendedProperties},
+		{StrictNameSpaceSpreadSheet, NameSpaceSpreadSheet.Value},
+		{StrictSourceRelationshipChart, SourceRelationshipChart},
+		{StrictSourceRelationshipComments, SourceRelationshipComments},
+		{StrictSourceRelationshipExtendProperties, SourceRelationshipExtendProperties},
+		{StrictSourceRelationshipImage, SourceRelationshipImage},
+		{StrictSourceRelationshipOfficeDocument, SourceRelationshipOfficeDocument},
+		{StrictSourceRelationship, SourceRelationship.Value},
+	} {
+		content = bytesReplace(content, []byte(namespace[0]), []byte(namespace[1]), -1)
 	}
 	return content
 }
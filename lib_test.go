@@ -283,6 +283,21 @@ func TestExtUnmarshalXML(t *testing.T) {
 	assert.Equal(t, extLst.Ext[0].URI, ExtURISlicerCachesX14)
 }
 
+func TestClientDataUnmarshalXML(t *testing.T) {
+	for content, expected := range map[string]xdrClientData{
+		`<clientData/>`:                                             {FLocksWithSheet: true, FPrintsWithSheet: true},
+		`<clientData fLocksWithSheet="0"/>`:                         {FLocksWithSheet: false, FPrintsWithSheet: true},
+		`<clientData fPrintsWithSheet="false"/>`:                    {FLocksWithSheet: true, FPrintsWithSheet: false},
+		`<clientData fLocksWithSheet="1" fPrintsWithSheet="true"/>`: {FLocksWithSheet: true, FPrintsWithSheet: true},
+	} {
+		var clientData xdrClientData
+		assert.NoError(t, xml.Unmarshal([]byte(content), &clientData))
+		assert.Equal(t, expected, clientData, content)
+	}
+	clientData := xdrClientData{}
+	assert.EqualError(t, clientData.UnmarshalXML(xml.NewDecoder(strings.NewReader("")), xml.StartElement{}), io.EOF.Error())
+}
+
 func TestBytesReplace(t *testing.T) {
 	s := []byte{0x01}
 	assert.EqualValues(t, s, bytesReplace(s, []byte{}, []byte{}, 0))
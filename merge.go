@@ -162,6 +162,66 @@ func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
 	return mergeCells, err
 }
 
+// RepairMergedCells provides a function to detect and resolve the overlapping
+// merged cells in the worksheet by given worksheet name. The merged cells are
+// checked in the order they are stored, the first merged cell will be kept
+// and the subsequent merged cells which overlap with it will be removed. This
+// function returns a report of the removed merged cells. For example, repair
+// the merged cells on Sheet1:
+//
+//	repairs, err := f.RepairMergedCells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, repair := range repairs {
+//	    fmt.Printf("removed %s overlaps with %s\n", repair.Removed, repair.Kept)
+//	}
+func (f *File) RepairMergedCells(sheet string) ([]MergedCellsRepair, error) {
+	var repairs []MergedCellsRepair
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return repairs, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.MergeCells == nil {
+		return repairs, err
+	}
+	kept := make([]*xlsxMergeCell, 0, len(ws.MergeCells.Cells))
+	for _, mergeCell := range ws.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := mergeCell.Rect()
+		if err != nil {
+			return repairs, err
+		}
+		_ = sortCoordinates(rect)
+		var overlap *xlsxMergeCell
+		for _, cell := range kept {
+			if rect[0] <= cell.rect[2] && cell.rect[0] <= rect[2] &&
+				rect[1] <= cell.rect[3] && cell.rect[1] <= rect[3] {
+				overlap = cell
+				break
+			}
+		}
+		if overlap != nil {
+			repairs = append(repairs, MergedCellsRepair{Removed: mergeCell.Ref, Kept: overlap.Ref})
+			continue
+		}
+		kept = append(kept, mergeCell)
+	}
+	ws.MergeCells.Cells = kept
+	ws.MergeCells.Count = len(ws.MergeCells.Cells)
+	if ws.MergeCells.Count == 0 {
+		ws.MergeCells = nil
+	}
+	return repairs, err
+}
+
 // overlapRange calculate overlap range of merged cells, and returns max
 // column and rows of the range.
 func overlapRange(ws *xlsxWorksheet) (row, col int, err error) {
@@ -286,6 +346,13 @@ func mergeCell(cell1, cell2 *xlsxMergeCell) *xlsxMergeCell {
 // example: []string{"D4:E10", "cell value"}
 type MergeCell []string
 
+// MergedCellsRepair directly maps the merged cell removed by the repair of
+// overlapping merged cells, and the kept merged cell which overlaps with it.
+type MergedCellsRepair struct {
+	Removed string
+	Kept    string
+}
+
 // GetCellValue returns merged cell value.
 func (m *MergeCell) GetCellValue() string {
 	return (*m)[1]
@@ -1,7 +1,9 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -108,6 +110,40 @@ func TestMergeCellOverlap(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestRepairMergedCells(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked = sync.Map{}
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/><mergeCells count="5"><mergeCell ref="A1:C2"/><mergeCell ref="B2:D3"/><mergeCell ref="F5:F1"/><mergeCell ref="E3:G3"/><mergeCell ref="A5:B6"/></mergeCells></worksheet>`))
+	repairs, err := f.RepairMergedCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergedCellsRepair{
+		{Removed: "B2:D3", Kept: "A1:C2"},
+		{Removed: "E3:G3", Kept: "F5:F1"},
+	}, repairs)
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `<mergeCells count="3"><mergeCell ref="A1:C2"></mergeCell><mergeCell ref="F5:F1"></mergeCell><mergeCell ref="A5:B6"></mergeCell></mergeCells>`)
+	// Test repair merged cells without overlapping merged cells
+	repairs, err = f.RepairMergedCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+	// Test repair merged cells without merged cells
+	assert.NoError(t, f.UnmergeCell("Sheet1", "A1", "F6"))
+	repairs, err = f.RepairMergedCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, repairs)
+	// Test repair merged cells with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{nil, {Ref: "A:B"}}}
+	_, err = f.RepairMergedCells("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test repair merged cells on not exists worksheet
+	_, err = f.RepairMergedCells("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestGetMergeCells(t *testing.T) {
 	wants := []struct {
 		value string
@@ -4770,6 +4770,31 @@ func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
 	return "", false
 }
 
+// isDateNumFmtCode provides a function to check if the given number format
+// code contains date tokens, the number formats which only contain time
+// tokens are not date formats.
+func isDateNumFmtCode(fmtCode string) bool {
+	var hasMonthOrMinute, hasTime bool
+	p := nfp.NumberFormatParser()
+	for _, section := range p.Parse(fmtCode) {
+		for _, token := range section.Items {
+			if token.TType == nfp.TokenTypeElapsedDateTimes {
+				hasTime = true
+			}
+			if token.TType != nfp.TokenTypeDateTimes {
+				continue
+			}
+			val := strings.ToUpper(token.TValue)
+			if strings.ContainsAny(val, "YDE") {
+				return true
+			}
+			hasMonthOrMinute = hasMonthOrMinute || strings.Contains(val, "M")
+			hasTime = hasTime || strings.ContainsAny(val, "HS")
+		}
+	}
+	return hasMonthOrMinute && !hasTime
+}
+
 // prepareNumberic split the number into two before and after parts by a
 // decimal point.
 func (nf *numberFormat) prepareNumberic(value string) {
@@ -4950,6 +4975,22 @@ func printCommaSep(text string) string {
 	return target.String()
 }
 
+// localSeparators replace the invariant decimal and thousands separators in
+// the formatted number with the separators specified in the options.
+func (nf *numberFormat) localSeparators(text string) string {
+	if nf.opts == nil || (nf.opts.DecimalSeparator == "" && nf.opts.ThousandsSeparator == "") {
+		return text
+	}
+	decimalSep, thousandsSep := ".", ","
+	if nf.opts.DecimalSeparator != "" {
+		decimalSep = nf.opts.DecimalSeparator
+	}
+	if nf.opts.ThousandsSeparator != "" {
+		thousandsSep = nf.opts.ThousandsSeparator
+	}
+	return strings.NewReplacer(".", decimalSep, ",", thousandsSep).Replace(text)
+}
+
 // printSwitchArgument format number with switch argument.
 func (nf *numberFormat) printSwitchArgument(text string) string {
 	if nf.switchArgument == "" {
@@ -5015,7 +5056,7 @@ func (nf *numberFormat) numberHandler() string {
 	}
 	if isNum, precision, decimal := isNumeric(nf.value); isNum {
 		if precision > 15 && intLen+fracLen > 15 && !nf.useScientificNotation {
-			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
+			return nf.printNumberLiteral(nf.localSeparators(nf.printBigNumber(decimal, fracLen)))
 		}
 	}
 	paddingLen := intLen + fracLen
@@ -5038,7 +5079,7 @@ func (nf *numberFormat) numberHandler() string {
 	if result = fmt.Sprintf(fmtCode, math.Abs(num)); nf.useCommaSep {
 		result = printCommaSep(result)
 	}
-	return nf.printNumberLiteral(result)
+	return nf.printNumberLiteral(nf.localSeparators(result))
 }
 
 // dateTimeHandler handling data and time number format expression for a
@@ -3589,6 +3589,22 @@ func TestNumFmt(t *testing.T) {
 		})
 		assert.Equal(t, item[2], result, item)
 	}
+	// Test format number with specified decimal and thousands separators
+	for _, item := range [][]string{
+		{"1234.56", "#,##0.00", "1.234,56"},
+		{"-1234567.891", "#,##0.000", "-1.234.567,891"},
+		{"1234.56", "0.0", "1234,6"},
+		{"0.1234", "0.00%", "12,34%"},
+		{"1234.56", "\"$\"#,##0.00", "$1.234,56"},
+		{"12345678901234567.89", "#,##0.00", "12.345.678.901.234.568,00"},
+	} {
+		result := format(item[0], item[1], false, CellTypeNumber, &Options{
+			DecimalSeparator:   ",",
+			ThousandsSeparator: ".",
+		})
+		assert.Equal(t, item[2], result, item)
+	}
+	assert.Equal(t, "1 234.56", format("1234.56", "#,##0.00", false, CellTypeNumber, &Options{ThousandsSeparator: " "}))
 	// Test format number with string data type cell value
 	for _, cellType := range []CellType{CellTypeSharedString, CellTypeInlineString} {
 		for _, item := range [][]string{
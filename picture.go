@@ -149,7 +149,9 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // graph object in a spreadsheet: "oneCell" (Move but don't size with
 // cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
 // size with cells). If you don't set this parameter, the default positioning
-// is to move and size with cells.
+// is to move and size with cells. The positioning, print and locked settings
+// of the picture will be returned in the format of the pictures by the
+// GetPictures function.
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
 	var err error
 	// Check picture exists first.
@@ -581,6 +583,12 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			pic.Format.Positioning = a.EditAs
+			pic.Format.Locked, pic.Format.PrintObject = boolPtr(true), boolPtr(true)
+			if a.ClientData != nil {
+				pic.Format.Locked = boolPtr(a.ClientData.FLocksWithSheet)
+				pic.Format.PrintObject = boolPtr(a.ClientData.FPrintsWithSheet)
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -589,6 +597,14 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		if buffer, _ := f.Pkg.Load(strings.ReplaceAll(r.Target, "..", "xl")); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			pic.Format.Positioning = a.EditAs
+			pic.Format.Locked, pic.Format.PrintObject = boolPtr(true), boolPtr(true)
+			if a.ClientData != nil && a.ClientData.FLocksWithSheet != nil {
+				pic.Format.Locked = a.ClientData.FLocksWithSheet
+			}
+			if a.ClientData != nil && a.ClientData.FPrintsWithSheet != nil {
+				pic.Format.PrintObject = a.ClientData.FPrintsWithSheet
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -636,6 +652,7 @@ func (f *File) extractDecodeCellAnchor(anchor *xdrCellAnchor, drawingRelationshi
 		deCellAnchor = new(decodeCellAnchor)
 	)
 	_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+	deCellAnchor.EditAs = anchor.EditAs
 	if deCellAnchor.From != nil && deCellAnchor.Pic != nil {
 		if cond(deCellAnchor.From) {
 			if drawRel = f.getDrawingRelationships(drawingRelationships, deCellAnchor.Pic.BlipFill.Blip.Embed); drawRel != nil {
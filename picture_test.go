@@ -143,6 +143,53 @@ func TestAddPictureErrors(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPicturePositioning(t *testing.T) {
+	f := NewFile()
+	for cell, opts := range map[string]*GraphicOptions{
+		"B2":  {Positioning: "twoCell"},
+		"B20": {Positioning: "oneCell"},
+		"B40": {Positioning: "absolute", PrintObject: boolPtr(false)},
+	} {
+		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.png"), opts))
+	}
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	anchors := make(map[string]xdrCellAnchor)
+	for _, anchor := range wsDr.TwoCellAnchor {
+		anchors[anchor.EditAs] = *anchor
+	}
+	assert.False(t, anchors["absolute"].ClientData.FPrintsWithSheet)
+	assert.True(t, anchors["oneCell"].ClientData.FPrintsWithSheet)
+	// Test the pictures move or size with cells on inserting rows
+	twoCellFrom, twoCellTo := *anchors["twoCell"].From, *anchors["twoCell"].To
+	oneCellFrom, oneCellTo := *anchors["oneCell"].From, *anchors["oneCell"].To
+	absoluteFrom, absoluteTo := *anchors["absolute"].From, *anchors["absolute"].To
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2))
+	assert.Equal(t, twoCellFrom, *anchors["twoCell"].From)
+	assert.Equal(t, twoCellTo.Row+2, anchors["twoCell"].To.Row)
+	assert.Equal(t, oneCellFrom.Row+2, anchors["oneCell"].From.Row)
+	assert.Equal(t, oneCellTo.Row+2, anchors["oneCell"].To.Row)
+	assert.Equal(t, absoluteFrom, *anchors["absolute"].From)
+	assert.Equal(t, absoluteTo, *anchors["absolute"].To)
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]*GraphicOptions{
+		"B2":  {Positioning: "twoCell", Locked: boolPtr(true), PrintObject: boolPtr(true)},
+		"B22": {Positioning: "oneCell", Locked: boolPtr(true), PrintObject: boolPtr(true)},
+		"B40": {Positioning: "absolute", Locked: boolPtr(true), PrintObject: boolPtr(false)},
+	} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1, cell)
+		assert.Equal(t, expected, pics[0].Format, cell)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestGetPicture(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
@@ -17,6 +17,7 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -78,6 +79,66 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 	return results[:maxVal], rows.Close()
 }
 
+// GetRowsAsMaps provides a function to get the rows after the header row in a
+// sheet by given worksheet name and header row number, each row returned as a
+// map keyed by the header names. The duplicate header names will be suffixed
+// with the sequence number of their occurrence, such as "Name", "Name_2", and
+// the blank header names will be replaced by the column names. The blank rows
+// after the header row will be skipped, and the cells beyond the last header
+// column will be ignored. For example, get the data rows with
+// the header in the first row on a worksheet named 'Sheet1':
+//
+//	rows, err := f.GetRowsAsMaps("Sheet1", 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, row := range rows {
+//	    fmt.Println(row["Name"], row["Age"])
+//	}
+func (f *File) GetRowsAsMaps(sheet string, headerRow int, opts ...Options) ([]map[string]string, error) {
+	if headerRow < 1 {
+		return nil, newInvalidRowNumberError(headerRow)
+	}
+	if headerRow > TotalRows {
+		return nil, ErrMaxRows
+	}
+	rows, err := f.GetRows(sheet, opts...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]map[string]string, 0, 64)
+	if headerRow > len(rows) {
+		return results, nil
+	}
+	header, keys, seen := rows[headerRow-1], []string{}, map[string]int{}
+	for idx, name := range header {
+		if name == "" {
+			name, _ = ColumnNumberToName(idx + 1)
+		}
+		key := name
+		for seen[key] > 0 {
+			seen[name]++
+			key = name + "_" + strconv.Itoa(seen[name])
+		}
+		seen[key]++
+		keys = append(keys, key)
+	}
+	for _, row := range rows[headerRow:] {
+		if len(row) == 0 {
+			continue
+		}
+		result := make(map[string]string, len(keys))
+		for idx, key := range keys {
+			if result[key] = ""; idx < len(row) {
+				result[key] = row[idx]
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
@@ -152,7 +213,7 @@ func (rows *Rows) Columns(opts ...Options) ([]string, error) {
 	var rowIterator rowXMLIterator
 	var token xml.Token
 	rows.rawCellValue = rows.f.getOptions(opts...).RawCellValue
-	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
+	if rows.sst, rowIterator.err = rows.f.sharedStringsValueReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
 	for {
@@ -235,7 +296,11 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 			}
 		}
 		blank := rowIterator.cellCol - len(rowIterator.cells)
-		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
+		val, err := colCell.getValueFrom(rows.f, rows.sst, raw)
+		if rowIterator.err = err; err != nil {
+			return
+		}
+		if val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
 		}
 	}
@@ -335,6 +400,49 @@ func (f *File) getFromStringItem(index int) string {
 	return f.getFromStringItem(index)
 }
 
+// getFromLazyStringItem provides a function to get shared string item by
+// given index without parsing the whole shared string table. The shared string
+// items will be decoded on demand until the given index, and the decoded items
+// will be cached for subsequent reads.
+func (f *File) getFromLazyStringItem(index int) (string, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.SharedStrings != nil {
+		f.SharedStrings.mu.Lock()
+		defer f.SharedStrings.mu.Unlock()
+		if index < len(f.SharedStrings.SI) {
+			return f.SharedStrings.SI[index].String(), true, nil
+		}
+		return "", false, nil
+	}
+	if f.sharedStringLazy == nil {
+		f.sharedStringLazy = []string{}
+		f.sharedStringDec = f.xmlNewDecoder(bytes.NewReader(
+			namespaceStrictToTransitional(f.readXML(defaultXMLPathSharedStrings))))
+	}
+	for f.sharedStringDec != nil && len(f.sharedStringLazy) <= index {
+		token, err := f.sharedStringDec.Token()
+		if err == io.EOF {
+			f.sharedStringDec = nil
+			break
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if xmlElement, ok := token.(xml.StartElement); ok && xmlElement.Name.Local == "si" {
+			var si xlsxSI
+			if err = f.sharedStringDec.DecodeElement(&si, &xmlElement); err != nil {
+				return "", false, err
+			}
+			f.sharedStringLazy = append(f.sharedStringLazy, si.String())
+		}
+	}
+	if index < len(f.sharedStringLazy) {
+		return f.sharedStringLazy[index], true, nil
+	}
+	return "", false, nil
+}
+
 // xmlDecoder creates XML decoder by given path in the zip from memory data
 // or system temporary file.
 func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
@@ -343,6 +451,9 @@ func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 		err      error
 		tempFile *os.File
 	)
+	if _, err = f.loadLazyFile(name); err != nil {
+		return false, nil, tempFile, err
+	}
 	if content = f.readXML(name); len(content) > 0 {
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
@@ -431,6 +542,17 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	return ht, nil
 }
 
+// sharedStringsValueReader provides a function to get the shared string table
+// for reading the cell values. The shared string table will not be parsed and
+// nil will be returned if the LazySharedStrings option is enabled, and the
+// shared string items will be decoded on demand.
+func (f *File) sharedStringsValueReader() (*xlsxSST, error) {
+	if f.options != nil && f.options.LazySharedStrings {
+		return nil, nil
+	}
+	return f.sharedStringsReader()
+}
+
 // sharedStringsReader provides a function to get the pointer to the structure
 // after deserialization of xl/sharedStrings.xml.
 func (f *File) sharedStringsReader() (*xlsxSST, error) {
@@ -452,6 +574,7 @@ func (f *File) sharedStringsReader() (*xlsxSST, error) {
 			sharedStrings.UniqueCount = sharedStrings.Count
 		}
 		f.SharedStrings = &sharedStrings
+		f.sharedStringLazy, f.sharedStringDec = nil, nil
 		for i := range sharedStrings.SI {
 			if sharedStrings.SI[i].T != nil {
 				f.sharedStringsMap[sharedStrings.SI[i].T.Val] = i
@@ -554,6 +677,57 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return ws.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// SetRowIndentByOutline provides a function to set the indentation of the
+// first cell (column A) in each row of the range by given worksheet name,
+// start and end row number, the indentation level will be equal to the outline
+// level of the row. The other style settings of the cells will be kept. This
+// function can be used to keep a readable hierarchy when the outlined data is
+// exported without grouping. For example, set indentation by outline level for
+// rows 2 to 10 in Sheet1:
+//
+//	err := f.SetRowIndentByOutline("Sheet1", 2, 10)
+func (f *File) SetRowIndentByOutline(sheet string, startRow, endRow int) error {
+	if endRow < startRow {
+		startRow, endRow = endRow, startRow
+	}
+	if startRow < 1 {
+		return newInvalidRowNumberError(startRow)
+	}
+	if endRow > TotalRows {
+		return ErrMaxRows
+	}
+	for row := startRow; row <= endRow; row++ {
+		level, err := f.GetRowOutlineLevel(sheet, row)
+		if err != nil {
+			return err
+		}
+		cell, _ := CoordinatesToCellName(1, row)
+		styleID, err := f.GetCellStyle(sheet, cell)
+		if err != nil {
+			return err
+		}
+		style, err := f.GetStyle(styleID)
+		if err != nil {
+			return err
+		}
+		if (style.Alignment == nil && level == 0) || (style.Alignment != nil && style.Alignment.Indent == int(level)) {
+			continue
+		}
+		if err = f.updateCellStyle(sheet, cell, func(style *Style) {
+			if style.Alignment == nil {
+				style.Alignment = &Alignment{}
+			}
+			if style.Alignment.Indent = int(level); level > 0 &&
+				inStrSlice([]string{"left", "right", "distributed"}, style.Alignment.Horizontal, true) == -1 {
+				style.Alignment.Horizontal = "left"
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -593,11 +767,18 @@ func (f *File) RemoveRow(sheet string, row int) error {
 //
 //	err := f.InsertRows("Sheet1", 3, 2)
 //
+// The optional InsertOptions can be used to copy the styles and data
+// validations of the adjacent row into the inserted rows, like "insert and
+// copy formatting" in the spreadsheet application. For example, create two
+// rows before row 3 in Sheet1 with the formatting of row 2:
+//
+//	err := f.InsertRows("Sheet1", 3, 2, excelize.InsertOptions{FormatFrom: "above"})
+//
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) InsertRows(sheet string, row, n int) error {
+func (f *File) InsertRows(sheet string, row, n int, opts ...InsertOptions) error {
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -607,7 +788,52 @@ func (f *File) InsertRows(sheet string, row, n int) error {
 	if n < 1 {
 		return ErrParameterInvalid
 	}
-	return f.adjustHelper(sheet, rows, row, n)
+	var src int
+	for _, opt := range opts {
+		switch opt.FormatFrom {
+		case "":
+			src = 0
+		case "above":
+			src = row - 1
+		case "below":
+			src = row + n
+		default:
+			return ErrParameterInvalid
+		}
+	}
+	if err := f.adjustHelper(sheet, rows, row, n); err != nil || src == 0 {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	return f.inheritRowsFormat(ws, sheet, src, row, n)
+}
+
+// inheritRowsFormat provides a function to copy the styles and data
+// validations of the given source row into the inserted rows.
+func (f *File) inheritRowsFormat(ws *xlsxWorksheet, sheet string, src, row, n int) error {
+	if src > len(ws.SheetData.Row) {
+		return nil
+	}
+	srcRow := ws.SheetData.Row[src-1]
+	for r := row; r < row+n; r++ {
+		ws.prepareSheetXML(len(srcRow.C), r)
+		rowData := &ws.SheetData.Row[r-1]
+		rowData.S, rowData.CustomFormat = srcRow.S, srcRow.CustomFormat
+		rowData.CustomHeight, rowData.Ht = srcRow.CustomHeight, nil
+		if srcRow.Ht != nil {
+			rowData.Ht = float64Ptr(*srcRow.Ht)
+		}
+		for i := range srcRow.C {
+			rowData.C[i].S = srcRow.C[i].S
+		}
+		if err := f.duplicateDataValidations(ws, sheet, src, r); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DuplicateRow inserts a copy of specified row (by its Excel row number) below
@@ -780,6 +1006,238 @@ func (f *File) duplicateMergeCells(ws *xlsxWorksheet, sheet string, row, row2 in
 	return nil
 }
 
+// sortKeyValue defined the value of the sort key cell for sorting the range.
+// The kind orders the values by numbers, texts, logical values and error
+// values, and the blank cells will be always placed at the end.
+type sortKeyValue struct {
+	kind   int
+	number float64
+	text   string
+}
+
+// newSortKeyValue create the value of the sort key cell by given cell value
+// and cell type.
+func newSortKeyValue(value string, cellType CellType) sortKeyValue {
+	switch {
+	case value == "":
+		return sortKeyValue{kind: 4}
+	case cellType == CellTypeBool:
+		return sortKeyValue{kind: 2, text: value}
+	case cellType == CellTypeError:
+		return sortKeyValue{kind: 3, text: value}
+	case cellType != CellTypeSharedString && cellType != CellTypeInlineString:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return sortKeyValue{kind: 0, number: number}
+		}
+	}
+	return sortKeyValue{kind: 1, text: value}
+}
+
+// compareSortKeyValue compares two values of the sort key cells by given sort
+// key settings, returns a negative number if the first value should be
+// placed before the second value.
+func compareSortKeyValue(a, b sortKeyValue, key SortKey) int {
+	if a.kind != b.kind {
+		result := a.kind - b.kind
+		if key.Descending && a.kind != 4 && b.kind != 4 {
+			return -result
+		}
+		return result
+	}
+	var result int
+	switch a.kind {
+	case 0:
+		if a.number < b.number {
+			result = -1
+		} else if a.number > b.number {
+			result = 1
+		}
+	case 1, 2:
+		lhs, rhs := a.text, b.text
+		if !key.CaseSensitive {
+			lhs, rhs = strings.ToLower(lhs), strings.ToLower(rhs)
+		}
+		result = strings.Compare(lhs, rhs)
+	}
+	if key.Descending {
+		return -result
+	}
+	return result
+}
+
+// SortRange provides a function to sort the rows of the range by given
+// worksheet name, range reference and sort options. The rows will be sorted
+// by one or more key columns, and each key could be in ascending or
+// descending order with optional case sensitivity. The numbers will be placed
+// before the texts, logical values and error values in ascending order, and
+// the blank cells will be always placed at the end. The sort is stable, the
+// rows with the same key values will keep their original order. Set the
+// HasHeader to exclude the first row of the range from sorting. The first
+// column of the range will be used as the key column if the keys are not
+// specified.
+//
+// The values and styles of the cells within the range will be moved with
+// their rows, and the cells outside the range will be kept. The relative row
+// references in the formulas of the moved cells will be adjusted by the
+// distance of the movement, the absolute row references will be kept, and
+// the formulas will not be evaluated. The shared formulas which intersect the
+// range will be converted to the normal formulas before sorting. It returns
+// ErrSortMergedCells if the rows to be sorted contain merged cells. For
+// example, sort the rows of the range A2:C6 on Sheet1 by the column C in
+// descending order, and then by the column A in ascending order:
+//
+//	err := f.SortRange("Sheet1", "A2:C6", excelize.SortOptions{
+//	    Keys: []excelize.SortKey{
+//	        {Column: "C", Descending: true},
+//	        {Column: "A"},
+//	    },
+//	})
+func (f *File) SortRange(sheet, rangeRef string, opts SortOptions) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	if opts.HasHeader {
+		y1++
+	}
+	keys, keyCols := opts.Keys, make([]int, len(opts.Keys))
+	if len(keys) == 0 {
+		colName, _ := ColumnNumberToName(x1)
+		keys, keyCols = []SortKey{{Column: colName}}, []int{x1}
+	}
+	for i, key := range opts.Keys {
+		if keyCols[i], err = ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if keyCols[i] < x1 || keyCols[i] > x2 {
+			return ErrParameterInvalid
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil || y1 >= y2 {
+		return err
+	}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			ref := mergeCell.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			rect, err := rangeRefToCoordinates(ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(rect)
+			if rect[0] <= x2 && rect[2] >= x1 && rect[1] <= y2 && rect[3] >= y1 {
+				return ErrSortMergedCells
+			}
+		}
+	}
+	n := y2 - y1 + 1
+	values, formulas := make([][]sortKeyValue, n), make([][]string, n)
+	for i := 0; i < n; i++ {
+		for _, col := range keyCols {
+			cell, _ := CoordinatesToCellName(col, y1+i)
+			value, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return err
+			}
+			cellType, _ := f.GetCellType(sheet, cell)
+			values[i] = append(values[i], newSortKeyValue(value, cellType))
+		}
+		for col := x1; col <= x2; col++ {
+			cell, _ := CoordinatesToCellName(col, y1+i)
+			formula, err := f.GetCellFormula(sheet, cell)
+			if err != nil {
+				return err
+			}
+			formulas[i] = append(formulas[i], formula)
+		}
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		for k, key := range keys {
+			if result := compareSortKeyValue(values[order[i]][k], values[order[j]][k], key); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.expandSharedFormulas([]int{x1, y1, x2, y2})
+	cells := make([][]xlsxC, n)
+	for i := 0; i < n; i++ {
+		ws.prepareSheetXML(x2, y1+i)
+		cells[i] = append([]xlsxC{}, ws.SheetData.Row[y1+i-1].C[x1-1:x2]...)
+	}
+	for i, src := range order {
+		row := y1 + i
+		for j, c := range cells[src] {
+			c.R, _ = CoordinatesToCellName(x1+j, row)
+			if c.F != nil && formulas[src][j] != "" {
+				formula, ref := formulas[src][j], ""
+				if formula, err = f.shiftFormulaRelativeRows(sheet, formula, i-src); err != nil {
+					return err
+				}
+				if c.F.T == STCellFormulaTypeArray && c.F.Ref != "" {
+					ref, _ = f.shiftFormulaRelativeRows(sheet, c.F.Ref, i-src)
+				}
+				c.F, c.f = &xlsxF{Content: formula, T: c.F.T, Ref: ref}, ""
+			}
+			ws.SheetData.Row[row-1].C[x1+j-1] = c
+		}
+	}
+	return err
+}
+
+// expandSharedFormulas provides a function to convert the cells of the shared
+// formula groups which intersect the given range to the normal formulas, so
+// the cells of these groups could be moved individually.
+func (ws *xlsxWorksheet) expandSharedFormulas(coordinates []int) {
+	masters, groups := map[int]xlsxC{}, map[int]bool{}
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil {
+				continue
+			}
+			if c.F.Ref != "" {
+				masters[*c.F.Si] = c
+			}
+			if col, row, err := CellNameToCoordinates(c.R); err == nil && cellInRange([]int{col, row}, coordinates) {
+				groups[*c.F.Si] = true
+			}
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	for i, r := range ws.SheetData.Row {
+		for j, c := range r.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeShared || c.F.Si == nil || !groups[*c.F.Si] {
+				continue
+			}
+			master, ok := masters[*c.F.Si]
+			col, row, err := CellNameToCoordinates(c.R)
+			if !ok || err != nil {
+				continue
+			}
+			masterCol, masterRow, _ := CellNameToCoordinates(master.R)
+			ws.SheetData.Row[i].C[j].F = &xlsxF{Content: shiftFormula(master.F.Content, col-masterCol, row-masterRow)}
+		}
+	}
+}
+
 // checkRow provides a function to check and fill each column element for all
 // rows and make that is continuous in a worksheet of XML. For example:
 //
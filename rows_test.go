@@ -21,6 +21,36 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetRowsAsMaps(t *testing.T) {
+	f := NewFile()
+	for cell, row := range map[string]*[]interface{}{
+		"A1": {"Title"},
+		"A2": {"Name", "Age", "Name", "", "Name_2", "Name"},
+		"A3": {"Alice", 30, "A", "x", "y", "z", "ignored"},
+		"A5": {"Bob", 25},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, row))
+	}
+	rows, err := f.GetRowsAsMaps("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"Name": "Alice", "Age": "30", "Name_2": "A", "D": "x", "Name_2_2": "y", "Name_3": "z"},
+		{"Name": "Bob", "Age": "25", "Name_2": "", "D": "", "Name_2_2": "", "Name_3": ""},
+	}, rows)
+	// Test get rows as maps with the header row after the last row
+	rows, err = f.GetRowsAsMaps("Sheet1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test get rows as maps with invalid header row number
+	_, err = f.GetRowsAsMaps("Sheet1", 0)
+	assert.Equal(t, newInvalidRowNumberError(0), err)
+	_, err = f.GetRowsAsMaps("Sheet1", TotalRows+1)
+	assert.Equal(t, ErrMaxRows, err)
+	// Test get rows as maps on not exists worksheet
+	_, err = f.GetRowsAsMaps("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -296,6 +326,48 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestSetRowIndentByOutline(t *testing.T) {
+	f := NewFile()
+	levels := []uint8{0, 1, 2, 1}
+	for idx, level := range levels {
+		row := idx + 1
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("Level %d", level)))
+		if level > 0 {
+			assert.NoError(t, f.SetRowOutlineLevel("Sheet1", row, level))
+		}
+	}
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", styleID))
+	assert.NoError(t, f.SetRowIndentByOutline("Sheet1", 4, 1))
+	for idx, level := range levels {
+		styleID, err := f.GetCellStyle("Sheet1", fmt.Sprintf("A%d", idx+1))
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		if level == 0 {
+			assert.Nil(t, style.Alignment)
+			continue
+		}
+		assert.Equal(t, int(level), style.Alignment.Indent)
+		assert.Equal(t, "left", style.Alignment.Horizontal)
+	}
+	styleID, err = f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	// Test set row indentation by outline level with invalid row number
+	assert.EqualError(t, f.SetRowIndentByOutline("Sheet1", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.SetRowIndentByOutline("Sheet1", 1, TotalRows+1))
+	// Test set row indentation by outline level on not exists worksheet
+	assert.EqualError(t, f.SetRowIndentByOutline("SheetN", 1, 1), "sheet SheetN does not exist")
+	// Test set row indentation by outline level with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRowIndentByOutline("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestRemoveRow(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -425,6 +497,65 @@ func TestInsertRowsInEmptyFile(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowInEmptyFile.xlsx")))
 }
 
+func TestInsertRowsWithFormat(t *testing.T) {
+	f := NewFile()
+	styleID1, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}})
+	assert.NoError(t, err)
+	styleID2, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", styleID1))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "C2", styleID2))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 30))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:C1"
+	assert.NoError(t, dv.SetRange(0, 10, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test insert rows with the formatting of the row above
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 2, InsertOptions{FormatFrom: "above"}))
+	for _, cell := range []string{"A2", "C2", "A3", "C3"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID1, styleID, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val, cell)
+	}
+	ht, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, ht)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	var sqrefs []string
+	for _, dv := range dvs {
+		sqrefs = append(sqrefs, dv.Sqref)
+	}
+	assert.Equal(t, []string{"A1:C1", "A2:C2", "A3:C3"}, sqrefs)
+	// Test insert rows with the formatting of the row below
+	assert.NoError(t, f.InsertRows("Sheet1", 4, 1, InsertOptions{FormatFrom: "below"}))
+	styleID, err := f.GetCellStyle("Sheet1", "B4")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID2, styleID)
+	val, err := f.GetCellValue("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.Equal(t, "5", val)
+	// Test insert rows without adjacent row
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1, InsertOptions{FormatFrom: "above"}))
+	assert.NoError(t, f.InsertRows("Sheet1", 10, 1, InsertOptions{FormatFrom: "below"}))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test insert rows with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.InsertRows("Sheet1", 2, 1, InsertOptions{FormatFrom: "left"}))
+	// Test insert rows with invalid data validation range
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).DataValidations.DataValidation[0].Sqref = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertRows("Sheet1", 3, 1, InsertOptions{FormatFrom: "above"}))
+	assert.NoError(t, f.Close())
+}
+
 func prepareTestBook2() (*File, error) {
 	f := NewFile()
 	for cell, val := range map[string]string{
@@ -915,6 +1046,8 @@ func TestDuplicateRow(t *testing.T) {
 	cfs, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, cfs, 2)
+	expected[0].Style, err = f.GetConditionalStyle(format)
+	assert.NoError(t, err)
 	assert.Equal(t, expected, cfs["A10:A10"])
 
 	dvs, err := f.GetDataValidations("Sheet1")
@@ -998,6 +1131,151 @@ func TestErrSheetNotExistError(t *testing.T) {
 	assert.Equal(t, "sheet Sheet1 does not exist", ErrSheetNotExist{"Sheet1"}.Error())
 }
 
+func TestSortRange(t *testing.T) {
+	f := NewFile()
+	for cell, row := range map[string][]interface{}{
+		"A1": {"Name", "Score", "Double", "Ratio"},
+		"A2": {"Alice", 30},
+		"A3": {"Bob", 50},
+		"A4": {"Carol", 10},
+		"A5": {"Dave", 40},
+		"A6": {"Eve", 20},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "F1", 10))
+	for row := 2; row <= 6; row++ {
+		assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("C%d", row), fmt.Sprintf("B%d*2", row)))
+		assert.NoError(t, f.SetCellFormula("Sheet1", fmt.Sprintf("D%d", row), fmt.Sprintf("B%d/$F$1", row)))
+		style, err := f.NewStyle(&Style{Font: &Font{Size: float64(row + 10)}})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), style))
+	}
+	styles := map[string]int{}
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave", "Eve"} {
+		result, err := f.SearchSheet("Sheet1", name)
+		assert.NoError(t, err)
+		styles[name], err = f.GetCellStyle("Sheet1", result[0])
+		assert.NoError(t, err)
+	}
+	// Test sort range by a numeric column in descending order
+	assert.NoError(t, f.SortRange("Sheet1", "A1:D6", SortOptions{
+		HasHeader: true,
+		Keys:      []SortKey{{Column: "B", Descending: true}},
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Score", "Double", "Ratio", "", "10"},
+		{"Bob", "50"}, {"Dave", "40"}, {"Alice", "30"}, {"Eve", "20"}, {"Carol", "10"},
+	}, [][]string{rows[0], rows[1][:2], rows[2][:2], rows[3][:2], rows[4][:2], rows[5][:2]})
+	for row, name := range []string{"Bob", "Dave", "Alice", "Eve", "Carol"} {
+		for _, col := range []string{"A", "B"} {
+			styleID, err := f.GetCellStyle("Sheet1", fmt.Sprintf("%s%d", col, row+2))
+			assert.NoError(t, err)
+			assert.Equal(t, styles[name], styleID)
+		}
+		formula, err := f.GetCellFormula("Sheet1", fmt.Sprintf("C%d", row+2))
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("B%d*2", row+2), formula)
+		formula, err = f.GetCellFormula("Sheet1", fmt.Sprintf("D%d", row+2))
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("B%d/$F$1", row+2), formula)
+	}
+	result, err := f.CalcCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", result)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSortRange.xlsx")))
+
+	// Test sort range by multiple key columns with mixed type values
+	f = NewFile()
+	for cell, row := range map[string][]interface{}{
+		"A1": {"b", 2},
+		"A2": {nil, 1},
+		"A3": {"B", 1},
+		"A4": {true, 3},
+		"A5": {3, 4},
+		"A6": {"a", 5},
+		"A7": {"b", 1},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C6", "A$1&B5"))
+	assert.NoError(t, f.SortRange("Sheet1", "C7:A1", SortOptions{
+		Keys: []SortKey{{Column: "A"}, {Column: "B", Descending: true}},
+	}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"3", "4"}, {"a", "5", ""}, {"b", "2"}, {"B", "1"}, {"b", "1"}, {"TRUE", "3"}, {"", "1"}}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A$1&B1", formula)
+	// Test sort range with case sensitive and the first column as the default key
+	assert.NoError(t, f.SortRange("Sheet1", "A2:B5", SortOptions{
+		Keys: []SortKey{{Column: "A", CaseSensitive: true, Descending: true}},
+	}))
+	assert.NoError(t, f.SortRange("Sheet1", "A3:B5", SortOptions{}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"3", "4"}, {"b", "2", ""}, {"a", "5"}, {"b", "1"}, {"B", "1"}, {"TRUE", "3"}, {"", "1"}}, rows)
+	// Test sort range with the shifted row reference out of the worksheet
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C4", "A1+B$2"))
+	assert.NoError(t, f.SortRange("Sheet1", "A1:C7", SortOptions{Keys: []SortKey{{Column: "B"}}}))
+	formula, err = f.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!+B$2", formula)
+	// Test sort range with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SortRange("Sheet1", "A:B2", SortOptions{}))
+	// Test sort range with invalid key column
+	assert.Equal(t, newInvalidColumnNameError("-"), f.SortRange("Sheet1", "A1:B2", SortOptions{Keys: []SortKey{{Column: "-"}}}))
+	assert.Equal(t, ErrParameterInvalid, f.SortRange("Sheet1", "A1:B2", SortOptions{Keys: []SortKey{{Column: "C"}}}))
+	// Test sort range on not exists worksheet
+	assert.EqualError(t, f.SortRange("SheetN", "A1:B2", SortOptions{}), "sheet SheetN does not exist")
+	// Test sort range with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SortRange("Sheet1", "A1:B7", SortOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test sort range which intersects the shared formula group
+	f = NewFile()
+	for row, value := range []int{3, 1, 2, 5, 4} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row+1), value))
+	}
+	formulaType, ref := STCellFormulaTypeShared, "B1:B5"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SortRange("Sheet1", "A1:B3", SortOptions{}))
+	for row, expected := range []string{"2", "4", "6", "10", "8"} {
+		cell := fmt.Sprintf("B%d", row+1)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("A%d*2", row+1), formula, cell)
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test sort range which contains merged cells
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "m"))
+	assert.Equal(t, ErrSortMergedCells, f.SortRange("Sheet1", "A1:D3", SortOptions{Keys: []SortKey{{Column: "A", Descending: true}}}))
+	assert.Equal(t, ErrSortMergedCells, f.SortRange("Sheet1", "D1:D3", SortOptions{}))
+	assert.NoError(t, f.SortRange("Sheet1", "A1:D3", SortOptions{HasHeader: true, Keys: []SortKey{{Column: "A", Descending: true}}}))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3", "2", "5", "4"}, cols[0])
+	assert.Equal(t, "m", cols[2][0])
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "C1:D1", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	// Test sort range with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "C1:D"
+	assert.Equal(t, newCellNameToCoordinatesError("D", newInvalidCellNameError("D")), f.SortRange("Sheet1", "A2:B3", SortOptions{}))
+	assert.NoError(t, f.Close())
+}
+
 func TestCheckRow(t *testing.T) {
 	f := NewFile()
 	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ><sheetData><row r="2"><c><v>1</v></c><c r="F2"><v>2</v></c><c><v>3</v></c><c><v>4</v></c><c r="M2"><v>5</v></c></row></sheetData></worksheet>`))
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"html"
 	"io"
 	"os"
 	"path"
@@ -316,6 +317,42 @@ func (f *File) SetActiveSheet(index int) {
 	}
 }
 
+// SetSelectedSheets provides a function to set the selected worksheets group
+// by given worksheet names. The active sheet will be kept if it is in the
+// selected worksheets group, otherwise the first given worksheet will be set
+// as the active sheet. For example, select the worksheets Sheet1 and Sheet2
+// as a group:
+//
+//	err := f.SetSelectedSheets([]string{"Sheet1", "Sheet2"})
+func (f *File) SetSelectedSheets(sheets []string) error {
+	if len(sheets) == 0 {
+		return ErrParameterRequired
+	}
+	var wss []*xlsxWorksheet
+	for _, sheet := range sheets {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		wss = append(wss, ws)
+	}
+	activeSheetIndex := f.GetActiveSheetIndex()
+	if inStrSlice(sheets, f.GetSheetName(activeSheetIndex), false) == -1 {
+		activeSheetIndex, _ = f.GetSheetIndex(sheets[0])
+	}
+	f.SetActiveSheet(activeSheetIndex)
+	for _, ws := range wss {
+		if ws.SheetViews == nil {
+			ws.SheetViews = &xlsxSheetViews{}
+		}
+		if len(ws.SheetViews.SheetView) == 0 {
+			ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{})
+		}
+		ws.SheetViews.SheetView[0].TabSelected = true
+	}
+	return nil
+}
+
 // GetActiveSheetIndex provides a function to get active sheet index of the
 // spreadsheet. If not found the active sheet will be return integer 0.
 func (f *File) GetActiveSheetIndex() (index int) {
@@ -486,6 +523,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.lazyFiles.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -589,6 +629,7 @@ func (f *File) DeleteSheet(sheet string) error {
 		_ = f.deleteCalcChain(f.getSheetID(sheet), "")
 		delete(f.sheetMap, v.Name)
 		f.Pkg.Delete(sheetXML)
+		f.lazyFiles.Delete(sheetXML)
 		f.Pkg.Delete(rels)
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
@@ -683,7 +724,9 @@ func (f *File) getSheetRelationshipsTargetByID(sheet, rID string) string {
 
 // CopySheet provides a function to duplicate a worksheet by gave source and
 // target worksheet index. Note that currently doesn't support duplicate
-// workbooks that contain tables, charts or pictures. For Example:
+// workbooks that contain tables, charts, pictures or comments, use
+// DuplicateSheet to duplicate a worksheet with pictures, charts and comments.
+// For Example:
 //
 //	// Sheet1 already exists...
 //	index, err := f.NewSheet("Sheet2")
@@ -713,14 +756,38 @@ func (f *File) copySheet(from, to int) error {
 	if len(worksheet.SheetViews.SheetView) > 0 {
 		worksheet.SheetViews.SheetView[0].TabSelected = false
 	}
+	// The drawing, comments and tables parts can't be shared between
+	// worksheets, so drop the relationships to these parts of the source
+	// worksheet
+	skipRels := map[string]bool{}
+	if worksheet.Drawing != nil {
+		skipRels[worksheet.Drawing.RID] = true
+	}
+	if worksheet.LegacyDrawing != nil {
+		skipRels[worksheet.LegacyDrawing.RID] = true
+	}
+	if worksheet.TableParts != nil {
+		for _, tablePart := range worksheet.TableParts.TableParts {
+			skipRels[tablePart.RID] = true
+		}
+	}
 	worksheet.Drawing = nil
+	worksheet.LegacyDrawing = nil
 	worksheet.TableParts = nil
 	worksheet.PageSetUp = nil
 	f.Sheet.Store(sheetXMLPath, worksheet)
 	toRels := "xl/worksheets/_rels/sheet" + toSheetID + ".xml.rels"
 	fromRels := "xl/worksheets/_rels/sheet" + strconv.Itoa(f.getSheetID(fromSheet)) + ".xml.rels"
-	if rels, ok := f.Pkg.Load(fromRels); ok && rels != nil {
-		f.Pkg.Store(toRels, rels.([]byte))
+	if rels, _ := f.relsReader(fromRels); rels != nil {
+		rels.mu.Lock()
+		sheetRels := &xlsxRelationships{}
+		for _, rel := range rels.Relationships {
+			if !skipRels[rel.ID] && rel.Type != SourceRelationshipComments {
+				sheetRels.Relationships = append(sheetRels.Relationships, rel)
+			}
+		}
+		rels.mu.Unlock()
+		f.Relationships.Store(toRels, sheetRels)
 	}
 	fromSheetXMLPath, _ := f.getSheetXMLPath(fromSheet)
 	fromSheetAttr, _ := f.xmlAttr.Load(fromSheetXMLPath)
@@ -728,6 +795,125 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// DuplicateSheet provides a function to create a new worksheet with the given
+// name and duplicate the source worksheet into it, including cells, styles,
+// merged cells, data validations, comments, pictures and charts. The
+// duplicated drawing, charts and comments are stored in their own parts, and
+// the references to the source worksheet in the duplicated charts will be
+// updated to the new worksheet. It returns the index of the new worksheet,
+// and returns an error if a sheet with the same name already exists. Note
+// that the tables and the form controls in the source worksheet will not be
+// duplicated. For example, duplicate Sheet1 as Sheet2:
+//
+//	index, err := f.DuplicateSheet("Sheet1", "Sheet2")
+func (f *File) DuplicateSheet(source, sheet string) (int, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return -1, err
+	}
+	from, err := f.GetSheetIndex(source)
+	if err != nil {
+		return -1, err
+	}
+	if from == -1 {
+		return -1, ErrSheetNotExist{source}
+	}
+	if index, _ := f.GetSheetIndex(sheet); index != -1 {
+		return -1, ErrExistsSheet
+	}
+	ws, err := f.workSheetReader(source)
+	if err != nil {
+		return -1, err
+	}
+	to, err := f.NewSheet(sheet)
+	if err != nil {
+		return to, err
+	}
+	if err = f.copySheet(from, to); err != nil {
+		return to, err
+	}
+	if ws.Drawing != nil {
+		if err = f.duplicateSheetDrawing(source, sheet, ws.Drawing.RID); err != nil {
+			return to, err
+		}
+	}
+	comments, err := f.GetComments(source)
+	if err != nil {
+		return to, err
+	}
+	for _, comment := range comments {
+		if err = f.AddComment(sheet, comment); err != nil {
+			return to, err
+		}
+	}
+	return to, err
+}
+
+// duplicateSheetDrawing provides a function to duplicate the drawing part and
+// the charts in it of the source worksheet to the target worksheet by given
+// source, target worksheet name and the relationship ID of the drawing.
+func (f *File) duplicateSheetDrawing(source, sheet, rID string) error {
+	target := f.getSheetRelationshipsTargetByID(source, rID)
+	wsDr, _, err := f.drawingParser(strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/"))
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	f.Drawings.Store(drawingXML, deepcopy.Copy(wsDr).(*xlsxWsDr))
+	if err = f.addContentTypePart(drawingID, "drawings"); err != nil {
+		return err
+	}
+	drawingRels, err := f.relsReader(strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels"))
+	if err != nil {
+		return err
+	}
+	if drawingRels != nil {
+		drawingRels.mu.Lock()
+		rels := deepcopy.Copy(drawingRels).(*xlsxRelationships)
+		drawingRels.mu.Unlock()
+		for i, rel := range rels.Relationships {
+			if rel.Type != SourceRelationshipChart {
+				continue
+			}
+			chartXML := strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+			chartID := f.countCharts() + 1
+			f.Pkg.Store("xl/charts/chart"+strconv.Itoa(chartID)+".xml",
+				duplicateChartFormula(f.readXML(chartXML), source, sheet))
+			if chartRels, ok := f.Pkg.Load(strings.ReplaceAll(chartXML, "xl/charts/", "xl/charts/_rels/") + ".rels"); ok {
+				f.Pkg.Store("xl/charts/_rels/chart"+strconv.Itoa(chartID)+".xml.rels", chartRels)
+			}
+			rels.Relationships[i].Target = "../charts/chart" + strconv.Itoa(chartID) + ".xml"
+			if err = f.addContentTypePart(chartID, "chart"); err != nil {
+				return err
+			}
+		}
+		f.Relationships.Store("xl/drawings/_rels/drawing"+strconv.Itoa(drawingID)+".xml.rels", rels)
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	drawingRID := f.addRels("xl/worksheets/_rels/"+filepath.Base(sheetXMLPath)+".rels",
+		SourceRelationshipDrawingML, "../drawings/drawing"+strconv.Itoa(drawingID)+".xml", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	f.addSheetDrawing(sheet, drawingRID)
+	return err
+}
+
+// chartFormulaExp matches the formula elements in the chart part.
+var chartFormulaExp = regexp.MustCompile(`(<(?:\w+:)?f>)([^<]*)(</(?:\w+:)?f>)`)
+
+// duplicateChartFormula provides a function to replace the references to the
+// source worksheet in the formulas of the chart part by given chart XML
+// content, source and target worksheet name.
+func duplicateChartFormula(content []byte, source, sheet string) []byte {
+	from, to := escapeSheetName(source)+"!", escapeSheetName(sheet)+"!"
+	return chartFormulaExp.ReplaceAllFunc(content, func(match []byte) []byte {
+		parts := chartFormulaExp.FindSubmatch(match)
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(strings.ReplaceAll(html.UnescapeString(string(parts[2])), from, to)))
+		return append(append(append([]byte{}, parts[1]...), buf.Bytes()...), parts[3]...)
+	})
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -783,14 +969,38 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 	return err
 }
 
+// getTopLeftCell check the top left visible cell of the scrollable pane, and
+// returns the first cell after the frozen rows and columns if which not
+// specified for the frozen panes.
+func (panes *Panes) getTopLeftCell() (string, error) {
+	if panes.TopLeftCell == "" {
+		if panes.Freeze && (panes.XSplit > 0 || panes.YSplit > 0) {
+			return CoordinatesToCellName(panes.XSplit+1, panes.YSplit+1)
+		}
+		return panes.TopLeftCell, nil
+	}
+	col, row, err := CellNameToCoordinates(panes.TopLeftCell)
+	if err != nil {
+		return panes.TopLeftCell, err
+	}
+	if panes.Freeze && (col <= panes.XSplit || row <= panes.YSplit) {
+		return panes.TopLeftCell, ErrParameterInvalid
+	}
+	return panes.TopLeftCell, err
+}
+
 // setPanes set create freeze panes and split panes by given options.
 func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	if panes == nil {
 		return ErrParameterInvalid
 	}
+	topLeftCell, err := panes.getTopLeftCell()
+	if err != nil {
+		return err
+	}
 	p := &xlsxPane{
 		ActivePane:  panes.ActivePane,
-		TopLeftCell: panes.TopLeftCell,
+		TopLeftCell: topLeftCell,
 		XSplit:      float64(panes.XSplit),
 		YSplit:      float64(panes.YSplit),
 	}
@@ -879,7 +1089,10 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 // attribute are defined by the W3C XML Schema double datatype.
 //
 // TopLeftCell: Location of the top left visible cell in the bottom right pane
-// (when in Left-To-Right mode).
+// (when in Left-To-Right mode). For the frozen panes, this cell controls the
+// initial scroll position of the scrollable region, it must be below the
+// frozen rows and at the right of the frozen columns, and the first cell
+// after the frozen rows and columns will be used if not specified.
 //
 // SQRef (Sequence of References): Range of the selection. Can be non-contiguous
 // set of ranges.
@@ -987,6 +1200,166 @@ func (f *File) GetPanes(sheet string) (Panes, error) {
 	return ws.getPanes(), err
 }
 
+// IsTopRowFrozen provides a function to check if the top row of the worksheet
+// is frozen by given worksheet name, which is the same as the "Freeze Top Row"
+// in the spreadsheet application. For example, check if the top row of Sheet1
+// is frozen:
+//
+//	frozen, err := f.IsTopRowFrozen("Sheet1")
+func (f *File) IsTopRowFrozen(sheet string) (bool, error) {
+	panes, err := f.GetPanes(sheet)
+	return panes.Freeze && panes.YSplit == 1, err
+}
+
+// IsFirstColFrozen provides a function to check if the first column of the
+// worksheet is frozen by given worksheet name, which is the same as the
+// "Freeze First Column" in the spreadsheet application. For example, check if
+// the first column of Sheet1 is frozen:
+//
+//	frozen, err := f.IsFirstColFrozen("Sheet1")
+func (f *File) IsFirstColFrozen(sheet string) (bool, error) {
+	panes, err := f.GetPanes(sheet)
+	return panes.Freeze && panes.XSplit == 1, err
+}
+
+// SetSelection provides a function to set the selected ranges and the active
+// cell of the worksheet by given worksheet name and selection options. The
+// SQRef could be a non-contiguous set of ranges separated by spaces, and the
+// ActiveCell must be within one of the ranges, which will be the top-left
+// cell of the first range if it is empty. The Pane specifies the pane to
+// which the selection belongs, and the active pane of the worksheet will be
+// used if it is empty. For example, select the ranges A1:A3 and C1:C3 with
+// the active cell C2 on Sheet1:
+//
+//	err := f.SetSelection("Sheet1", excelize.Selection{
+//	    SQRef:      "A1:A3 C1:C3",
+//	    ActiveCell: "C2",
+//	})
+func (f *File) SetSelection(sheet string, selection Selection) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	areas := strings.Fields(strings.ToUpper(selection.SQRef))
+	if len(areas) == 0 {
+		return ErrParameterRequired
+	}
+	var refs [][]int
+	for _, area := range areas {
+		if !strings.Contains(area, ":") {
+			area += ":" + area
+		}
+		coordinates, err := rangeRefToCoordinates(area)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		refs = append(refs, coordinates)
+	}
+	activeCell, activeCellID := strings.ToUpper(selection.ActiveCell), -1
+	if activeCell == "" {
+		activeCell, _ = CoordinatesToCellName(refs[0][0], refs[0][1])
+	}
+	col, row, err := CellNameToCoordinates(activeCell)
+	if err != nil {
+		return err
+	}
+	for idx, coordinates := range refs {
+		if cellInRange([]int{col, row}, coordinates) {
+			activeCellID = idx
+			break
+		}
+	}
+	if activeCellID == -1 {
+		return ErrParameterInvalid
+	}
+	if ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{}}}
+	}
+	sw := &ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	if selection.Pane == "" && sw.Pane != nil {
+		selection.Pane = sw.Pane.ActivePane
+	}
+	sel := &xlsxSelection{ActiveCell: activeCell, Pane: selection.Pane, SQRef: strings.Join(areas, " ")}
+	if activeCellID > 0 {
+		sel.ActiveCellID = intPtr(activeCellID)
+	}
+	for idx, s := range sw.Selection {
+		if s != nil && s.Pane == selection.Pane {
+			sw.Selection[idx] = sel
+			return err
+		}
+	}
+	sw.Selection = append(sw.Selection, sel)
+	return err
+}
+
+// GetSelection provides a function to get the selected ranges and the active
+// cell of the active pane of the worksheet by given worksheet name.
+func (f *File) GetSelection(sheet string) (Selection, error) {
+	var selection Selection
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		return selection, err
+	}
+	sw := ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1]
+	if sw.Pane != nil {
+		selection.Pane = sw.Pane.ActivePane
+	}
+	for _, s := range sw.Selection {
+		if s != nil && s.Pane == selection.Pane {
+			selection.SQRef, selection.ActiveCell = s.SQRef, s.ActiveCell
+			break
+		}
+	}
+	return selection, err
+}
+
+// SetAllSelections provides a function to set the selected ranges of
+// multiple worksheets at once by given map of worksheet name and selection
+// range reference sequence, which is convenient for restoring the snapshot
+// got by the GetAllSelections function. For example:
+//
+//	err := f.SetAllSelections(map[string]string{
+//	    "Sheet1": "A1:A3",
+//	    "Sheet2": "C5",
+//	})
+func (f *File) SetAllSelections(selections map[string]string) error {
+	sheets := make([]string, 0, len(selections))
+	for sheet := range selections {
+		sheets = append(sheets, sheet)
+	}
+	sort.Strings(sheets)
+	for _, sheet := range sheets {
+		if err := f.SetSelection(sheet, Selection{SQRef: selections[sheet]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAllSelections provides a function to get the selected ranges of the
+// active pane of all worksheets in the workbook at once. The key of the
+// returned map is the worksheet name and the value is the selection range
+// reference sequence, the worksheets without selection and the chart sheets
+// will be skipped.
+func (f *File) GetAllSelections() (map[string]string, error) {
+	selections := map[string]string{}
+	for _, sheet := range f.GetSheetList() {
+		if name, _ := f.getSheetXMLPath(sheet); !isWorksheetPath(name) {
+			continue
+		}
+		selection, err := f.GetSelection(sheet)
+		if err != nil {
+			return selections, err
+		}
+		if selection.SQRef != "" {
+			selections[sheet] = selection.SQRef
+		}
+	}
+	return selections, nil
+}
+
 // GetSheetVisible provides a function to get worksheet visible by given worksheet
 // name. For example, get visible state of Sheet1:
 //
@@ -1007,6 +1380,46 @@ func (f *File) GetSheetVisible(sheet string) (bool, error) {
 	return visible, nil
 }
 
+// GetSheetInfo provides a function to get the aggregated information of the
+// worksheet by given worksheet name, including the position, visibility
+// state, selection state, code name and tab color in one call. For example,
+// get the information of Sheet1:
+//
+//	info, err := f.GetSheetInfo("Sheet1")
+func (f *File) GetSheetInfo(sheet string) (SheetInfo, error) {
+	var info SheetInfo
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return info, err
+	}
+	if idx == -1 {
+		return info, ErrSheetNotExist{sheet}
+	}
+	wb, _ := f.workbookReader()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return info, err
+	}
+	info.Index, info.Name, info.State = idx, wb.Sheets.Sheet[idx].Name, wb.Sheets.Sheet[idx].State
+	if info.State == "" {
+		info.State = "visible"
+	}
+	info.Active = f.GetActiveSheetIndex() == idx
+	if ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 {
+		info.Selected = ws.SheetViews.SheetView[0].TabSelected
+	}
+	if ws.SheetPr != nil {
+		info.CodeName = ws.SheetPr.CodeName
+		if ws.SheetPr.TabColor != nil {
+			info.TabColorIndexed = intPtr(ws.SheetPr.TabColor.Indexed)
+			info.TabColorRGB = stringPtr(ws.SheetPr.TabColor.RGB)
+			info.TabColorTheme = ws.SheetPr.TabColor.Theme
+			info.TabColorTint = float64Ptr(ws.SheetPr.TabColor.Tint)
+		}
+	}
+	return info, err
+}
+
 // SearchSheet provides a function to get cell reference by given worksheet name,
 // cell value, and regular expression. The function doesn't support searching
 // on the calculated result, formatted numbers and conditional lookup
@@ -1053,7 +1466,7 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 		sst                 *xlsxSST
 	)
 
-	if sst, err = f.sharedStringsReader(); err != nil {
+	if sst, err = f.sharedStringsValueReader(); err != nil {
 		return
 	}
 	regex := regexp.MustCompile(value)
@@ -1079,7 +1492,10 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 			if inElement == "c" {
 				colCell := xlsxC{}
 				_ = decoder.DecodeElement(&colCell, &xmlElement)
-				val, _ := colCell.getValueFrom(f, sst, false)
+				var val string
+				if val, err = colCell.getValueFrom(f, sst, false); err != nil {
+					return
+				}
 				if regSearch {
 					if !regex.MatchString(val) {
 						continue
@@ -1379,6 +1795,44 @@ func (f *File) ProtectSheet(sheet string, opts *SheetProtectionOptions) error {
 	return err
 }
 
+// GetSheetProtection provides a function to get worksheet protection settings
+// by given worksheet name. The returned options describe the operations that
+// users are allowed to perform on the protected worksheet, and the Password
+// field will always be empty since the password can't be recovered from the
+// stored hash value. Nil options will be returned if the worksheet is not
+// protected. For example, get the protection settings of Sheet1:
+//
+//	opts, err := f.GetSheetProtection("Sheet1")
+func (f *File) GetSheetProtection(sheet string) (*SheetProtectionOptions, error) {
+	var opts *SheetProtectionOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return opts, err
+	}
+	if ws.SheetProtection == nil || !ws.SheetProtection.Sheet {
+		return opts, err
+	}
+	opts = &SheetProtectionOptions{
+		AlgorithmName:       ws.SheetProtection.AlgorithmName,
+		AutoFilter:          !ws.SheetProtection.AutoFilter,
+		DeleteColumns:       !ws.SheetProtection.DeleteColumns,
+		DeleteRows:          !ws.SheetProtection.DeleteRows,
+		EditObjects:         !ws.SheetProtection.Objects,
+		EditScenarios:       !ws.SheetProtection.Scenarios,
+		FormatCells:         !ws.SheetProtection.FormatCells,
+		FormatColumns:       !ws.SheetProtection.FormatColumns,
+		FormatRows:          !ws.SheetProtection.FormatRows,
+		InsertColumns:       !ws.SheetProtection.InsertColumns,
+		InsertHyperlinks:    !ws.SheetProtection.InsertHyperlinks,
+		InsertRows:          !ws.SheetProtection.InsertRows,
+		PivotTables:         !ws.SheetProtection.PivotTables,
+		SelectLockedCells:   !ws.SheetProtection.SelectLockedCells,
+		SelectUnlockedCells: !ws.SheetProtection.SelectUnlockedCells,
+		Sort:                !ws.SheetProtection.Sort,
+	}
+	return opts, err
+}
+
 // UnprotectSheet provides a function to remove protection for a sheet,
 // specified the second optional password parameter to remove sheet
 // protection with password verification.
@@ -1604,6 +2058,26 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
+	if opts.Draft != nil {
+		ws.newPageSetUp()
+		ws.PageSetUp.Draft = *opts.Draft
+	}
+	if opts.PrintGridLines != nil {
+		ws.newPrintOptions()
+		ws.PrintOptions.GridLines = *opts.PrintGridLines
+	}
+	if opts.PrintHeadings != nil {
+		ws.newPrintOptions()
+		ws.PrintOptions.Headings = *opts.PrintHeadings
+	}
+}
+
+// newPrintOptions initialize print options settings for the worksheet if which
+// not exist.
+func (ws *xlsxWorksheet) newPrintOptions() {
+	if ws.PrintOptions == nil {
+		ws.PrintOptions = new(xlsxPrintOptions)
+	}
 }
 
 // GetPageLayout provides a function to gets worksheet page layout.
@@ -1638,12 +2112,18 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 			opts.FitToWidth = ws.PageSetUp.FitToWidth
 		}
 		opts.BlackAndWhite = boolPtr(ws.PageSetUp.BlackAndWhite)
+		opts.Draft = boolPtr(ws.PageSetUp.Draft)
+	}
+	if ws.PrintOptions != nil {
+		opts.PrintGridLines = boolPtr(ws.PrintOptions.GridLines)
+		opts.PrintHeadings = boolPtr(ws.PrintOptions.Headings)
 	}
 	return opts, err
 }
 
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
+// or worksheet. If not specified scope, the default scope is workbook. Set the
+// Hidden field to true to hide the defined name from the Name Manager.
 // For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
@@ -1666,6 +2146,7 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
 		Comment: definedName.Comment,
+		Hidden:  definedName.Hidden,
 		Data:    definedName.RefersTo,
 	}
 	if definedName.Scope != "" {
@@ -1736,6 +2217,7 @@ func (f *File) GetDefinedName() []DefinedName {
 				Comment:  dn.Comment,
 				RefersTo: dn.Data,
 				Scope:    "Workbook",
+				Hidden:   dn.Hidden,
 			}
 			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
 				definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
@@ -1746,6 +2228,164 @@ func (f *File) GetDefinedName() []DefinedName {
 	return definedNames
 }
 
+// SetNamedRange provides a function to create a workbook scope defined name
+// which refers to the cell or cell range on the given worksheet. The sheet
+// name will be quoted if necessary and the reference will be converted to
+// the absolute reference. For example, create a defined name "Amount" which
+// refers to 'Sales Data'!$A$1:$B$2:
+//
+//	err := f.SetNamedRange("Amount", "Sales Data", "A1:B2")
+func (f *File) SetNamedRange(name, sheet, rangeRef string) error {
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if idx == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	ref, err := f.absRangeRef(rangeRef)
+	if err != nil {
+		return err
+	}
+	return f.SetDefinedName(&DefinedName{
+		Name:     name,
+		RefersTo: escapeSheetName(f.GetSheetName(idx)) + "!" + ref,
+	})
+}
+
+// absRangeRef provides a function to convert the given cell or cell range
+// reference to the absolute reference.
+func (f *File) absRangeRef(rangeRef string) (string, error) {
+	cells := strings.Split(strings.ReplaceAll(rangeRef, "$", ""), ":")
+	if len(cells) == 1 {
+		col, row, err := CellNameToCoordinates(cells[0])
+		if err != nil {
+			return "", err
+		}
+		return CoordinatesToCellName(col, row, true)
+	}
+	if len(cells) != 2 {
+		return "", ErrParameterInvalid
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[1])
+	if err != nil {
+		return "", err
+	}
+	_ = sortCoordinates(coordinates)
+	return f.coordinatesToRangeRef(coordinates, true)
+}
+
+// GetNamedRange provides a function to get the worksheet name and the cell
+// or cell range reference which the workbook scope defined name refers to.
+// The returned reference doesn't contain the absolute reference symbols. For
+// example, get the worksheet name and cell range of the defined name
+// "Amount":
+//
+//	sheet, rangeRef, err := f.GetNamedRange("Amount")
+func (f *File) GetNamedRange(name string) (string, string, error) {
+	data, err := f.getWorkbookDefinedName(name)
+	if err != nil {
+		return "", "", err
+	}
+	i := strings.LastIndex(data, "!")
+	if i == -1 {
+		return "", "", ErrParameterInvalid
+	}
+	sheet, rangeRef := unquoteSheetName(data[:i]), strings.ReplaceAll(data[i+1:], "$", "")
+	if _, err = f.absRangeRef(rangeRef); err != nil {
+		return "", "", err
+	}
+	return sheet, rangeRef, err
+}
+
+// GetNamedRangeValues provides a function to get the cell values of all cells
+// which the workbook scope defined name refers to. The defined name could
+// refer to multiple areas, even on different worksheets, and the values of
+// each area will be appended as the rows of the returned two-dimensional
+// array in the order of the areas. An error will be returned if the defined
+// name doesn't refer to cell ranges, such as a constant or formula. For
+// example, get the values of the defined name "Amount":
+//
+//	rows, err := f.GetNamedRangeValues("Amount")
+func (f *File) GetNamedRangeValues(name string) ([][]string, error) {
+	var rows [][]string
+	data, err := f.getWorkbookDefinedName(name)
+	if err != nil {
+		return rows, err
+	}
+	for _, area := range splitDefinedNameAreas(data) {
+		i := strings.LastIndex(area, "!")
+		if i == -1 {
+			return rows, ErrParameterInvalid
+		}
+		sheet, rangeRef := unquoteSheetName(area[:i]), strings.ReplaceAll(area[i+1:], "$", "")
+		if rangeRef, err = f.absRangeRef(rangeRef); err != nil {
+			return rows, err
+		}
+		cells := strings.Split(strings.ReplaceAll(rangeRef, "$", ""), ":")
+		if len(cells) == 1 {
+			cells = append(cells, cells[0])
+		}
+		coordinates, _ := cellRefsToCoordinates(cells[0], cells[1])
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			var values []string
+			for col := coordinates[0]; col <= coordinates[2]; col++ {
+				cell, _ := CoordinatesToCellName(col, row)
+				value, err := f.GetCellValue(sheet, cell)
+				if err != nil {
+					return rows, err
+				}
+				values = append(values, value)
+			}
+			rows = append(rows, values)
+		}
+	}
+	return rows, err
+}
+
+// getWorkbookDefinedName provides a function to get the refers to data of the
+// workbook scope defined name by given name.
+func (f *File) getWorkbookDefinedName(name string) (string, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", err
+	}
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID != nil || !strings.EqualFold(dn.Name, name) {
+				continue
+			}
+			return dn.Data, err
+		}
+	}
+	return "", ErrDefinedNameScope
+}
+
+// splitDefinedNameAreas provides a function to split the refers to data of
+// the defined name into areas by the union operator, the commas in the quoted
+// worksheet names will be ignored.
+func splitDefinedNameAreas(data string) []string {
+	var (
+		areas  []string
+		quoted bool
+		start  int
+	)
+	data = strings.TrimPrefix(data, "=")
+	if strings.HasPrefix(data, "(") && strings.HasSuffix(data, ")") {
+		data = data[1 : len(data)-1]
+	}
+	for i, r := range data {
+		if r == '\'' {
+			quoted = !quoted
+		}
+		if r == ',' && !quoted {
+			areas = append(areas, data[start:i])
+			start = i + 1
+		}
+	}
+	return append(areas, data[start:])
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
@@ -139,6 +139,168 @@ func TestPanes(t *testing.T) {
 	))
 }
 
+func TestPanesTopLeftCell(t *testing.T) {
+	f := NewFile()
+	// Test freeze the top two rows and scroll to the row 50
+	expected := Panes{
+		Freeze:      true,
+		YSplit:      2,
+		TopLeftCell: "A50",
+		ActivePane:  "bottomLeft",
+	}
+	assert.NoError(t, f.SetPanes("Sheet1", &expected))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, panes)
+	// Test freeze panes without top left cell
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: 1, YSplit: 2, ActivePane: "bottomRight"}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B3", panes.TopLeftCell)
+	// Test freeze panes with the top left cell in the frozen region
+	assert.Equal(t, ErrParameterInvalid, f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: 2, TopLeftCell: "A2"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: 2, TopLeftCell: "B5"}))
+	// Test set panes with invalid top left cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: 2, TopLeftCell: "A"}))
+	// Test freeze panes with exceeds maximum rows
+	assert.Equal(t, ErrMaxRows, f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: TotalRows}))
+	assert.NoError(t, f.Close())
+}
+
+func TestFrozenTopRowAndFirstCol(t *testing.T) {
+	f := NewFile()
+	frozen, err := f.IsTopRowFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}))
+	frozen, err = f.IsTopRowFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, frozen)
+	frozen, err = f.IsFirstColFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Freeze: true, XSplit: 1, TopLeftCell: "B1", ActivePane: "topRight"}))
+	frozen, err = f.IsTopRowFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+	frozen, err = f.IsFirstColFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, frozen)
+	// Test check split panes
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{Split: true, XSplit: 1, YSplit: 1, TopLeftCell: "B2", ActivePane: "bottomRight"}))
+	frozen, err = f.IsTopRowFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+	frozen, err = f.IsFirstColFrozen("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, frozen)
+	// Test check frozen state on not exists worksheet
+	_, err = f.IsTopRowFrozen("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.IsFirstColFrozen("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestSelection(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "a1:a3  C3:C1", ActiveCell: "C2"}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `<selection activeCell="C2" activeCellId="1" sqref="A1:A3 C3:C1"></selection>`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	selection, err := f.GetSelection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Selection{SQRef: "A1:A3 C3:C1", ActiveCell: "C2"}, selection)
+	// Test set selection with default active cell
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "B2:D4 F6"}))
+	selection, err = f.GetSelection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Selection{SQRef: "B2:D4 F6", ActiveCell: "B2"}, selection)
+	// Test set selection with active pane of the worksheet
+	assert.NoError(t, f.SetPanes("Sheet1", &Panes{
+		Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft",
+		Selection: []Selection{{SQRef: "A2", ActiveCell: "A2", Pane: "bottomLeft"}},
+	}))
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "A2:A5 C2:C5", ActiveCell: "A3"}))
+	selection, err = f.GetSelection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Selection{SQRef: "A2:A5 C2:C5", ActiveCell: "A3", Pane: "bottomLeft"}, selection)
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "A1", Pane: "topLeft"}))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Selection{
+		{SQRef: "A2:A5 C2:C5", ActiveCell: "A3", Pane: "bottomLeft"},
+		{SQRef: "A1", ActiveCell: "A1", Pane: "topLeft"},
+	}, panes.Selection)
+	// Test set and get selection without sheet views
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews = nil
+	selection, err = f.GetSelection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Selection{}, selection)
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "B2"}))
+	selection, err = f.GetSelection("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Selection{SQRef: "B2", ActiveCell: "B2"}, selection)
+	// Test set selection with active cell outside the ranges
+	assert.Equal(t, ErrParameterInvalid, f.SetSelection("Sheet1", Selection{SQRef: "A1:A3 C1:C3", ActiveCell: "B2"}))
+	// Test set selection without ranges
+	assert.Equal(t, ErrParameterRequired, f.SetSelection("Sheet1", Selection{}))
+	// Test set selection with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSelection("Sheet1", Selection{SQRef: "A1 A:B2"}))
+	// Test set selection with invalid active cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSelection("Sheet1", Selection{SQRef: "A1", ActiveCell: "A"}))
+	// Test set and get selection on not exists worksheet
+	assert.EqualError(t, f.SetSelection("SheetN", Selection{SQRef: "A1"}), "sheet SheetN does not exist")
+	_, err = f.GetSelection("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestAllSelections(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet3.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews = nil
+	assert.NoError(t, f.SetSelection("Sheet1", Selection{SQRef: "A1:A3 C1:C3", ActiveCell: "C2"}))
+	assert.NoError(t, f.SetSelection("Sheet2", Selection{SQRef: "B5"}))
+	selections, err := f.GetAllSelections()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Sheet1": "A1:A3 C1:C3", "Sheet2": "B5"}, selections)
+	// Test restore the selections snapshot
+	assert.NoError(t, f.SetAllSelections(map[string]string{"Sheet1": "D4", "Sheet3": "E1:E9"}))
+	assert.NoError(t, f.SetAllSelections(selections))
+	selections, err = f.GetAllSelections()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Sheet1": "A1:A3 C1:C3", "Sheet2": "B5", "Sheet3": "E1:E9"}, selections)
+	// Test set all selections with invalid selection
+	assert.Equal(t, ErrParameterRequired, f.SetAllSelections(map[string]string{"Sheet1": ""}))
+	assert.EqualError(t, f.SetAllSelections(map[string]string{"SheetN": "A1"}), "sheet SheetN does not exist")
+	// Test get all selections with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetAllSelections()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSearchSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "SharedStrings.xlsx"))
 	if !assert.NoError(t, err) {
@@ -210,11 +372,25 @@ func TestSetPageLayout(t *testing.T) {
 		FitToHeight:     intPtr(2),
 		FitToWidth:      intPtr(2),
 		BlackAndWhite:   boolPtr(true),
+		Draft:           boolPtr(true),
+		PrintGridLines:  boolPtr(true),
+		PrintHeadings:   boolPtr(false),
 	}
 	assert.NoError(t, f.SetPageLayout("Sheet1", &expected))
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set print grid lines and headings, and get them after reopen
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{PrintHeadings: boolPtr(true)}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `<printOptions gridLines="true" headings="true"></printOptions>`)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *opts.PrintGridLines)
+	assert.True(t, *opts.PrintHeadings)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
@@ -223,8 +399,27 @@ func TestSetPageLayout(t *testing.T) {
 
 func TestGetPageLayout(t *testing.T) {
 	f := NewFile()
+	expected := PageLayoutOptions{
+		Size:            intPtr(9),
+		Orientation:     stringPtr("landscape"),
+		FirstPageNumber: uintPtr(3),
+		AdjustTo:        uintPtr(80),
+		BlackAndWhite:   boolPtr(true),
+		Draft:           boolPtr(false),
+		PrintGridLines:  boolPtr(false),
+		PrintHeadings:   boolPtr(true),
+	}
+	assert.NoError(t, f.SetPageLayout("Sheet1", &expected))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
 	// Test get page layout on not exists worksheet
-	_, err := f.GetPageLayout("SheetN")
+	_, err = f.GetPageLayout("SheetN")
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 	// Test get page layout with invalid sheet name
 	_, err = f.GetPageLayout("Sheet:1")
@@ -322,6 +517,29 @@ func TestDefinedName(t *testing.T) {
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[0].RefersTo)
 	assert.Len(t, f.GetDefinedName(), 3)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
+	// Test set and get defined name with comment and hidden state
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name:     "HiddenAmount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Comment:  "hidden defined name comment",
+		Hidden:   true,
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), `<definedName comment="hidden defined name comment" hidden="true" name="HiddenAmount">Sheet1!$A$2:$D$5</definedName>`)
+	f2, err := OpenReader(buf)
+	assert.NoError(t, err)
+	definedNames := f2.GetDefinedName()
+	assert.Len(t, definedNames, 4)
+	assert.Equal(t, DefinedName{
+		Name:     "HiddenAmount",
+		Comment:  "hidden defined name comment",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Workbook",
+		Hidden:   true,
+	}, definedNames[3])
+	assert.False(t, definedNames[0].Hidden)
+	assert.NoError(t, f2.Close())
 	// Test set defined name with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
@@ -335,6 +553,103 @@ func TestDefinedName(t *testing.T) {
 		"XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestNamedRange(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sales Data")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetNamedRange("Amount", "Sales Data", "B2:A1"))
+	assert.NoError(t, f.SetNamedRange("Total", "Sheet1", "$C$3"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), `<definedName name="Amount">&#39;Sales Data&#39;!$A$1:$B$2</definedName>`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!$A$1:$B$2", f.GetDefinedName()[0].RefersTo)
+	sheet, rangeRef, err := f.GetNamedRange("Amount")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sales Data", sheet)
+	assert.Equal(t, "A1:B2", rangeRef)
+	sheet, rangeRef, err = f.GetNamedRange("Total")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1", sheet)
+	assert.Equal(t, "C3", rangeRef)
+	// Test set named range with duplicate name
+	assert.Equal(t, ErrDefinedNameDuplicate, f.SetNamedRange("Amount", "Sheet1", "A1:B2"))
+	// Test set named range with invalid name
+	assert.Equal(t, newInvalidNameError("1Amount"), f.SetNamedRange("1Amount", "Sheet1", "A1:B2"))
+	// Test set named range with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetNamedRange("Price", "Sheet1", "A"))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.SetNamedRange("Price", "Sheet1", "A1:B"))
+	assert.Equal(t, ErrParameterInvalid, f.SetNamedRange("Price", "Sheet1", "A1:B2:C3"))
+	// Test set named range on not exists worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetNamedRange("Price", "SheetN", "A1:B2"))
+	// Test set named range with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetNamedRange("Price", "Sheet:1", "A1:B2"))
+	// Test get named range which not exists or not refers to a cell range
+	_, _, err = f.GetNamedRange("Price")
+	assert.Equal(t, ErrDefinedNameScope, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "0.5"}))
+	_, _, err = f.GetNamedRange("Rate")
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Header", RefersTo: "Sheet1!$1:$1"}))
+	_, _, err = f.GetNamedRange("Header")
+	assert.Error(t, err)
+	assert.NoError(t, f.Close())
+	// Test get named range with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, _, err = f.GetNamedRange("Amount")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetNamedRangeValues(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sales, Data")
+	assert.NoError(t, err)
+	for idx, row := range [][]interface{}{{"Month", "Sales"}, {"Jan", 100}, {"Feb", 120}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetCellValue("Sales, Data", "C3", "Total"))
+	assert.NoError(t, f.SetCellValue("Sales, Data", "D3", 220))
+	assert.NoError(t, f.SetNamedRange("Amount", "Sheet1", "A1:B3"))
+	rows, err := f.GetNamedRangeValues("Amount")
+	assert.NoError(t, err)
+	expected, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	// Test get values of the defined name refers to multiple areas
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Summary", RefersTo: "Sheet1!$A$2:$B$2,'Sales, Data'!$C$3:$D$3,Sheet1!$B$3"}))
+	rows, err = f.GetNamedRangeValues("Summary")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Jan", "100"}, {"Total", "220"}, {"120"}}, rows)
+	// Test get values of the defined name which not exists
+	_, err = f.GetNamedRangeValues("Price")
+	assert.Equal(t, ErrDefinedNameScope, err)
+	// Test get values of the defined name which refers to a constant or formula
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Rate", RefersTo: "0.5"}))
+	_, err = f.GetNamedRangeValues("Rate")
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Sum", RefersTo: "SUM(Sheet1!$B$2:$B$3)"}))
+	_, err = f.GetNamedRangeValues("Sum")
+	assert.Error(t, err)
+	// Test get values of the defined name refers to not exists worksheet
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Lost", RefersTo: "SheetN!$A$1"}))
+	_, err = f.GetNamedRangeValues("Lost")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+	// Test get named range values with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetNamedRangeValues("Amount")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -460,6 +775,43 @@ func TestSetActiveSheet(t *testing.T) {
 	f.SetActiveSheet(idx)
 }
 
+func TestSetSelectedSheets(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSelectedSheets([]string{"Sheet2", "sheet3"}))
+	assert.Equal(t, 1, f.GetActiveSheetIndex())
+	for sheet, selected := range map[string]bool{"Sheet1": false, "Sheet2": true, "Sheet3": true} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, selected, ws.SheetViews.SheetView[0].TabSelected, sheet)
+	}
+	// Test set selected worksheets with the active worksheet in the group
+	f.SetActiveSheet(2)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews = nil
+	assert.NoError(t, f.SetSelectedSheets([]string{"Sheet1", "Sheet3"}))
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSelectedSheets.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestSetSelectedSheets.xlsx"))
+	assert.NoError(t, err)
+	for sheet, selected := range map[string]bool{"Sheet1": true, "Sheet2": false, "Sheet3": true} {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, selected, ws.SheetViews.SheetView[0].TabSelected, sheet)
+	}
+	// Test set selected worksheets without worksheet names
+	assert.Equal(t, ErrParameterRequired, f.SetSelectedSheets(nil))
+	// Test set selected worksheets with not exists worksheet
+	assert.EqualError(t, f.SetSelectedSheets([]string{"Sheet1", "SheetN"}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetSheetName(t *testing.T) {
 	f := NewFile()
 	// Test set worksheet with the same name
@@ -577,6 +929,56 @@ func TestGetSheetVisible(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetSheetInfo(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetProps("Sheet2", &SheetPropsOptions{
+		CodeName:    stringPtr("Report"),
+		TabColorRGB: stringPtr("FFFF0000"),
+	}))
+	assert.NoError(t, f.SetSelectedSheets([]string{"Sheet1", "Sheet2"}))
+	assert.NoError(t, f.SetSheetVisible("Sheet3", false, true))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	info, err := f.GetSheetInfo("sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetInfo{
+		Index:           1,
+		Name:            "Sheet2",
+		State:           "visible",
+		Selected:        true,
+		CodeName:        "Report",
+		TabColorIndexed: intPtr(0),
+		TabColorRGB:     stringPtr("FFFF0000"),
+		TabColorTint:    float64Ptr(0),
+	}, info)
+	info, err = f.GetSheetInfo("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetInfo{Index: 0, Name: "Sheet1", State: "visible", Active: true, Selected: true}, info)
+	info, err = f.GetSheetInfo("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetInfo{Index: 2, Name: "Sheet3", State: "veryHidden"}, info)
+	// Test get sheet information with invalid sheet name
+	_, err = f.GetSheetInfo("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get sheet information on not exists worksheet
+	_, err = f.GetSheetInfo("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sheet information with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetSheetInfo("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetSheetIndex(t *testing.T) {
 	f := NewFile()
 	// Test get sheet index with invalid sheet name
@@ -11,7 +11,12 @@
 
 package excelize
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // SetPageMargins provides a function to set worksheet page margins.
 func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) error {
@@ -226,3 +231,82 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 	}
 	return opts, err
 }
+
+// GetSheetCodeName provides a function to get the code name of the worksheet
+// by given worksheet name. The code name is a stable name of the sheet used by
+// the VBA code to reference the sheet, which could be different from the sheet
+// name displayed on the sheet tab. An empty string will be returned if the
+// worksheet doesn't have a code name.
+func (f *File) GetSheetCodeName(sheet string) (string, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetPr == nil {
+		return "", err
+	}
+	return ws.SheetPr.CodeName, err
+}
+
+// SetSheetCodeName provides a function to set the code name of the worksheet
+// by given worksheet name and code name. The code name should be started with
+// a letter, contain only letters, numbers and underscore characters, and no
+// more than 31 characters. The code name should be unique in the workbook
+// without case-sensitive, set an empty code name to remove it. For example, set
+// the code name of Sheet1 to "wsSummary":
+//
+//	err := f.SetSheetCodeName("Sheet1", "wsSummary")
+func (f *File) SetSheetCodeName(sheet, codeName string) error {
+	if err := checkCodeName(codeName); err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if codeName != "" {
+		wb, err := f.workbookReader()
+		if err != nil {
+			return err
+		}
+		if wb.WorkbookPr != nil && strings.EqualFold(wb.WorkbookPr.CodeName, codeName) {
+			return ErrCodeNameDuplicate
+		}
+		for _, name := range f.GetSheetList() {
+			if strings.EqualFold(name, sheet) {
+				continue
+			}
+			sheetXML, err := f.workSheetReader(name)
+			if err != nil {
+				if err.Error() == newNotWorksheetError(name).Error() {
+					continue
+				}
+				return err
+			}
+			if sheetXML.SheetPr != nil && strings.EqualFold(sheetXML.SheetPr.CodeName, codeName) {
+				return ErrCodeNameDuplicate
+			}
+		}
+	}
+	if ws.SheetPr == nil && codeName == "" {
+		return err
+	}
+	ws.prepareSheetPr()
+	ws.SheetPr.CodeName = codeName
+	return err
+}
+
+// checkCodeName check whether the given code name is a valid identifier for
+// the VBA code.
+func checkCodeName(codeName string) error {
+	if codeName == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(codeName) > 31 {
+		return ErrParameterInvalid
+	}
+	for i, r := range codeName {
+		if unicode.IsLetter(r) || (i > 0 && (unicode.IsDigit(r) || r == '_')) {
+			continue
+		}
+		return ErrParameterInvalid
+	}
+	return nil
+}
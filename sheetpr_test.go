@@ -1,6 +1,9 @@
 package excelize
 
 import (
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -100,3 +103,69 @@ func TestGetSheetProps(t *testing.T) {
 	_, err = f.GetSheetProps("Sheet:1")
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
+
+func TestSheetCodeName(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	codeName, err := f.GetSheetCodeName("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", "wsSummary"))
+	codeName, err = f.GetSheetCodeName("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "wsSummary", codeName)
+	// Test set the same code name on the same worksheet
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", "WSSummary"))
+	// Test set duplicate code name on another worksheet
+	assert.Equal(t, ErrCodeNameDuplicate, f.SetSheetCodeName("Sheet2", "wssummary"))
+	codeName, err = f.GetSheetCodeName("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	// Test set code name which is the same as the workbook code name
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{CodeName: stringPtr("ThisWorkbook")}))
+	assert.Equal(t, ErrCodeNameDuplicate, f.SetSheetCodeName("Sheet2", "ThisWorkbook"))
+	// Test set and get the code name after saving the workbook
+	assert.NoError(t, f.SetSheetCodeName("Sheet2", "wsData_2"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetCodeName.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSheetCodeName.xlsx"))
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "WSSummary", "Sheet2": "wsData_2"} {
+		codeName, err = f.GetSheetCodeName(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, codeName)
+	}
+	// Test remove the code name
+	assert.NoError(t, f.SetSheetCodeName("Sheet2", ""))
+	codeName, err = f.GetSheetCodeName("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, codeName)
+	assert.NoError(t, f.Close())
+	// Test set invalid code name
+	for _, codeName := range []string{"1Sheet", "_Sheet", "Sheet 1", "Sheet-1", strings.Repeat("s", 32)} {
+		assert.Equal(t, ErrParameterInvalid, f.SetSheetCodeName("Sheet1", codeName), codeName)
+	}
+	// Test get and set code name on not exists worksheet
+	_, err = f.GetSheetCodeName("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetSheetCodeName("SheetN", "wsN"), "sheet SheetN does not exist")
+	// Test set code name with chart sheet in the workbook
+	f, err = OpenFile(filepath.Join("test", "TestSheetCodeName.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}}))
+	assert.NoError(t, f.SetSheetCodeName("Sheet1", "wsChart"))
+	// Test set code name with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetCodeName("Sheet1", "wsSheet"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test set code name with unsupported charset worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.SetSheetCodeName("Sheet1", "wsSheet"), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -11,6 +11,11 @@
 
 package excelize
 
+import (
+	"strconv"
+	"strings"
+)
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -127,3 +132,87 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	}
 	return opts, err
 }
+
+// SetGridlineColor provides a function to set the color of the grid lines for
+// the first sheet view of the worksheet by given worksheet name and RGB hex
+// color code, such as "0000FF". Since the grid lines color is stored as an
+// index of the color palette, the nearest color in the palette of the
+// workbook will be used. Specify an empty color to use the default grid lines
+// color. For example, set the grid lines color of Sheet1 to blue:
+//
+//	err := f.SetGridlineColor("Sheet1", "0000FF")
+func (f *File) SetGridlineColor(sheet, color string) error {
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil {
+		return err
+	}
+	if color == "" {
+		view.DefaultGridColor, view.ColorID = nil, 0
+		return err
+	}
+	rgb, err := parseHexColor(color)
+	if err != nil {
+		return err
+	}
+	colorID, minDist := 0, -1
+	for idx := 8; idx < 64; idx++ {
+		paletteRGB, err := parseHexColor(f.getPaletteColor(idx))
+		if err != nil {
+			continue
+		}
+		var dist int
+		for i := range rgb {
+			dist += (rgb[i] - paletteRGB[i]) * (rgb[i] - paletteRGB[i])
+		}
+		if minDist == -1 || dist < minDist {
+			colorID, minDist = idx, dist
+		}
+	}
+	view.DefaultGridColor, view.ColorID = boolPtr(false), colorID
+	return nil
+}
+
+// getPaletteColor provides a function to get the RGB hex color code of the
+// color palette by given index, the alpha channel of the custom indexed
+// colors in the workbook will be ignored.
+func (f *File) getPaletteColor(idx int) string {
+	color := f.GetBaseColor("", idx, nil)
+	if len(color) > 6 {
+		color = color[len(color)-6:]
+	}
+	return color
+}
+
+// GetGridlineColor provides a function to get the RGB hex color code of the
+// grid lines for the first sheet view of the worksheet by given worksheet
+// name. An empty string will be returned if the worksheet uses the default
+// grid lines color.
+func (f *File) GetGridlineColor(sheet string) (string, error) {
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil {
+		return "", err
+	}
+	if view.DefaultGridColor == nil || *view.DefaultGridColor || view.ColorID >= 64 {
+		return "", err
+	}
+	return strings.ToUpper(f.getPaletteColor(view.ColorID)), err
+}
+
+// parseHexColor provides a function to parse the red, green and blue
+// components of the RGB hex color code, the color code could be prefixed with
+// the number sign.
+func parseHexColor(color string) ([]int, error) {
+	hexColor := strings.TrimPrefix(color, "#")
+	if len(hexColor) != 6 {
+		return nil, newInvalidColorError(color)
+	}
+	rgb := make([]int, 3)
+	for i := range rgb {
+		val, err := strconv.ParseUint(hexColor[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return nil, newInvalidColorError(color)
+		}
+		rgb[i] = int(val)
+	}
+	return rgb, nil
+}
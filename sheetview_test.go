@@ -48,3 +48,53 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestGridlineColor(t *testing.T) {
+	f := NewFile()
+	color, err := f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	assert.NoError(t, f.SetGridlineColor("Sheet1", "#0000ff"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 12, ws.(*xlsxWorksheet).SheetViews.SheetView[0].ColorID)
+	assert.False(t, *ws.(*xlsxWorksheet).SheetViews.SheetView[0].DefaultGridColor)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0000FF", color)
+	// Test set grid lines color with the nearest palette color
+	assert.NoError(t, f.SetGridlineColor("Sheet1", "FE0102"))
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "FF0000", color)
+	// Test reset grid lines color to the default color
+	assert.NoError(t, f.SetGridlineColor("Sheet1", ""))
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	// Test set grid lines color with the custom indexed colors
+	f.Styles.Colors = &xlsxStyleColors{IndexedColors: &xlsxIndexedColors{}}
+	for idx := 0; idx < 64; idx++ {
+		f.Styles.Colors.IndexedColors.RgbColor = append(f.Styles.Colors.IndexedColors.RgbColor, xlsxColor{RGB: "00112233"})
+	}
+	f.Styles.Colors.IndexedColors.RgbColor[10].RGB = "invalid"
+	assert.NoError(t, f.SetGridlineColor("Sheet1", "112233"))
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "112233", color)
+	// Test set grid lines color with invalid color
+	for _, color := range []string{"0000F", "#00GG00", "blue"} {
+		assert.Equal(t, newInvalidColorError(color), f.SetGridlineColor("Sheet1", color))
+	}
+	// Test set and get grid lines color on not exists worksheet
+	assert.EqualError(t, f.SetGridlineColor("SheetN", "0000FF"), "sheet SheetN does not exist")
+	_, err = f.GetGridlineColor("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
@@ -19,9 +19,16 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
+// timelineDateLayout defined the layout of the date in the timeline cache.
+const timelineDateLayout = "2006-01-02T15:04:05"
+
+// timelineLevels defined the time levels of the timeline.
+var timelineLevels = []string{"years", "quarters", "months", "days"}
+
 // SlicerOptions represents the settings of the slicer.
 //
 // Name specifies the slicer name, should be an existing field name of the given
@@ -467,6 +474,20 @@ func (f *File) timelineReader(timelineXML string) (*xlsxTimelines, error) {
 	return timeline, nil
 }
 
+// timelineCacheReader provides a function to get the pointer to the
+// structure after deserialization of xl/timelineCaches/timelineCache%d.xml.
+func (f *File) timelineCacheReader(timelineCacheXML string) (*xlsxTimelineCacheDefinition, error) {
+	content, ok := f.Pkg.Load(timelineCacheXML)
+	timelineCache := &xlsxTimelineCacheDefinition{}
+	if ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(timelineCache); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return timelineCache, nil
+}
+
 // addSlicerCache adds a new slicer cache by giving the slicer cache name,
 // column index, slicer, and table or pivot table options.
 func (f *File) addSlicerCache(slicerCacheName string, colIdx int, opts *SlicerOptions, table *Table, pivotTable *PivotTableOptions) error {
@@ -725,3 +746,109 @@ func (f *File) addWorkbookSlicerCache(slicerCacheID int, URI string) error {
 	wb.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
 	return err
 }
+
+// GetTimelines provides a function to get all timelines in a worksheet by
+// given worksheet name. The timelines are the date range filters of the pivot
+// tables, this function returns the connected pivot table and date field, and
+// the current selected date range of each timeline. For example, get the
+// timelines on Sheet1:
+//
+//	timelines, err := f.GetTimelines("Sheet1")
+func (f *File) GetTimelines(sheet string) ([]Timeline, error) {
+	var timelines []Timeline
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return timelines, err
+	}
+	if ws.ExtLst == nil {
+		return timelines, err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return timelines, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURITimelineRefs {
+			continue
+		}
+		timelineRefs := new(decodeTimelineRefs)
+		_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(timelineRefs)
+		for _, timelineRef := range timelineRefs.TimelineRef {
+			target := f.getSheetRelationshipsTargetByID(sheet, timelineRef.RID)
+			if target == "" {
+				continue
+			}
+			timelineXML := strings.ReplaceAll(target, "..", "xl")
+			if strings.HasPrefix(target, "/") {
+				timelineXML = strings.TrimPrefix(target, "/")
+			}
+			sheetTimelines, err := f.timelineReader(timelineXML)
+			if err != nil {
+				return timelines, err
+			}
+			for _, timeline := range sheetTimelines.Timeline {
+				opts, err := f.getTimeline(timeline)
+				if err != nil {
+					return timelines, err
+				}
+				timelines = append(timelines, opts)
+			}
+		}
+	}
+	return timelines, err
+}
+
+// getTimeline provides a function to get the timeline settings by given
+// timeline view, the connected pivot table, date field and selected date
+// range are read from the timeline cache.
+func (f *File) getTimeline(timeline xlsxTimeline) (Timeline, error) {
+	opts := Timeline{Name: timeline.Name, Caption: timeline.Caption}
+	if timeline.Level >= 0 && timeline.Level < len(timelineLevels) {
+		opts.Level = timelineLevels[timeline.Level]
+	}
+	var (
+		err           error
+		timelineCache *xlsxTimelineCacheDefinition
+		cachePaths    []string
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/timelineCaches/timelineCache") {
+			cachePaths = append(cachePaths, k.(string))
+		}
+		return true
+	})
+	sort.Strings(cachePaths)
+	for _, cachePath := range cachePaths {
+		cache, err := f.timelineCacheReader(cachePath)
+		if err != nil {
+			return opts, err
+		}
+		if cache.Name == timeline.Cache {
+			timelineCache = cache
+			break
+		}
+	}
+	if timelineCache == nil {
+		return opts, err
+	}
+	opts.Field = timelineCache.SourceName
+	if timelineCache.PivotTables != nil && len(timelineCache.PivotTables.PivotTable) > 0 {
+		pivotTable := timelineCache.PivotTables.PivotTable[0]
+		opts.TableName = pivotTable.Name
+		wb, err := f.workbookReader()
+		if err != nil {
+			return opts, err
+		}
+		for _, sheet := range wb.Sheets.Sheet {
+			if sheet.SheetID == pivotTable.TabID {
+				opts.TableSheet = sheet.Name
+			}
+		}
+	}
+	if timelineCache.State != nil && timelineCache.State.Selection != nil {
+		opts.StartDate, _ = time.Parse(timelineDateLayout, timelineCache.State.Selection.StartDate)
+		opts.EndDate, _ = time.Parse(timelineDateLayout, timelineCache.State.Selection.EndDate)
+	}
+	return opts, err
+}
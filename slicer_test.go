@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -384,3 +385,66 @@ func TestAddPivotCacheSlicer(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestGetTimelines(t *testing.T) {
+	f := NewFile()
+	// Test get timelines without timelines
+	timelines, err := f.GetTimelines("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, timelines)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	rID := f.addRels("xl/worksheets/_rels/sheet1.xml.rels", "http://schemas.microsoft.com/office/2011/relationships/timeline", "../timelines/timeline1.xml", "")
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x15="%s"><x15:timelineRefs><x15:timelineRef xmlns:r="%s" r:id="rId%d"/><x15:timelineRef xmlns:r="%s" r:id="rId100"/></x15:timelineRefs></ext>`,
+		ExtURITimelineRefs, NameSpaceSpreadSheetX15.Value, SourceRelationship.Value, rID, SourceRelationship.Value)}
+	f.Pkg.Store("xl/timelines/timeline1.xml", []byte(`<timelines xmlns="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"><timeline name="NativeTimeline_Date" cache="NativeTimeline_Date" caption="Date" level="2" selectionLevel="2" scrollPosition="2023-01-01T00:00:00"/><timeline name="Timeline_Ship" cache="NativeTimeline_Ship" caption="Ship" level="0" selectionLevel="0"/></timelines>`))
+	f.Pkg.Store("xl/timelineCaches/timelineCache1.xml", []byte(`<timelineCacheDefinition xmlns="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main" name="NativeTimeline_Order" sourceName="Order"/>`))
+	f.Pkg.Store("xl/timelineCaches/timelineCache2.xml", []byte(`<timelineCacheDefinition xmlns="http://schemas.microsoft.com/office/spreadsheetml/2010/11/main" name="NativeTimeline_Date" sourceName="Date"><pivotTables><pivotTable tabId="2" name="PivotTable1"/></pivotTables><state minimalRefreshVersion="6" lastRefreshVersion="6" pivotCacheId="1" filterType="dateBetween"><selection startDate="2023-01-01T00:00:00" endDate="2023-03-31T00:00:00"/><bounds startDate="2023-01-01T00:00:00" endDate="2024-01-01T00:00:00"/></state></timelineCacheDefinition>`))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	timelines, err = f.GetTimelines("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Timeline{
+		{
+			Name:       "NativeTimeline_Date",
+			Caption:    "Date",
+			Field:      "Date",
+			TableSheet: "Sheet2",
+			TableName:  "PivotTable1",
+			Level:      "months",
+			StartDate:  time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:    time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{Name: "Timeline_Ship", Caption: "Ship", Level: "years"},
+	}, timelines)
+	// Test get timelines with not exist worksheet
+	_, err = f.GetTimelines("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get timelines with unsupported charset timeline cache
+	f.Pkg.Store("xl/timelineCaches/timelineCache1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTimelines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get timelines with unsupported charset workbook
+	f.Pkg.Delete("xl/timelineCaches/timelineCache1.xml")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetTimelines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get timelines with unsupported charset timeline
+	f.Pkg.Store("xl/timelines/timeline1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTimelines("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get timelines with invalid worksheet extension list
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ExtLst.Ext = "<ext><x15:timelineRefs>"
+	_, err = f.GetTimelines("Sheet1")
+	assert.Error(t, err)
+	assert.NoError(t, f.Close())
+}
@@ -15,6 +15,7 @@ import (
 	"encoding/xml"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -412,7 +413,8 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 		}
 		sparkType = specifiedSparkTypes
 	}
-	group = sparklineGroupPresets[opts.Style]
+	preset := *sparklineGroupPresets[opts.Style]
+	group = &preset
 	group.Type = sparkType
 	group.ColorAxis = &xlsxColor{RGB: "FF000000"}
 	group.DisplayEmptyCellsAs = "gap"
@@ -439,6 +441,86 @@ func (f *File) AddSparkline(sheet string, opts *SparklineOptions) error {
 	return err
 }
 
+// GetSparklineData provides a function to get the values plotted by the
+// sparkline by given worksheet name and the cell reference of the sparkline
+// location. The data range of the sparkline will be resolved in the workbook,
+// and the values of the numeric cells will be returned in the order of the
+// range, the empty and non-numeric cells which are not plotted will be
+// skipped. An error will be returned if the cell doesn't have a sparkline.
+// For example, get the values plotted by the sparkline in the cell F1 on
+// Sheet1:
+//
+//	values, err := f.GetSparklineData("Sheet1", "F1")
+func (f *File) GetSparklineData(sheet, cell string) ([]float64, error) {
+	var values []float64
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return values, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return values, err
+	}
+	if ws.ExtLst == nil {
+		return values, newNoExistSparklineError(cell)
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return values, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURISparklineGroups {
+			continue
+		}
+		decodeSparklineGroups := new(decodeX14SparklineGroups)
+		if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+			Decode(decodeSparklineGroups); err != nil && err != io.EOF {
+			return values, err
+		}
+		for _, group := range decodeSparklineGroups.SparklineGroups {
+			for _, sparkline := range group.Sparklines.Sparkline {
+				if c, r, err := CellNameToCoordinates(sparkline.Sqref); err != nil || c != col || r != row {
+					continue
+				}
+				return f.getSparklineValues(sheet, sparkline.F)
+			}
+		}
+	}
+	return values, newNoExistSparklineError(cell)
+}
+
+// getSparklineValues provides a function to get the numeric values in the
+// data range of the sparkline.
+func (f *File) getSparklineValues(sheet, ref string) ([]float64, error) {
+	var values []float64
+	if i := strings.LastIndex(ref, "!"); i != -1 {
+		sheet, ref = unquoteSheetName(ref[:i]), ref[i+1:]
+	}
+	cells := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[1])
+	if err != nil {
+		return values, err
+	}
+	_ = sortCoordinates(coordinates)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			value, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return values, err
+			}
+			if num, err := strconv.ParseFloat(value, 64); err == nil {
+				values = append(values, num)
+			}
+		}
+	}
+	return values, err
+}
+
 // parseFormatAddSparklineSet provides a function to validate sparkline
 // properties.
 func (f *File) parseFormatAddSparklineSet(sheet string, opts *SparklineOptions) (*xlsxWorksheet, error) {
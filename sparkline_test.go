@@ -3,6 +3,7 @@ package excelize
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -290,6 +291,65 @@ func TestAppendSparkline(t *testing.T) {
 	assert.EqualError(t, f.appendSparkline(ws, &xlsxX14SparklineGroup{}, &xlsxX14SparklineGroups{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSparklineData(t *testing.T) {
+	f, err := prepareSparklineDataset()
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A1", "A2"},
+		Range:    []string{"Sheet3!A1:J1", "'Sheet3'!$A$2:$J$2"},
+		Markers:  true,
+	}))
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A3"},
+		Range:    []string{"Sheet2!A3:E3"},
+		Type:     "column",
+	}))
+	for cell, expected := range map[string][]float64{
+		"A1": {-2, 2, 3, -1, 0, -2, 3, 2, 1, 0},
+		"A2": {30, 20, 33, 20, 15, 5, 5, 15, 10, 15},
+		"A3": {1, -1, -1, 1, -1},
+	} {
+		values, err := f.GetSparklineData("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, values, cell)
+	}
+	// Test get sparkline data with empty and non-numeric cells in the range
+	assert.NoError(t, f.SetCellValue("Sheet2", "B3", nil))
+	assert.NoError(t, f.SetCellValue("Sheet2", "C3", "text"))
+	values, err := f.GetSparklineData("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 1, -1}, values)
+	// Test get sparkline data on the cell without sparkline
+	_, err = f.GetSparklineData("Sheet1", "B1")
+	assert.EqualError(t, err, "sparkline in cell B1 does not exist")
+	_, err = f.GetSparklineData("Sheet2", "A1")
+	assert.EqualError(t, err, "sparkline in cell A1 does not exist")
+	// Test get sparkline data with invalid cell reference
+	_, err = f.GetSparklineData("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get sparkline data on not exists worksheet
+	_, err = f.GetSparklineData("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sparkline data with not exists worksheet in the data range
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ext := ws.ExtLst.Ext
+	ws.ExtLst.Ext = strings.ReplaceAll(ext, "Sheet2!A3:E3", "SheetN!A3:E3")
+	_, err = f.GetSparklineData("Sheet1", "A3")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sparkline data with invalid data range
+	ws.ExtLst.Ext = strings.ReplaceAll(ext, "Sheet2!A3:E3", "Sheet2!A:E3")
+	_, err = f.GetSparklineData("Sheet1", "A3")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get sparkline data with unsupported charset
+	ws.ExtLst.Ext = string(MacintoshCyrillicCharset)
+	_, err = f.GetSparklineData("Sheet1", "A3")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	ws.ExtLst.Ext = fmt.Sprintf(`<ext uri="%s">%s</ext>`, ExtURISparklineGroups, MacintoshCyrillicCharset)
+	_, err = f.GetSparklineData("Sheet1", "A3")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func prepareSparklineDataset() (*File, error) {
 	f := NewFile()
 	sheet2 := [][]int{
@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -547,6 +548,14 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 	case []RichTextRun:
 		c.T, c.IS = "inlineStr", &xlsxSI{}
 		c.IS.R, err = setRichText(val)
+	case fmt.Stringer:
+		c.setCellValue(val.String())
+	case encoding.TextMarshaler:
+		var text []byte
+		if text, err = val.MarshalText(); err != nil {
+			return err
+		}
+		c.setCellValue(string(text))
 	default:
 		c.setCellValue(fmt.Sprint(val))
 	}
@@ -680,6 +689,7 @@ func (sw *StreamWriter) Flush() error {
 	sw.file.Sheet.Delete(sheetPath)
 	sw.file.checked.Delete(sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
+	sw.file.lazyFiles.Delete(sheetPath)
 
 	return nil
 }
@@ -21,6 +21,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/mohae/deepcopy"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -134,6 +136,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 			return style, ErrFontSize
 		}
 	}
+	if style.Alignment != nil && style.Alignment.ReadingOrder > 2 {
+		return style, ErrReadingOrder
+	}
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
@@ -241,6 +246,14 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 centerContinuous
 //	 distributed
 //
+// The horizontal alignment 'centerContinuous' centers the text across the
+// selected cells, which is known as "Center Across Selection" in the
+// spreadsheet application. Apply the style with this alignment to a range of
+// cells, the value of the leftmost non-empty cell will be displayed centered
+// across the adjacent empty cells in the range. Unlike the merged cells, the
+// cells are not merged, each cell remains individually addressable and can
+// still be selected, sorted and set values independently.
+//
 // The following table shows the type of cells' vertical alignment used in
 // 'Alignment.Vertical':
 //
@@ -957,6 +970,14 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // or GetConditionalStyle function, the DecimalPlaces only doesn't nil if a
 // number format code has the same decimal places in the positive part negative
 // part, or only the positive part.
+//
+// QuotePrefix is used to mark the cell value as text, the spreadsheet
+// application will not interpret the leading character of the value like
+// "=", "+" or "-" as the beginning of a formula.
+//
+// Checkbox is used to apply the checkbox cell control, the boolean cell value
+// will be displayed as a checkbox in the spreadsheet application which
+// supports this feature.
 func (f *File) NewStyle(style *Style) (int, error) {
 	var (
 		fs                                  *Style
@@ -1023,7 +1044,119 @@ func (f *File) NewStyle(style *Style) (int, error) {
 
 	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
 	applyProtection, protection := fs.Protection != nil, newProtection(fs)
-	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	if cellXfsID, err = setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, fs.QuotePrefix, alignment, protection); err != nil || !fs.Checkbox {
+		return cellXfsID, err
+	}
+	complementID, err := f.addFeaturePropertyBag()
+	if err != nil {
+		return cellXfsID, err
+	}
+	s.CellXfs.Xf[cellXfsID].ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(templateXfCheckbox, complementID)}
+	return cellXfsID, err
+}
+
+// addFeaturePropertyBag provides a function to add the feature property bag
+// part which defines the checkbox cell control to the workbook if not exist,
+// or look up and append the checkbox bags in the existing part. This function
+// returns the index of the checkbox cell format complement in the mapped
+// feature property bags.
+func (f *File) addFeaturePropertyBag() (int, error) {
+	content := f.readXML(defaultXMLPathFeaturePropBag)
+	if len(content) == 0 {
+		if err := f.setContentTypes("/"+defaultXMLPathFeaturePropBag, ContentTypeFeaturePropertyBag); err != nil {
+			return 0, err
+		}
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipFeaturePropertyBag, strings.TrimPrefix(defaultXMLPathFeaturePropBag, "xl/"), "")
+		f.Pkg.Store(defaultXMLPathFeaturePropBag, []byte(xml.Header+templateFeaturePropertyBag))
+		return 0, nil
+	}
+	bags := new(xlsxFeaturePropertyBags)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+		Decode(bags); err != nil && err != io.EOF {
+		return 0, err
+	}
+	count := len(bags.Bag)
+	decodeBag := func(bag xlsxFeaturePropertyBag) (decode decodeFeaturePropertyBag) {
+		_ = xml.Unmarshal([]byte("<bag>"+bag.Content+"</bag>"), &decode)
+		return
+	}
+	getBagID := func(bagType, key string, val int) int {
+		for idx, bag := range bags.Bag {
+			if bag.Type != bagType {
+				continue
+			}
+			if key == "" {
+				return idx
+			}
+			for _, bagID := range decodeBag(bag).BagID {
+				if bagID.K == key && bagID.Val == val {
+					return idx
+				}
+			}
+		}
+		bag := xlsxFeaturePropertyBag{Type: bagType}
+		if key != "" {
+			bag.Content = fmt.Sprintf(`<bagId k="%s">%d</bagId>`, key, val)
+		}
+		bags.Bag = append(bags.Bag, bag)
+		return len(bags.Bag) - 1
+	}
+	complementID := getBagID("XFComplement", "XFControls", getBagID("XFControls", "CellControl", getBagID("Checkbox", "", 0)))
+	mapperID := -1
+	for idx, bag := range bags.Bag {
+		if bag.Type == "XFComplements" {
+			mapperID = idx
+			break
+		}
+	}
+	if mapperID == -1 {
+		bags.Bag = append(bags.Bag, xlsxFeaturePropertyBag{Type: "XFComplements", ExtRef: "XFComplementsMapperExtRef"})
+		mapperID = len(bags.Bag) - 1
+	}
+	mapper := decodeBag(bags.Bag[mapperID])
+	mappedID := -1
+	for idx, a := range mapper.A {
+		if a.K == "MappedFeaturePropertyBags" {
+			mappedID = idx
+			break
+		}
+	}
+	if mappedID == -1 {
+		mapper.A = append(mapper.A, decodeFeaturePropertyBagArray{K: "MappedFeaturePropertyBags"})
+		mappedID = len(mapper.A) - 1
+	}
+	complementIdx := -1
+	for idx, bagID := range mapper.A[mappedID].BagID {
+		if bagID == complementID {
+			complementIdx = idx
+			break
+		}
+	}
+	if complementIdx != -1 && len(bags.Bag) == count {
+		return complementIdx, nil
+	}
+	if complementIdx == -1 {
+		mapper.A[mappedID].BagID = append(mapper.A[mappedID].BagID, complementID)
+		complementIdx = len(mapper.A[mappedID].BagID) - 1
+		var mapped strings.Builder
+		for _, a := range mapper.A {
+			mapped.WriteString(fmt.Sprintf(`<a k="%s">`, a.K))
+			for _, bagID := range a.BagID {
+				mapped.WriteString(fmt.Sprintf("<bagId>%d</bagId>", bagID))
+			}
+			mapped.WriteString("</a>")
+		}
+		bags.Bag[mapperID].Content = mapped.String()
+	}
+	output, err := xml.Marshal(bags)
+	f.saveFileList(defaultXMLPathFeaturePropBag, output)
+	return complementIdx, err
+}
+
+// isCheckboxXf returns if the given cell format applies the checkbox cell
+// control.
+func isCheckboxXf(xf xlsxXf) bool {
+	return xf.ExtLst != nil && strings.Contains(xf.ExtLst.Ext, ExtURIFeaturePropertyBag)
 }
 
 var (
@@ -1134,6 +1267,12 @@ var (
 			}
 			return reflect.DeepEqual(xf.Protection, newProtection(style)) && xf.ApplyProtection != nil && *xf.ApplyProtection
 		},
+		"quotePrefix": func(ID int, xf xlsxXf, style *Style) bool {
+			return style.QuotePrefix == (xf.QuotePrefix != nil && *xf.QuotePrefix)
+		},
+		"checkbox": func(ID int, xf xlsxXf, style *Style) bool {
+			return style.Checkbox == isCheckboxXf(xf)
+		},
 	}
 
 	// extractStyleCondFuncs provides a function set to returns if shoudle be
@@ -1625,6 +1764,8 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 	if extractStyleCondFuncs["protection"](xf, s) {
 		f.extractProtection(xf.Protection, s, style)
 	}
+	style.QuotePrefix = xf.QuotePrefix != nil && *xf.QuotePrefix
+	style.Checkbox = isCheckboxXf(xf)
 	f.extractNumFmt(xf.NumFmtID, s, style)
 	return style, nil
 }
@@ -1653,7 +1794,9 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 			getXfIDFuncs["fill"](fillID, xf, style) &&
 			getXfIDFuncs["border"](borderID, xf, style) &&
 			getXfIDFuncs["alignment"](0, xf, style) &&
-			getXfIDFuncs["protection"](0, xf, style) {
+			getXfIDFuncs["protection"](0, xf, style) &&
+			getXfIDFuncs["quotePrefix"](0, xf, style) &&
+			getXfIDFuncs["checkbox"](0, xf, style) {
 			styleID = xfID
 			return styleID, err
 		}
@@ -1710,6 +1853,7 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1796,6 +1940,117 @@ func (f *File) SetDefaultFont(fontName string) error {
 	return err
 }
 
+// GetUsedFonts provides a function to get the distinct fonts referenced by
+// the cells, rows and columns of the worksheet by given worksheet name. The
+// blank cells without style will be ignored. The returned fonts are ordered
+// by the font index in the style sheet, this function can be used to check
+// which fonts should be available for rendering the worksheet.
+func (f *File) GetUsedFonts(sheet string) ([]Font, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	fontIDs := map[int]struct{}{}
+	ws.getUsedFontIDs(s, fontIDs)
+	return f.getUsedFonts(s, fontIDs), nil
+}
+
+// GetWorkbookUsedFonts provides a function to get the distinct fonts
+// referenced by the cells, rows and columns of all worksheets in the
+// workbook.
+func (f *File) GetWorkbookUsedFonts() ([]Font, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	fontIDs := map[int]struct{}{}
+	for _, sheet := range f.GetSheetList() {
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return nil, err
+		}
+		ws.getUsedFontIDs(s, fontIDs)
+	}
+	return f.getUsedFonts(s, fontIDs), nil
+}
+
+// getUsedFontIDs provides a function to collect font indexes referenced by
+// the cells, rows and columns of the worksheet.
+func (ws *xlsxWorksheet) getUsedFontIDs(s *xlsxStyleSheet, fontIDs map[int]struct{}) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	addFontID := func(styleID int) {
+		if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+			return
+		}
+		if xf := s.CellXfs.Xf[styleID]; extractStyleCondFuncs["font"](xf, s) {
+			fontIDs[*xf.FontID] = struct{}{}
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			addFontID(col.Style)
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if row.CustomFormat {
+			addFontID(row.S)
+		}
+		for _, c := range row.C {
+			if c.S != 0 || c.V != "" || c.IS != nil || c.F != nil {
+				addFontID(c.S)
+			}
+		}
+	}
+}
+
+// getUsedFonts provides a function to get the distinct font definitions by
+// given font indexes in the style sheet.
+func (f *File) getUsedFonts(s *xlsxStyleSheet, fontIDs map[int]struct{}) []Font {
+	var (
+		IDs   []int
+		fonts []Font
+	)
+	for fontID := range fontIDs {
+		IDs = append(IDs, fontID)
+	}
+	sort.Ints(IDs)
+	for _, fontID := range IDs {
+		var style Style
+		f.extractFont(s.Fonts.Font[fontID], s, &style)
+		if style.Font == nil || inFontSlice(fonts, *style.Font) != -1 {
+			continue
+		}
+		fonts = append(fonts, *style.Font)
+	}
+	return fonts
+}
+
+// inFontSlice provides a function to return the index of the font in the
+// given font slice, if not found return -1.
+func inFontSlice(fonts []Font, font Font) int {
+	for idx, fnt := range fonts {
+		if reflect.DeepEqual(fnt, font) {
+			return idx
+		}
+	}
+	return -1
+}
+
 // readDefaultFont provides an un-marshalled font value.
 func (f *File) readDefaultFont() (*xlsxFont, error) {
 	f.mu.Lock()
@@ -2149,7 +2404,7 @@ func newBorders(style *Style) *xlsxBorder {
 
 // setCellXfs provides a function to set describes all the formatting for a
 // cell.
-func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
+func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection, quotePrefix bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
 	var xf xlsxXf
 	xf.FontID = intPtr(fontID)
 	if fontID != 0 {
@@ -2179,6 +2434,9 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 		xf.ApplyProtection = boolPtr(applyProtection)
 		xf.Protection = protection
 	}
+	if quotePrefix {
+		xf.QuotePrefix = boolPtr(quotePrefix)
+	}
 	xfID := 0
 	xf.XfID = &xfID
 	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
@@ -2202,6 +2460,37 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
+// GetCellBorder provides a function to get the resolved border definitions of
+// the cell by given worksheet name and cell reference. The returned borders
+// contain the left, right, top, bottom and diagonal lines which have been set
+// for the cell, with the border style index and RGB color of each line.
+func (f *File) GetCellBorder(sheet, cell string) ([]Border, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	return style.Border, err
+}
+
+// GetCellFill provides a function to get the resolved fill definition of the
+// cell by given worksheet name and cell reference. Theme and indexed colors
+// will be converted to RGB color.
+func (f *File) GetCellFill(sheet, cell string) (Fill, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return Fill{}, err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return Fill{}, err
+	}
+	return style.Fill, err
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, range reference and style ID. This function is concurrency
 // safe. Note that diagonalDown and diagonalUp type border should be use same
@@ -2361,6 +2650,156 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	return err
 }
 
+// SetIntersectionStyle provides a function to set the style of the cells at
+// the intersections of the given rows and columns by given worksheet name, row
+// numbers, column numbers and style ID. Only the cells where a listed row
+// meets a listed column will be styled, the other cells in these rows and
+// columns will be kept. Note that the style ID should be obtained by the
+// NewStyle function. For example, set the style of the cells B2, D2, B4 and D4
+// on Sheet1:
+//
+//	err := f.SetIntersectionStyle("Sheet1", []int{2, 4}, []int{2, 4}, styleID)
+func (f *File) SetIntersectionStyle(sheet string, rows, cols []int, styleID int) error {
+	var maxCol int
+	for _, row := range rows {
+		if row < 1 {
+			return newInvalidRowNumberError(row)
+		}
+		if row > TotalRows {
+			return ErrMaxRows
+		}
+	}
+	for _, col := range cols {
+		if col < MinColumns || col > MaxColumns {
+			return ErrColumnNumber
+		}
+		if col > maxCol {
+			maxCol = col
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return newInvalidStyleID(styleID)
+	}
+	if len(rows) == 0 || len(cols) == 0 {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range rows {
+		ws.prepareSheetXML(maxCol, row)
+		for _, col := range cols {
+			ws.SheetData.Row[row-1].C[col-1].S = styleID
+		}
+	}
+	return err
+}
+
+// SetRangeUnlocked provides a function to mark the cells in the given range
+// reference as unlocked input cells, which remain editable after the
+// worksheet has been protected by the ProtectSheet function. This function
+// keeps the other style attributes of each cell in the range, and only
+// changes the locked flag of the cell protection settings. For example,
+// unlock the range B2:C5 on Sheet1 and protect the worksheet:
+//
+//	if err := f.SetRangeUnlocked("Sheet1", "B2:C5"); err != nil {
+//	    fmt.Println(err)
+//	}
+//	err := f.ProtectSheet("Sheet1", &excelize.SheetProtectionOptions{
+//	    Password:            "password",
+//	    SelectLockedCells:   true,
+//	    SelectUnlockedCells: true,
+//	})
+func (f *File) SetRangeUnlocked(sheet, rangeRef string) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws.prepareSheetXML(coordinates[2], coordinates[3])
+	ws.makeContiguousColumns(coordinates[1], coordinates[3], coordinates[2])
+	unlockedStyles := make(map[int]int)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			styleID := ws.prepareCellStyle(col, row, c.S)
+			unlockedID, ok := unlockedStyles[styleID]
+			if !ok {
+				if unlockedID, err = s.unlockedStyleID(styleID); err != nil {
+					return err
+				}
+				unlockedStyles[styleID] = unlockedID
+			}
+			c.S = unlockedID
+		}
+	}
+	return err
+}
+
+// unlockedStyleID provides a function to get the cell format ID which has the
+// same formatting with the given cell format ID but with the locked
+// protection flag turned off. A new cell format will be created if not exists.
+func (ss *xlsxStyleSheet) unlockedStyleID(styleID int) (int, error) {
+	if ss.CellXfs == nil {
+		ss.CellXfs = &xlsxCellXfs{}
+	}
+	if styleID < 0 || len(ss.CellXfs.Xf) <= styleID {
+		styleID = 0
+	}
+	var xf xlsxXf
+	if styleID < len(ss.CellXfs.Xf) {
+		xf = deepcopy.Copy(ss.CellXfs.Xf[styleID]).(xlsxXf)
+	} else {
+		xf = xlsxXf{NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0), XfID: intPtr(0)}
+	}
+	if xf.Protection != nil && xf.Protection.Locked != nil && !*xf.Protection.Locked &&
+		xf.ApplyProtection != nil && *xf.ApplyProtection {
+		return styleID, nil
+	}
+	if xf.Protection == nil {
+		xf.Protection = &xlsxProtection{}
+	}
+	xf.Protection.Locked, xf.ApplyProtection = boolPtr(false), boolPtr(true)
+	for xfID, cellXf := range ss.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return xfID, nil
+		}
+	}
+	if len(ss.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	ss.CellXfs.Xf = append(ss.CellXfs.Xf, xf)
+	ss.CellXfs.Count = len(ss.CellXfs.Xf)
+	return ss.CellXfs.Count - 1, nil
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
@@ -3178,7 +3617,7 @@ func (f *File) extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalF
 // extractCondFmtIconSet provides a function to extract conditional format
 // settings for icon sets by given conditional formatting rule.
 func (f *File) extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
-	format := ConditionalFormatOptions{Type: "icon_set"}
+	format := ConditionalFormatOptions{StopIfTrue: c.StopIfTrue, Type: "icon_set"}
 	if c.IconSet != nil {
 		if c.IconSet.ShowValue != nil {
 			format.IconsOnly = !*c.IconSet.ShowValue
@@ -3190,41 +3629,147 @@ func (f *File) extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) Conditio
 }
 
 // GetConditionalFormats returns conditional format settings by given worksheet
-// name.
+// name. The differential format of each rule will be resolved to the Style
+// field of the conditional format settings, so that you can know the
+// formatting which the rule applies. For example, get the fill color of the
+// first rule on the range reference A1:A10:
+//
+//	formats, err := f.GetConditionalFormats("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if rules := formats["A1:A10"]; len(rules) > 0 && rules[0].Style != nil {
+//	    fmt.Println(rules[0].Style.Fill.Color)
+//	}
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return conditionalFormats, err
 	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return conditionalFormats, err
+	}
+	var dxfs int
+	if s.Dxfs != nil {
+		dxfs = len(s.Dxfs.Dxfs)
+	}
 	for _, cf := range ws.ConditionalFormatting {
 		var opts []ConditionalFormatOptions
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(f, cr, ws.ExtLst))
+				opt := extractFunc(f, cr, ws.ExtLst)
+				if cr.DxfID != nil && *cr.DxfID >= 0 && *cr.DxfID < dxfs {
+					if opt.Style, err = f.GetConditionalStyle(*cr.DxfID); err != nil {
+						return conditionalFormats, err
+					}
+				}
+				opts = append(opts, opt)
 			}
 		}
-		conditionalFormats[cf.SQRef] = opts
+		SQRef := strings.Join(strings.Fields(cf.SQRef), " ")
+		conditionalFormats[SQRef] = append(conditionalFormats[SQRef], opts...)
 	}
 	return conditionalFormats, err
 }
 
 // UnsetConditionalFormat provides a function to unset the conditional format
-// by given worksheet name and range reference.
+// by given worksheet name and range reference. The range reference could
+// contain multiple areas separated by space or comma. When a conditional
+// format applies to multiple areas, only the given areas will be removed from
+// it, and the conditional format will be kept on the other areas. For example,
+// the conditional format was applied on the range reference "A1:A5 C1:C5",
+// remove it from the area C1:C5 only:
+//
+//	err := f.UnsetConditionalFormat("Sheet1", "C1:C5")
 func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	for i, cf := range ws.ConditionalFormatting {
-		if cf.SQRef == rangeRef {
+	areas := strings.Fields(strings.ReplaceAll(rangeRef, ",", " "))
+	if SQRef, _, err := prepareConditionalFormatRange(rangeRef); err == nil {
+		areas = append(areas, strings.Fields(SQRef)...)
+	}
+	for i := len(ws.ConditionalFormatting) - 1; i >= 0; i-- {
+		cf := ws.ConditionalFormatting[i]
+		if cf == nil {
+			continue
+		}
+		var kept []string
+		cfAreas := strings.Fields(cf.SQRef)
+		for _, area := range cfAreas {
+			if inStrSlice(areas, area, false) != -1 {
+				continue
+			}
+			if ref, _, err := prepareConditionalFormatRange(area); err == nil && inStrSlice(areas, ref, false) != -1 {
+				continue
+			}
+			kept = append(kept, area)
+		}
+		if len(kept) == len(cfAreas) {
+			continue
+		}
+		if len(kept) == 0 {
 			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
-			return nil
+			continue
 		}
+		cf.SQRef = strings.Join(kept, " ")
 	}
 	return nil
 }
 
+// NormalizeConditionalFormatPriorities provides a function to renumber the
+// priorities of all conditional formatting rules in the worksheet to a
+// contiguous sequence starting from 1 by given worksheet name, the relative
+// order of the rules will be preserved. The rules with the same priority and
+// the rules without priority will be ordered as they appear in the worksheet,
+// and the rules without priority will be placed after the others. This
+// function can be used to resolve the collided priorities or gaps between
+// priorities after adding or removing the rules. Note that the rules which are
+// only defined in the worksheet extension list, such as the icon sets with
+// custom icons created by the spreadsheet applications, will not be
+// renumbered, and their priorities may collide with the renumbered rules. For
+// example, renumber the priorities of the rules in Sheet1 after removing the
+// conditional format on the range C1:C5:
+//
+//	if err := f.UnsetConditionalFormat("Sheet1", "C1:C5"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.NormalizeConditionalFormatPriorities("Sheet1")
+func (f *File) NormalizeConditionalFormatPriorities(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var rules []*xlsxCfRule
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil {
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			if rule != nil {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority == 0 || rules[j].Priority == 0 {
+			return rules[j].Priority == 0 && rules[i].Priority != 0
+		}
+		return rules[i].Priority < rules[j].Priority
+	})
+	for i, rule := range rules {
+		rule.Priority = i + 1
+	}
+	return err
+}
+
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
@@ -3485,16 +4030,19 @@ func drawCondFmtNoBlanks(p int, ct, ref, GUID string, format *ConditionalFormatO
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
 func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
-	cfRule, ok := condFmtIconSetPresets[format.IconStyle]
+	preset, ok := condFmtIconSetPresets[format.IconStyle]
 	if !ok {
 		return nil, nil
 	}
+	cfRule, iconSet := *preset, *preset.IconSet
+	cfRule.IconSet = &iconSet
 	cfRule.Priority = p + 1
+	cfRule.StopIfTrue = format.StopIfTrue
 	cfRule.IconSet.IconSet = format.IconStyle
 	cfRule.IconSet.Reverse = format.ReverseIcons
 	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
 	cfRule.Type = validType[format.Type]
-	return cfRule, nil
+	return &cfRule, nil
 }
 
 // getPaletteColor provides a function to convert the RBG color by given
@@ -3517,6 +4065,65 @@ func (f *File) themeReader() (*decodeTheme, error) {
 	return &theme, nil
 }
 
+// GetThemeName provides a function to get the name of the theme used in the
+// workbook, it returns empty string if the workbook doesn't contain a theme.
+func (f *File) GetThemeName() string {
+	if f.Theme == nil {
+		return ""
+	}
+	return f.Theme.Name
+}
+
+// ApplyOfficeTheme provides a function to replace the color scheme and font
+// scheme of the workbook theme with a built-in Office theme by given theme
+// name. Theme colored cells, shapes and charts will be rendered with the new
+// palette. The supported theme names are:
+//
+//	Berlin
+//	Facet
+//	Ion
+//	Office
+//	Office 2007 - 2010
+//	Retrospect
+//
+// For example, rebrand the workbook with the Facet theme:
+//
+//	err := f.ApplyOfficeTheme("Facet")
+func (f *File) ApplyOfficeTheme(name string) error {
+	theme, ok := builtInThemes[name]
+	if !ok {
+		return newUnsupportedThemeError(name)
+	}
+	if f.Theme == nil {
+		if err := f.setContentTypes("/"+defaultXMLPathTheme, ContentTypeTheme); err != nil {
+			return err
+		}
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipTheme, "theme/theme1.xml", "")
+		f.Pkg.Store(defaultXMLPathTheme, []byte(xml.Header+templateTheme))
+		f.Theme, _ = f.themeReader()
+	}
+	f.Theme.Name = name
+	if name == "Office" {
+		f.Theme.Name = "Office Theme"
+	}
+	clrScheme := &f.Theme.ThemeElements.ClrScheme
+	clrScheme.Name = name
+	clrScheme.Dk1 = decodeCTColor{SysClr: &xlsxSysClr{Val: "windowText", LastClr: "000000"}}
+	clrScheme.Lt1 = decodeCTColor{SysClr: &xlsxSysClr{Val: "window", LastClr: "FFFFFF"}}
+	for i, clr := range []*decodeCTColor{
+		&clrScheme.Dk2, &clrScheme.Lt2, &clrScheme.Accent1, &clrScheme.Accent2,
+		&clrScheme.Accent3, &clrScheme.Accent4, &clrScheme.Accent5,
+		&clrScheme.Accent6, &clrScheme.Hlink, &clrScheme.FolHlink,
+	} {
+		*clr = decodeCTColor{SrgbClr: &attrValString{Val: stringPtr(theme.colors[i])}}
+	}
+	fontScheme := &f.Theme.ThemeElements.FontScheme
+	fontScheme.Name = name
+	fontScheme.MajorFont.Latin = &xlsxCTTextFont{Typeface: theme.majorFont}
+	fontScheme.MinorFont.Latin = &xlsxCTTextFont{Typeface: theme.minorFont}
+	return nil
+}
+
 // ThemeColor applied the color with tint value.
 func ThemeColor(baseColor string, tint float64) string {
 	if tint == 0 {
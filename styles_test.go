@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -241,6 +242,35 @@ func TestSetConditionalFormat(t *testing.T) {
 	})
 }
 
+func TestSetConditionalFormatStopIfTrue(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	// Test stacked rules where the higher priority rule stops evaluating
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "90", StopIfTrue: true},
+		{Type: "cell", Criteria: ">", Format: format, Value: "60"},
+	}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	cfRules := ws.ConditionalFormatting[0].CfRule
+	assert.Len(t, cfRules, 2)
+	assert.True(t, cfRules[0].StopIfTrue)
+	assert.False(t, cfRules[1].StopIfTrue)
+	assert.Less(t, cfRules[0].Priority, cfRules[1].Priority)
+	output, err := xml.Marshal(cfRules[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `stopIfTrue="true"`)
+	output, err = xml.Marshal(cfRules[1])
+	assert.NoError(t, err)
+	assert.NotContains(t, string(output), "stopIfTrue")
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, opts["A1:A10"][0].StopIfTrue)
+	assert.False(t, opts["A1:A10"][1].StopIfTrue)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetConditionalFormats(t *testing.T) {
 	for _, format := range [][]ConditionalFormatOptions{
 		{{Type: "cell", Format: 1, Criteria: "greater than", Value: "6"}},
@@ -274,6 +304,7 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "errors", Format: 1}},
 		{{Type: "no_errors", Format: 1}},
 		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "3Flags", StopIfTrue: true}, {Type: "icon_set", IconStyle: "3Signs"}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A2:A1,B:B,2:2", format)
@@ -293,6 +324,26 @@ func TestGetConditionalFormats(t *testing.T) {
 	opts, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts["A1:A2"])
+	// Test get conditional formats with the differential format
+	format, err := f.NewConditionalStyle(&Style{
+		Font: &Font{Color: "9A0511"},
+		Fill: Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+	}))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["B1:B10"], 1)
+	assert.NotNil(t, opts["B1:B10"][0].Style)
+	assert.Equal(t, []string{"FEC7CE"}, opts["B1:B10"][0].Style.Fill.Color)
+	assert.Equal(t, "9A0511", opts["B1:B10"][0].Style.Font.Color)
+	// Test get conditional formats with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetConditionalFormats("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 
 	// Test get conditional formats on no exists worksheet
 	f = NewFile()
@@ -311,6 +362,49 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: format, Value: "6"}}))
 	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	// Test unset conditional format from one of the multiple areas
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5 C1:C5", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: format, Value: "6"}}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A5 C1:C5"], 1)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:C5"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 1)
+	assert.Len(t, opts["A1:A5"], 1)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A5"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+	// Test get and unset conditional format with multiple areas separated by
+	// consecutive whitespace characters
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "A1:A5\n  C1:C5 E1:E5", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"TRUE"}}}},
+	}
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A5 C1:C5 E1:E5"], 1)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:C5,E1:E5"))
+	assert.Equal(t, "A1:A5", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	// Test unset conditional format with the whole column and row reference
+	ws.(*xlsxWorksheet).ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "A:A 1:1", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"TRUE"}}}},
+		{SQRef: "B:B", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"TRUE"}}}},
+	}
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A:A"))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "B1:B1048576"))
+	assert.Len(t, ws.(*xlsxWorksheet).ConditionalFormatting, 1)
+	assert.Equal(t, "1:1", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	// Test unset conditional format with invalid range reference
+	ws.(*xlsxWorksheet).ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "A1:A2:A3", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"TRUE"}}}},
+	}
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", ""))
+	assert.Len(t, ws.(*xlsxWorksheet).ConditionalFormatting, 1)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A2:A3"))
+	assert.Empty(t, ws.(*xlsxWorksheet).ConditionalFormatting)
 	// Test unset conditional format on not exists worksheet
 	assert.EqualError(t, f.UnsetConditionalFormat("SheetN", "A1:A10"), "sheet SheetN does not exist")
 	// Test unset conditional format with invalid sheet name
@@ -319,6 +413,47 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUnsetConditionalFormat.xlsx")))
 }
 
+func TestNormalizeConditionalFormatPriorities(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	for _, rangeRef := range []string{"A1:A10", "B1:B10", "C1:C10"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", rangeRef, []ConditionalFormatOptions{
+			{Type: "cell", Criteria: ">", Format: format, Value: "6"},
+			{Type: "cell", Criteria: "<", Format: format, Value: "2"},
+		}))
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	getPriorities := func() (priorities []int) {
+		for _, cf := range ws.(*xlsxWorksheet).ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				priorities = append(priorities, rule.Priority)
+			}
+		}
+		return
+	}
+	// Test normalize priorities with collided priorities after removing rules
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "B1:B10"))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: format, Value: "6"}}))
+	assert.Equal(t, []int{1, 2, 5, 6, 5}, getPriorities())
+	assert.NoError(t, f.NormalizeConditionalFormatPriorities("Sheet1"))
+	assert.Equal(t, []int{1, 2, 3, 5, 4}, getPriorities())
+	// Test normalize gapped priorities and rules without priority
+	ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].Priority = 30
+	ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[1].Priority = 0
+	ws.(*xlsxWorksheet).ConditionalFormatting[1].CfRule[0].Priority = 10
+	ws.(*xlsxWorksheet).ConditionalFormatting[1].CfRule[1].Priority = 20
+	ws.(*xlsxWorksheet).ConditionalFormatting[2].CfRule[0].Priority = 5
+	ws.(*xlsxWorksheet).ConditionalFormatting = append(ws.(*xlsxWorksheet).ConditionalFormatting, nil)
+	assert.NoError(t, f.NormalizeConditionalFormatPriorities("Sheet1"))
+	ws.(*xlsxWorksheet).ConditionalFormatting = ws.(*xlsxWorksheet).ConditionalFormatting[:3]
+	assert.Equal(t, []int{4, 5, 2, 3, 1}, getPriorities())
+	// Test normalize priorities on not exists worksheet
+	assert.EqualError(t, f.NormalizeConditionalFormatPriorities("SheetN"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestNewStyle(t *testing.T) {
 	f := NewFile()
 	for i := 0; i < 18; i++ {
@@ -439,6 +574,52 @@ func TestNewStyle(t *testing.T) {
 	assert.Equal(t, ErrCellStyles, err)
 }
 
+func TestNewStyleReadingOrder(t *testing.T) {
+	f := NewFile()
+	// Test create style with right-to-left reading order
+	styleID, err := f.NewStyle(&Style{Alignment: &Alignment{ReadingOrder: 2}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	alignment, err := xml.Marshal(f.Styles.CellXfs.Xf[styleID].Alignment)
+	assert.NoError(t, err)
+	assert.Contains(t, string(alignment), `readingOrder="2"`)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), style.Alignment.ReadingOrder)
+	// Test create style with invalid reading order
+	_, err = f.NewStyle(&Style{Alignment: &Alignment{ReadingOrder: 3}})
+	assert.Equal(t, ErrReadingOrder, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestNewStyleCenterContinuous(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "centerContinuous"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Title"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", styleID))
+	alignment, err := xml.Marshal(f.Styles.CellXfs.Xf[styleID].Alignment)
+	assert.NoError(t, err)
+	assert.Contains(t, string(alignment), `horizontal="centerContinuous"`)
+	for _, cell := range []string{"A1", "B1", "C1"} {
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, cellStyleID)
+	}
+	// Test the cells are not merged and remain individually addressable
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "Note"))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title", "", "Note"}}, rows)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "centerContinuous", style.Alignment.Horizontal)
+	assert.NoError(t, f.Close())
+}
+
 func TestConditionalStyle(t *testing.T) {
 	f := NewFile()
 	expected := &Style{Protection: &Protection{Hidden: true, Locked: true}}
@@ -520,6 +701,72 @@ func TestSetDefaultFont(t *testing.T) {
 	assert.EqualError(t, f.SetDefaultFont("Arial"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetUsedFonts(t *testing.T) {
+	f := NewFile()
+	font1, font2 := Font{Bold: true, Family: "Arial", Size: 12}, Font{Family: "Times New Roman", Size: 14}
+	styleID1, err := f.NewStyle(&Style{Font: &font1})
+	assert.NoError(t, err)
+	styleID2, err := f.NewStyle(&Style{Font: &font1, Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	styleID3, err := f.NewStyle(&Style{Font: &font2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID1))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", styleID2))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", styleID3))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, styleID1))
+	fonts, err := f.GetUsedFonts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Font{font1, font2}, fonts)
+	// Test get used fonts with duplicated font definitions in the style sheet
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.Fonts.Font = append(s.Fonts.Font, s.Fonts.Font[*s.CellXfs.Xf[styleID3].FontID])
+	s.Fonts.Count = len(s.Fonts.Font)
+	s.CellXfs.Xf[styleID2].FontID = intPtr(len(s.Fonts.Font) - 1)
+	fonts, err = f.GetUsedFonts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Font{font1, font2}, fonts)
+	// Test get used fonts of the workbook
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Excelize"))
+	fonts, err = f.GetWorkbookUsedFonts()
+	assert.NoError(t, err)
+	assert.Len(t, fonts, 3)
+	assert.Equal(t, "Calibri", fonts[0].Family)
+	// Test get used fonts with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	fonts, err = f.GetUsedFonts("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, fonts)
+	// Test get used fonts with not exist worksheet
+	_, err = f.GetUsedFonts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get used fonts of the workbook with not worksheet
+	f.Pkg.Store("xl/chartsheets/sheet3.xml", nil)
+	f.sheetMap["Chart1"] = "xl/chartsheets/sheet3.xml"
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet, xlsxSheet{Name: "Chart1", SheetID: 3, ID: "rId10"})
+	_, err = f.GetWorkbookUsedFonts()
+	assert.NoError(t, err)
+	// Test get used fonts of the workbook with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet2.xml")
+	_, err = f.GetWorkbookUsedFonts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get used fonts with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetUsedFonts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetWorkbookUsedFonts()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestStylesReader(t *testing.T) {
 	f := NewFile()
 	// Test read styles with unsupported charset
@@ -539,6 +786,47 @@ func TestThemeReader(t *testing.T) {
 	assert.EqualValues(t, &decodeTheme{}, theme)
 }
 
+func TestApplyOfficeTheme(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, "Office Theme", f.GetThemeName())
+	styleID, err := f.NewStyle(&Style{Font: &Font{ColorTheme: intPtr(4)}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	assert.NoError(t, f.ApplyOfficeTheme("Facet"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathTheme)), `<a:accent1><a:srgbClr val="90C226"></a:srgbClr></a:accent1>`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Facet", f.GetThemeName())
+	// Test the theme colored cell resolves to the accent color of the theme
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "90C226", f.GetBaseColor("", 0, style.Font.ColorTheme))
+	assert.Equal(t, "FFFFFF", f.GetBaseColor("", 0, intPtr(0)))
+	assert.Equal(t, "Trebuchet MS", f.Theme.ThemeElements.FontScheme.MajorFont.Latin.Typeface)
+	// Test apply the default Office theme
+	assert.NoError(t, f.ApplyOfficeTheme("Office"))
+	assert.Equal(t, "Office Theme", f.GetThemeName())
+	assert.Equal(t, "5B9BD5", f.GetBaseColor("", 0, intPtr(4)))
+	// Test apply theme on the workbook without theme
+	f.Theme = nil
+	f.Pkg.Delete(defaultXMLPathTheme)
+	assert.Empty(t, f.GetThemeName())
+	assert.NoError(t, f.ApplyOfficeTheme("Ion"))
+	assert.Equal(t, "B01513", f.GetBaseColor("", 0, intPtr(4)))
+	// Test apply unsupported built-in theme
+	assert.EqualError(t, f.ApplyOfficeTheme("Unknown"), "unsupported built-in theme Unknown")
+	// Test apply theme with unsupported charset content types
+	f.Theme, f.ContentTypes = nil, nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ApplyOfficeTheme("Ion"), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellStyle(t *testing.T) {
 	f := NewFile()
 	// Test set cell style on not exists worksheet
@@ -553,6 +841,126 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetIntersectionStyle(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetIntersectionStyle("Sheet1", []int{4, 2}, []int{2, 4}, styleID))
+	for row := 1; row <= 5; row++ {
+		for col := 1; col <= 5; col++ {
+			cell, err := CoordinatesToCellName(col, row)
+			assert.NoError(t, err)
+			style, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			if (row == 2 || row == 4) && (col == 2 || col == 4) {
+				assert.Equal(t, styleID, style, cell)
+				continue
+			}
+			assert.Zero(t, style, cell)
+		}
+	}
+	// Test set intersection style with empty rows or columns
+	assert.NoError(t, f.SetIntersectionStyle("Sheet1", nil, []int{1}, styleID))
+	assert.NoError(t, f.SetIntersectionStyle("Sheet1", []int{1}, nil, styleID))
+	// Test set intersection style with invalid row and column numbers
+	assert.EqualError(t, f.SetIntersectionStyle("Sheet1", []int{0}, []int{1}, styleID), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.SetIntersectionStyle("Sheet1", []int{TotalRows + 1}, []int{1}, styleID))
+	assert.Equal(t, ErrColumnNumber, f.SetIntersectionStyle("Sheet1", []int{1}, []int{MaxColumns + 1}, styleID))
+	// Test set intersection style with invalid style ID
+	assert.Equal(t, newInvalidStyleID(-1), f.SetIntersectionStyle("Sheet1", []int{1}, []int{1}, -1))
+	// Test set intersection style on not exists worksheet
+	assert.EqualError(t, f.SetIntersectionStyle("SheetN", []int{1}, []int{1}, styleID), "sheet SheetN does not exist")
+	// Test set intersection style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetIntersectionStyle("Sheet1", []int{1}, []int{1}, styleID), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetRangeUnlocked(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Protection: &Protection{Hidden: true, Locked: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", styleID))
+	assert.NoError(t, f.SetRangeUnlocked("Sheet1", "C3:B2"))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{Password: "password", SelectUnlockedCells: true}))
+	for _, cell := range []string{"B2", "C2", "B3", "C3"} {
+		idx, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(idx)
+		assert.NoError(t, err)
+		assert.NotNil(t, style.Protection, cell)
+		assert.False(t, style.Protection.Locked, cell)
+	}
+	// Test the other style attributes are preserved
+	idx, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(idx)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.True(t, style.Protection.Hidden)
+	// Test the adjacent cells are still locked
+	for _, cell := range []string{"A1", "D3", "B4"} {
+		idx, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(idx)
+		assert.NoError(t, err)
+		assert.True(t, style.Protection == nil || style.Protection.Locked, cell)
+	}
+	// Test unlock the cells which already unlocked
+	cellXfs := len(f.Styles.CellXfs.Xf)
+	assert.NoError(t, f.SetRangeUnlocked("Sheet1", "B2:C3"))
+	assert.Len(t, f.Styles.CellXfs.Xf, cellXfs)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRangeUnlocked.xlsx")))
+	// Test unlock cells with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetRangeUnlocked("Sheet1", "A1"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetRangeUnlocked("Sheet1", "A:B2"))
+	// Test unlock cells on not exists worksheet
+	assert.EqualError(t, f.SetRangeUnlocked("SheetN", "A1:B2"), "sheet SheetN does not exist")
+	// Test unlock cells with exceeds cell styles limit
+	f.Styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	assert.Equal(t, ErrCellStyles, f.SetRangeUnlocked("Sheet1", "E5:E6"))
+	// Test unlock cells with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRangeUnlocked("Sheet1", "A1:A2"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellBorderAndFill(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{
+		Border: []Border{{Type: "left", Color: "FF0000", Style: 5}},
+		Fill:   Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", styleID))
+	borders, err := f.GetCellBorder("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []Border{{Type: "left", Color: "FF0000", Style: 5}}, borders)
+	fill, err := f.GetCellFill("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}, fill)
+	// Test get border and fill of the cell without style
+	borders, err = f.GetCellBorder("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, borders)
+	fill, err = f.GetCellFill("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern"}, fill)
+	// Test get border and fill on not exists worksheet
+	_, err = f.GetCellBorder("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellFill("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get border and fill with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].S = 10
+	_, err = f.GetCellBorder("Sheet1", "B2")
+	assert.Equal(t, newInvalidStyleID(10), err)
+	_, err = f.GetCellFill("Sheet1", "B2")
+	assert.Equal(t, newInvalidStyleID(10), err)
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
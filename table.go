@@ -16,7 +16,9 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -201,6 +203,105 @@ func (f *File) DeleteTable(name string) error {
 	return newNoExistTableError(name)
 }
 
+// getTableColumn provides a function to get the table, the column in the
+// table and the table part path by given worksheet name, table name and
+// column name.
+func (f *File) getTableColumn(sheet, table, column string) (*xlsxTable, *xlsxTableColumn, string, error) {
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	for _, tbl := range tables {
+		if tbl.Name != table {
+			continue
+		}
+		content, _ := f.Pkg.Load(tbl.tableXML)
+		t := new(xlsxTable)
+		_ = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).Decode(t)
+		if t.TableColumns != nil {
+			for _, col := range t.TableColumns.TableColumn {
+				if col.Name == column {
+					return t, col, tbl.tableXML, err
+				}
+			}
+		}
+		return nil, nil, "", newNoExistTableColumnError(column)
+	}
+	return nil, nil, "", newNoExistTableError(table)
+}
+
+// SetTableColumnType provides the method to set the data type hint of the
+// table column by given worksheet name, table name, column name and data
+// type. The supported data types are "text", "number", "date" and "boolean".
+// The data type hint was stored in the excelize private extension of the table
+// column, which isn't defined in the ECMA-376 and will be ignored by the
+// spreadsheet applications, it helps downstream tools interpret the column
+// values without inference, set the data type with empty string to remove the
+// hint. For example, set the date type hint on the column "Date" of the table
+// "Table1" in the worksheet Sheet1:
+//
+//	err := f.SetTableColumnType("Sheet1", "Table1", "Date", "date")
+func (f *File) SetTableColumnType(sheet, table, column, dataType string) error {
+	if dataType != "" && inStrSlice([]string{"text", "number", "date", "boolean"}, dataType, true) == -1 {
+		return ErrTableColumnType
+	}
+	t, col, tableXML, err := f.getTableColumn(sheet, table, column)
+	if err != nil {
+		return err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if col.ExtLst != nil {
+		if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + col.ExtLst.Ext + "</extLst>")).
+			Decode(decodeExtLst); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	for i := 0; i < len(decodeExtLst.Ext); i++ {
+		if decodeExtLst.Ext[i].URI == extURIExcelizeTableColumn {
+			decodeExtLst.Ext = append(decodeExtLst.Ext[:i], decodeExtLst.Ext[i+1:]...)
+			i--
+		}
+	}
+	if dataType != "" {
+		dataTypeBytes, _ := xml.Marshal(&xlsxTableColumnDataType{XMLNSXLZ: nameSpaceExcelizeTableColumn, Val: dataType})
+		decodeExtLst.Ext = append(decodeExtLst.Ext, &xlsxExt{URI: extURIExcelizeTableColumn, Content: string(dataTypeBytes)})
+	}
+	col.ExtLst = nil
+	if len(decodeExtLst.Ext) > 0 {
+		extLstBytes, _ := xml.Marshal(decodeExtLst)
+		col.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	}
+	tableBytes, _ := xml.Marshal(t)
+	f.saveFileList(tableXML, tableBytes)
+	return err
+}
+
+// GetTableColumnType provides the method to get the data type hint of the
+// table column by given worksheet name, table name and column name. An empty
+// string will be returned if the column doesn't have a data type hint.
+func (f *File) GetTableColumnType(sheet, table, column string) (string, error) {
+	_, col, _, err := f.getTableColumn(sheet, table, column)
+	if err != nil || col.ExtLst == nil {
+		return "", err
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err = f.xmlNewDecoder(strings.NewReader("<extLst>" + col.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return "", err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == extURIExcelizeTableColumn {
+			dataType := new(decodeTableColumnDataType)
+			if err = f.xmlNewDecoder(strings.NewReader(ext.Content)).
+				Decode(dataType); err != nil && err != io.EOF {
+				return "", err
+			}
+			return dataType.Val, nil
+		}
+	}
+	return "", err
+}
+
 // countTables provides a function to get table files count storage in the
 // folder xl/tables.
 func (f *File) countTables() int {
@@ -546,6 +647,323 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	return nil
 }
 
+// GetAutoFilter provides the method to get the auto filter range reference and
+// the filter criteria of each column in a worksheet by given worksheet name.
+// The expressions of the filter criteria are using 'x' as the placeholder
+// variable, and an empty range reference will be returned if the worksheet
+// doesn't have an auto filter. For example, get the auto filter in Sheet1:
+//
+//	rangeRef, opts, err := f.GetAutoFilter("Sheet1")
+func (f *File) GetAutoFilter(sheet string) (string, []AutoFilterOptions, error) {
+	var opts []AutoFilterOptions
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return "", opts, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.AutoFilter == nil {
+		return "", opts, err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return ws.AutoFilter.Ref, opts, err
+	}
+	_ = sortCoordinates(coordinates)
+	for _, fc := range ws.AutoFilter.FilterColumn {
+		if exp := extractFilterExpression(fc); exp != "" {
+			col, _ := ColumnNumberToName(coordinates[0] + fc.ColID)
+			opts = append(opts, AutoFilterOptions{Column: col, Expression: exp})
+		}
+	}
+	ref, err := f.coordinatesToRangeRef(coordinates)
+	return ref, opts, err
+}
+
+// RemoveAutoFilter provides the method to remove the auto filter in a worksheet
+// by given worksheet name. This function will remove the hidden defined name
+// '_xlnm._FilterDatabase' of the worksheet, and make the rows hidden by the
+// auto filter visible. All hidden rows in the auto filter range will be
+// treated as hidden by the auto filter, and the hidden rows outside of the
+// range will not be changed. For example, remove the auto filter in Sheet1:
+//
+//	err := f.RemoveAutoFilter("Sheet1")
+func (f *File) RemoveAutoFilter(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	sheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	if wb.DefinedNames != nil {
+		definedNames := wb.DefinedNames.DefinedName[:0]
+		for _, definedName := range wb.DefinedNames.DefinedName {
+			localSheetID := 0
+			if definedName.LocalSheetID != nil {
+				localSheetID = *definedName.LocalSheetID
+			}
+			if definedName.Name == builtInDefinedNames[2] && localSheetID == sheetID && definedName.Hidden {
+				continue
+			}
+			definedNames = append(definedNames, definedName)
+		}
+		wb.DefinedNames.DefinedName = definedNames
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetPr != nil {
+		ws.SheetPr.FilterMode = false
+	}
+	if ws.AutoFilter == nil {
+		return err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	ws.AutoFilter = nil
+	for i := range ws.SheetData.Row {
+		if row := &ws.SheetData.Row[i]; row.R != nil && *row.R > coordinates[1] && *row.R <= coordinates[3] {
+			row.Hidden = false
+		}
+	}
+	return err
+}
+
+// GetFilteredRows provides a function to get the row numbers which are visible
+// under the auto filter of the worksheet by given worksheet name. The filter
+// criteria of each column will be evaluated against the cell values in the
+// auto filter range, the header row of the range will not be included in the
+// result. The values list, blanks, custom filters and top or bottom items
+// criteria are supported, and the hidden state of the rows stored in the
+// worksheet will be used for the other kinds of criteria, such as the color,
+// icon and dynamic filters. This function returns nil if the worksheet
+// doesn't have an auto filter. For example, get visible rows under the auto
+// filter in Sheet1:
+//
+//	rows, err := f.GetFilteredRows("Sheet1")
+func (f *File) GetFilteredRows(sheet string) ([]int, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	if ws.AutoFilter == nil {
+		ws.mu.Unlock()
+		return nil, err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		ws.mu.Unlock()
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	filterColumns, hiddenRows := ws.AutoFilter.FilterColumn, map[int]bool{}
+	for _, row := range ws.SheetData.Row {
+		if row.R != nil && row.Hidden {
+			hiddenRows[*row.R] = true
+		}
+	}
+	ws.mu.Unlock()
+	visible := make([]bool, coordinates[3]-coordinates[1])
+	for i := range visible {
+		visible[i] = true
+	}
+	for _, fc := range filterColumns {
+		var values, raws []string
+		for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+			cell, err := CoordinatesToCellName(coordinates[0]+fc.ColID, row)
+			if err != nil {
+				return nil, err
+			}
+			value, err := f.GetCellValue(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			raw, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return nil, err
+			}
+			values, raws = append(values, value), append(raws, raw)
+		}
+		matches, ok := evalFilterColumn(fc, values, raws)
+		for i := range visible {
+			if ok {
+				visible[i] = visible[i] && matches[i]
+				continue
+			}
+			visible[i] = visible[i] && !hiddenRows[coordinates[1]+1+i]
+		}
+	}
+	rows := []int{}
+	for i, ok := range visible {
+		if ok {
+			rows = append(rows, coordinates[1]+1+i)
+		}
+	}
+	return rows, err
+}
+
+// evalFilterColumn evaluates the filter criteria of the filter column against
+// the formatted and raw cell values of the column, the second return value
+// will be false if the filter criteria is not supported.
+func evalFilterColumn(fc *xlsxFilterColumn, values, raws []string) ([]bool, bool) {
+	matches := make([]bool, len(values))
+	switch {
+	case fc.Filters != nil:
+		if len(fc.Filters.DateGroupItem) > 0 {
+			return nil, false
+		}
+		for i, value := range values {
+			matches[i] = value == "" && fc.Filters.Blank
+			for _, filter := range fc.Filters.Filter {
+				matches[i] = matches[i] || strings.EqualFold(filter.Val, value) ||
+					(value == "" && strings.EqualFold(filter.Val, "blanks"))
+			}
+		}
+	case fc.CustomFilters != nil:
+		for i := range values {
+			matches[i] = fc.CustomFilters.And
+			for _, filter := range fc.CustomFilters.CustomFilter {
+				if fc.CustomFilters.And {
+					matches[i] = matches[i] && evalCustomFilter(filter, values[i], raws[i])
+					continue
+				}
+				matches[i] = matches[i] || evalCustomFilter(filter, values[i], raws[i])
+			}
+		}
+	case fc.Top10 != nil:
+		var nums []float64
+		for _, raw := range raws {
+			if num, err := strconv.ParseFloat(raw, 64); err == nil {
+				nums = append(nums, num)
+			}
+		}
+		if len(nums) == 0 {
+			return matches, true
+		}
+		sort.Float64s(nums)
+		count := int(fc.Top10.Val)
+		if fc.Top10.Percent {
+			count = int(float64(len(nums)) * fc.Top10.Val / 100)
+		}
+		count = int(math.Min(math.Max(float64(count), 1), float64(len(nums))))
+		threshold := nums[count-1]
+		if fc.Top10.Top {
+			threshold = nums[len(nums)-count]
+		}
+		for i, raw := range raws {
+			if num, err := strconv.ParseFloat(raw, 64); err == nil {
+				matches[i] = (fc.Top10.Top && num >= threshold) || (!fc.Top10.Top && num <= threshold)
+			}
+		}
+	case fc.ColorFilter != nil, fc.DynamicFilter != nil, fc.IconFilter != nil:
+		return nil, false
+	default:
+		for i := range matches {
+			matches[i] = true
+		}
+	}
+	return matches, true
+}
+
+// evalCustomFilter evaluates the custom filter criteria against the formatted
+// and raw cell value, the numbers will be compared by their numeric values,
+// and the text will be compared without case-sensitive, the wildcard
+// characters '*' and '?' are supported on checking equality. The blank cells
+// only match the not equal criteria except the non-blanks criteria.
+func evalCustomFilter(filter *xlsxCustomFilter, value, raw string) bool {
+	if raw == "" {
+		return filter.Operator == "notEqual" && filter.Val != " "
+	}
+	var result int
+	lhs, lhsErr := strconv.ParseFloat(raw, 64)
+	rhs, rhsErr := strconv.ParseFloat(filter.Val, 64)
+	switch {
+	case lhsErr == nil && rhsErr == nil:
+		if result = 0; lhs < rhs {
+			result = -1
+		} else if lhs > rhs {
+			result = 1
+		}
+	case filter.Operator == "" || filter.Operator == "equal" || filter.Operator == "notEqual":
+		exp, _ := matchPatternToRegExp(filter.Val, false)
+		if re, err := regexp.Compile("(?i)" + exp + "$"); err == nil && re.MatchString(value) || strings.EqualFold(value, filter.Val) {
+			return filter.Operator != "notEqual"
+		}
+		return filter.Operator == "notEqual"
+	default:
+		result = strings.Compare(strings.ToLower(value), strings.ToLower(filter.Val))
+	}
+	switch filter.Operator {
+	case "greaterThan":
+		return result > 0
+	case "greaterThanOrEqual":
+		return result >= 0
+	case "lessThan":
+		return result < 0
+	case "lessThanOrEqual":
+		return result <= 0
+	case "notEqual":
+		return result != 0
+	}
+	return result == 0
+}
+
+// extractFilterExpression provides a function to convert the filter criteria
+// of the filter column to the auto filter expression.
+func extractFilterExpression(fc *xlsxFilterColumn) string {
+	var exps []string
+	if fc.Filters != nil {
+		if fc.Filters.Blank {
+			exps = append(exps, "x == Blanks")
+		}
+		for _, filter := range fc.Filters.Filter {
+			exps = append(exps, "x == "+filter.Val)
+		}
+		return strings.Join(exps, " or ")
+	}
+	if fc.CustomFilters == nil {
+		return ""
+	}
+	operators := map[string]string{
+		"":                   "==",
+		"equal":              "==",
+		"greaterThan":        ">",
+		"greaterThanOrEqual": ">=",
+		"lessThan":           "<",
+		"lessThanOrEqual":    "<=",
+		"notEqual":           "!=",
+	}
+	for _, filter := range fc.CustomFilters.CustomFilter {
+		if filter.Operator == "notEqual" && filter.Val == " " {
+			exps = append(exps, "x == NonBlanks")
+			continue
+		}
+		exps = append(exps, fmt.Sprintf("x %s %s", operators[filter.Operator], filter.Val))
+	}
+	if fc.CustomFilters.And {
+		return strings.Join(exps, " and ")
+	}
+	return strings.Join(exps, " or ")
+}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
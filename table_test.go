@@ -142,6 +142,60 @@ func TestSetTableColumns(t *testing.T) {
 	assert.Equal(t, newCoordinatesToCellNameError(1, 0), f.setTableColumns("Sheet1", true, 1, 0, 1, nil))
 }
 
+func TestTableColumnType(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Date", "Amount"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B5", Name: "Table1"}))
+	// Test get table column data type without hint
+	dataType, err := f.GetTableColumnType("Sheet1", "Table1", "Date")
+	assert.NoError(t, err)
+	assert.Empty(t, dataType)
+	// Test set and get table column data type
+	assert.NoError(t, f.SetTableColumnType("Sheet1", "Table1", "Date", "date"))
+	assert.NoError(t, f.SetTableColumnType("Sheet1", "Table1", "Amount", "text"))
+	assert.NoError(t, f.SetTableColumnType("Sheet1", "Table1", "Amount", "number"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTableColumnType.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestTableColumnType.xlsx"))
+	assert.NoError(t, err)
+	for column, expected := range map[string]string{"Date": "date", "Amount": "number"} {
+		dataType, err = f.GetTableColumnType("Sheet1", "Table1", column)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dataType)
+	}
+	// Test the data type hint was kept on inserting rows
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	dataType, err = f.GetTableColumnType("Sheet1", "Table1", "Date")
+	assert.NoError(t, err)
+	assert.Equal(t, "date", dataType)
+	// Test remove table column data type
+	assert.NoError(t, f.SetTableColumnType("Sheet1", "Table1", "Date", ""))
+	dataType, err = f.GetTableColumnType("Sheet1", "Table1", "Date")
+	assert.NoError(t, err)
+	assert.Empty(t, dataType)
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 1, strings.Count(string(content.([]byte)), "uri=\""+extURIExcelizeTableColumn+"\""))
+	// Test set table column data type with invalid data type
+	assert.Equal(t, ErrTableColumnType, f.SetTableColumnType("Sheet1", "Table1", "Date", "time"))
+	// Test set and get table column data type on not exists table and column
+	assert.Equal(t, newNoExistTableError("Table2"), f.SetTableColumnType("Sheet1", "Table2", "Date", "date"))
+	assert.Equal(t, newNoExistTableColumnError("Price"), f.SetTableColumnType("Sheet1", "Table1", "Price", "date"))
+	_, err = f.GetTableColumnType("Sheet1", "Table2", "Date")
+	assert.Equal(t, newNoExistTableError("Table2"), err)
+	// Test set and get table column data type on not exists worksheet
+	assert.EqualError(t, f.SetTableColumnType("SheetN", "Table1", "Date", "date"), "sheet SheetN does not exist")
+	_, err = f.GetTableColumnType("SheetN", "Table1", "Date")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set and get table column data type with unsupported charset
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTableColumnType("Sheet1", "Table1", "Amount")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetTableColumnType("Sheet1", "Table1", "Amount", "date"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAutoFilter(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilter%d.xlsx")
 	f, err := prepareTestBook1()
@@ -215,6 +269,195 @@ func TestAutoFilterError(t *testing.T) {
 	}}))
 }
 
+func TestGetAutoFilter(t *testing.T) {
+	f := NewFile()
+	// Test get auto filter on worksheet without auto filter
+	rangeRef, opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rangeRef)
+	assert.Empty(t, opts)
+	for _, c := range []struct {
+		opts     []AutoFilterOptions
+		expected []AutoFilterOptions
+	}{
+		{opts: []AutoFilterOptions{}},
+		{opts: []AutoFilterOptions{{Column: "B", Expression: "x > 2000"}}},
+		{opts: []AutoFilterOptions{{Column: "B", Expression: "x >= 2000 and x < 5000"}, {Column: "D", Expression: "x <= 10 or x != 20"}}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x == 1 or x == 2"}}},
+		{opts: []AutoFilterOptions{{Column: "C", Expression: "x == b*"}}},
+		{
+			opts:     []AutoFilterOptions{{Column: "B", Expression: "x == Blanks"}, {Column: "C", Expression: "x != blanks"}},
+			expected: []AutoFilterOptions{{Column: "B", Expression: "x == blanks"}, {Column: "C", Expression: "x == NonBlanks"}},
+		},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "D4:B1", c.opts))
+		rangeRef, opts, err := f.GetAutoFilter("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, "B1:D4", rangeRef)
+		if c.expected == nil && len(c.opts) > 0 {
+			c.expected = c.opts
+		}
+		assert.Equal(t, c.expected, opts)
+	}
+	// Test get auto filter with the blank filter and unsupported filter
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{
+		{ColID: 0, Filters: &xlsxFilters{Blank: true, Filter: []*xlsxFilter{{Val: "1"}}}},
+		{ColID: 1, Top10: &xlsxTop10{Val: 10}},
+	}
+	_, opts, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AutoFilterOptions{{Column: "B", Expression: "x == Blanks or x == 1"}}, opts)
+	// Test get auto filter with invalid range reference
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A:B1"
+	_, _, err = f.GetAutoFilter("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get auto filter on not exists worksheet
+	_, _, err = f.GetAutoFilter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get auto filter with invalid sheet name
+	_, _, err = f.GetAutoFilter("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+}
+
+func TestRemoveAutoFilter(t *testing.T) {
+	f := NewFile()
+	// Test remove auto filter on worksheet without auto filter
+	assert.NoError(t, f.RemoveAutoFilter("Sheet1"))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		assert.NoError(t, f.AutoFilter(sheet, "A1:B5", []AutoFilterOptions{{Column: "A", Expression: "x > 2"}}))
+	}
+	for row := 2; row <= 6; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row-1))
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, row > 3))
+	}
+	assert.NoError(t, f.SetRowVisible("Sheet1", 6, false))
+	assert.NoError(t, f.RemoveAutoFilter("Sheet1"))
+	rangeRef, opts, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rangeRef)
+	assert.Empty(t, opts)
+	// Test the hidden rows in the auto filter range will be visible, and the
+	// hidden rows outside of the auto filter range will not be changed
+	for row, expected := range map[int]bool{2: true, 3: true, 4: true, 5: true, 6: false} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, row)
+	}
+	// Test the defined name of the auto filter for the other worksheet will be kept
+	assert.Equal(t, []DefinedName{{Name: builtInDefinedNames[2], RefersTo: "'Sheet2'!$A$1:$B$5", Scope: "Sheet2", Hidden: true}}, f.GetDefinedName())
+	rangeRef, _, err = f.GetAutoFilter("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", rangeRef)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveAutoFilter.xlsx")))
+	// Test remove auto filter with invalid range reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A:B1"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RemoveAutoFilter("Sheet2"))
+	// Test remove auto filter on not exists worksheet
+	assert.EqualError(t, f.RemoveAutoFilter("SheetN"), "sheet SheetN does not exist")
+	// Test remove auto filter with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveAutoFilter("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetFilteredRows(t *testing.T) {
+	f := NewFile()
+	for row, data := range [][]interface{}{
+		{"Name", "Score"},
+		{"Apple", 80},
+		{"Banana", 45},
+		{"Cherry", 92},
+		{"apricot", nil},
+		{"Grape", 51},
+		{"Avocado", 30},
+	} {
+		cell, err := CoordinatesToCellName(1, row+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &data))
+	}
+	// Test get filtered rows without auto filter
+	rows, err := f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, rows)
+	for _, c := range []struct {
+		opts     []AutoFilterOptions
+		expected []int
+	}{
+		{nil, []int{2, 3, 4, 5, 6, 7}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x > 50"}}, []int{2, 4, 6}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x >= 45 and x <= 80"}}, []int{2, 3, 6}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x < 40 or x > 90"}}, []int{4, 7}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == 45 or x == 92"}}, []int{3, 4}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x != 80"}}, []int{3, 4, 5, 6, 7}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == Blanks"}}, []int{5}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == NonBlanks"}}, []int{2, 3, 4, 6, 7}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == a*"}}, []int{2, 5, 7}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x != *a*"}}, []int{4}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x > c"}}, []int{4, 6}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == a*"}, {Column: "B", Expression: "x > 50"}}, []int{2}},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:B7", c.opts))
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows, c.opts)
+	}
+	// Test get filtered rows with top and bottom items criteria
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, c := range []struct {
+		top10    *xlsxTop10
+		expected []int
+	}{
+		{&xlsxTop10{Top: true, Val: 2}, []int{2, 4}},
+		{&xlsxTop10{Top: false, Val: 2}, []int{3, 7}},
+		{&xlsxTop10{Top: true, Val: 40, Percent: true}, []int{2, 4}},
+		{&xlsxTop10{Top: true, Val: 0}, []int{4}},
+	} {
+		ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{{ColID: 1, Top10: c.top10}}
+		rows, err := f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows)
+	}
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{{ColID: 0, Top10: &xlsxTop10{Top: true, Val: 2}}}
+	rows, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test get filtered rows with unsupported criteria by the hidden rows
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	for _, fc := range []*xlsxFilterColumn{
+		{ColID: 1, ColorFilter: &xlsxColorFilter{CellColor: true}},
+		{ColID: 1, Filters: &xlsxFilters{DateGroupItem: []*xlsxDateGroupItem{{Year: 2024, DateTimeGrouping: "year"}}}},
+	} {
+		ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{fc}
+		rows, err = f.GetFilteredRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{2, 4, 5, 6, 7}, rows)
+	}
+	// Test get filtered rows with invalid auto filter range reference
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A:B7"
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A1:B7"
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{{ColID: -1}}
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 2), err)
+	// Test get filtered rows on not exists worksheet
+	_, err = f.GetFilteredRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get filtered rows with unsupported charset shared strings table
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{{ColID: 0}}
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestParseFilterTokens(t *testing.T) {
 	f := NewFile()
 	// Test with unknown operator
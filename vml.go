@@ -17,6 +17,8 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -36,6 +38,15 @@ const (
 	FormControlScrollBar
 )
 
+var (
+	// vmlVisibleExp matches the visible element in the client data of the VML
+	// shape.
+	vmlVisibleExp = regexp.MustCompile(`<(\w+:)?Visible\s*(/>|>\s*</(\w+:)?Visible>)`)
+	// vmlClientDataEndExp matches the end tag of the client data in the VML
+	// shape.
+	vmlClientDataEndExp = regexp.MustCompile(`</(\w+:)?ClientData>`)
+)
+
 // GetComments retrieves all comments in a worksheet by given worksheet name.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
 	var comments []Comment
@@ -43,18 +54,21 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	if !ok {
 		return comments, ErrSheetNotExist{sheet}
 	}
-	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
-	if !strings.HasPrefix(commentsXML, "/") {
-		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
-	}
-	commentsXML = strings.TrimPrefix(commentsXML, "/")
-	cmts, err := f.commentsReader(commentsXML)
+	cmts, err := f.commentsReader(f.getSheetCommentsPath(sheetXMLPath))
 	if err != nil {
 		return comments, err
 	}
 	if cmts != nil {
+		visible, err := f.getCommentsVisibility(sheet)
+		if err != nil {
+			return comments, err
+		}
+		resolved, err := f.getCommentsResolved(sheetXMLPath)
+		if err != nil {
+			return comments, err
+		}
 		for _, cmt := range cmts.CommentList.Comment {
-			comment := Comment{}
+			comment := Comment{Visible: visible[cmt.Ref], Resolved: resolved[cmt.Ref]}
 			if cmt.AuthorID < len(cmts.Authors.Author) {
 				comment.Author = cmts.Authors.Author[cmt.AuthorID]
 			}
@@ -72,12 +86,171 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 					comment.Paragraph = append(comment.Paragraph, run)
 				}
 			}
+			if comment.Text != "" {
+				comment.Paragraphs = splitCommentParagraphs(append([]RichTextRun{{Text: comment.Text}}, comment.Paragraph...))
+			} else if len(comment.Paragraph) > 0 {
+				comment.Paragraphs = splitCommentParagraphs(comment.Paragraph)
+			}
 			comments = append(comments, comment)
 		}
 	}
 	return comments, nil
 }
 
+// GetCommentedCells provides a function to get the cell references which have
+// comments by given worksheet name, the comment text will not be parsed. The
+// threaded comments have legacy comments as placeholder, so those cells will
+// also be returned. For example, get the commented cells on Sheet1:
+//
+//	cells, err := f.GetCommentedCells("Sheet1")
+func (f *File) GetCommentedCells(sheet string) ([]string, error) {
+	var cells []string
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return cells, ErrSheetNotExist{sheet}
+	}
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
+	if cmts := f.Comments[commentsXML]; cmts != nil {
+		for _, cmt := range cmts.CommentList.Comment {
+			cells = append(cells, cmt.Ref)
+		}
+		return cells, nil
+	}
+	content, ok := f.Pkg.Load(commentsXML)
+	if !ok || content == nil {
+		return cells, nil
+	}
+	var refs decodeCommentRefs
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(&refs); err != nil && err != io.EOF {
+		return cells, err
+	}
+	for _, cmt := range refs.CommentList.Comment {
+		cells = append(cells, cmt.Ref)
+	}
+	return cells, nil
+}
+
+// getSheetCommentsPath provides a function to get the comments part path of
+// the worksheet by given worksheet XML path.
+func (f *File) getSheetCommentsPath(sheetXMLPath string) string {
+	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
+	if !strings.HasPrefix(commentsXML, "/") {
+		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
+	}
+	return strings.TrimPrefix(commentsXML, "/")
+}
+
+// splitCommentParagraphs provides a function to split the rich text runs of
+// the comment into paragraphs by the line breaks, the blank lines will be
+// returned as the empty paragraphs.
+func splitCommentParagraphs(runs []RichTextRun) [][]RichTextRun {
+	paragraphs := [][]RichTextRun{nil}
+	for _, run := range runs {
+		for i, text := range strings.Split(run.Text, "\n") {
+			if i > 0 {
+				paragraphs = append(paragraphs, nil)
+			}
+			if text != "" {
+				paragraphs[len(paragraphs)-1] = append(paragraphs[len(paragraphs)-1], RichTextRun{Text: text, Font: run.Font})
+			}
+		}
+	}
+	return paragraphs
+}
+
+// joinCommentParagraphs provides a function to join the paragraphs of the
+// comment into rich text runs with line breaks.
+func joinCommentParagraphs(paragraphs [][]RichTextRun) []RichTextRun {
+	var runs []RichTextRun
+	for i, paragraph := range paragraphs {
+		runs = append(runs, paragraph...)
+		if i == len(paragraphs)-1 {
+			break
+		}
+		if len(paragraph) == 0 {
+			runs = append(runs, RichTextRun{Text: "\n"})
+			continue
+		}
+		runs[len(runs)-1].Text += "\n"
+	}
+	return runs
+}
+
+// getCommentsVisibility provides a function to get the visibility of the
+// comments in the worksheet by given worksheet name, it returns a map keyed by
+// the cell reference of the always visible comments.
+func (f *File) getCommentsVisibility(sheet string) (map[string]bool, error) {
+	visible := map[string]bool{}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return visible, err
+	}
+	vml, err := f.getVMLDrawing(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+	if err != nil {
+		return visible, err
+	}
+	for _, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil ||
+			shapeVal.ClientData.ObjectType != "Note" || shapeVal.ClientData.Column == nil || shapeVal.ClientData.Row == nil {
+			continue
+		}
+		cell, err := CoordinatesToCellName(*shapeVal.ClientData.Column+1, *shapeVal.ClientData.Row+1)
+		if err != nil {
+			continue
+		}
+		visible[cell] = shapeVal.ClientData.Visible != nil
+	}
+	return visible, nil
+}
+
+// SetCommentVisible provides the method to set the comment in a worksheet to
+// be always visible or only shown when hovering over the cell by given
+// worksheet name, cell reference and visibility. For example, make the comment
+// in Sheet1!A5 always visible:
+//
+//	err := f.SetCommentVisible("Sheet1", "A5", true)
+func (f *File) SetCommentVisible(sheet, cell string, visible bool) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.LegacyDrawing == nil {
+		return newNoExistCommentError(cell)
+	}
+	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+	vml, err := f.getVMLDrawing(sheetRelationshipsDrawingVML)
+	if err != nil {
+		return err
+	}
+	for i, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err != nil ||
+			shapeVal.ClientData.ObjectType != "Note" || shapeVal.ClientData.Column == nil || shapeVal.ClientData.Row == nil ||
+			*shapeVal.ClientData.Column != col-1 || *shapeVal.ClientData.Row != row-1 {
+			continue
+		}
+		state := map[bool]string{true: "visibility:visible", false: "visibility:hidden"}
+		if strings.Contains(sp.Style, state[!visible]) {
+			vml.Shape[i].Style = strings.Replace(sp.Style, state[!visible], state[visible], 1)
+		} else if !strings.Contains(sp.Style, state[visible]) {
+			vml.Shape[i].Style = strings.TrimSuffix(sp.Style, ";") + ";" + state[visible]
+		}
+		vml.Shape[i].Val = vmlVisibleExp.ReplaceAllString(sp.Val, "")
+		if visible {
+			vml.Shape[i].Val = vmlClientDataEndExp.ReplaceAllString(vml.Shape[i].Val, "<${1}Visible></${1}Visible></${1}ClientData>")
+		}
+		f.VMLDrawing[strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")] = vml
+		return nil
+	}
+	return newNoExistCommentError(cell)
+}
+
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
@@ -94,6 +267,105 @@ func (f *File) getSheetComments(sheetFile string) string {
 	return ""
 }
 
+// getSheetThreadedCommentsPath provides a function to get the threaded
+// comments part path of the worksheet by given worksheet XML path, it returns
+// an empty string if the worksheet doesn't have threaded comments.
+func (f *File) getSheetThreadedCommentsPath(sheetXMLPath string) string {
+	var target string
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for _, v := range rels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				target = v.Target
+				break
+			}
+		}
+	}
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of the threaded comments part by given
+// path, it returns nil if the part doesn't exist.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, ok := f.Pkg.Load(path)
+	if !ok || content == nil {
+		return nil, nil
+	}
+	threadedComments := new(xlsxThreadedComments)
+	if err := f.xmlNewDecoder(bytes.NewReader(content.([]byte))).
+		Decode(threadedComments); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return threadedComments, nil
+}
+
+// getCommentsResolved provides a function to get the resolved state of the
+// threaded comments in the worksheet by given worksheet XML path, it returns a
+// map keyed by the cell reference of the resolved threads.
+func (f *File) getCommentsResolved(sheetXMLPath string) (map[string]bool, error) {
+	resolved := map[string]bool{}
+	threadedComments, err := f.threadedCommentsReader(f.getSheetThreadedCommentsPath(sheetXMLPath))
+	if err != nil || threadedComments == nil {
+		return resolved, err
+	}
+	for _, cmt := range threadedComments.ThreadedComment {
+		if cmt.ParentID == "" && cmt.Done != nil && *cmt.Done {
+			resolved[cmt.Ref] = true
+		}
+	}
+	return resolved, nil
+}
+
+// ResolveComment provides the method to mark the threaded comment thread in
+// the cell as resolved or reopen it by given worksheet name, cell reference
+// and resolved state. The resolved state is stored in the threaded comments
+// part of the worksheet, so the cell must already have a threaded comment
+// created by the spreadsheet application. For example, mark the comment in
+// Sheet1!A1 as resolved:
+//
+//	err := f.ResolveComment("Sheet1", "A1", true)
+func (f *File) ResolveComment(sheet, cell string, resolved bool) error {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return err
+	}
+	path := f.getSheetThreadedCommentsPath(sheetXMLPath)
+	threadedComments, err := f.threadedCommentsReader(path)
+	if err != nil {
+		return err
+	}
+	if threadedComments == nil {
+		return newNoExistCommentError(cell)
+	}
+	for i, cmt := range threadedComments.ThreadedComment {
+		if cmt.ParentID == "" && strings.EqualFold(cmt.Ref, cell) {
+			threadedComments.ThreadedComment[i].Done = boolPtr(resolved)
+			output, err := xml.Marshal(threadedComments)
+			if err != nil {
+				return err
+			}
+			f.saveFileList(path, output)
+			return nil
+		}
+	}
+	return newNoExistCommentError(cell)
+}
+
 // AddComment provides the method to add comments in a sheet by giving the
 // worksheet name, cell reference, and format set (such as author and text).
 // Note that the maximum author name length is 255 and the max text length is
@@ -110,9 +382,106 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	    Height: 40,
 //	    Width:  180,
 //	})
+//
+// Use the Paragraphs field to add a comment with multiple paragraphs, and
+// each paragraph consists of rich text runs, the Paragraph field will be
+// ignored in this case. For example, add a comment with a bold heading
+// paragraph and a plain body paragraph in Sheet1!B3:
+//
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:   "B3",
+//	    Author: "Excelize",
+//	    Paragraphs: [][]excelize.RichTextRun{
+//	        {{Text: "Heading", Font: &excelize.Font{Bold: true}}},
+//	        {{Text: "This is the body of the comment."}},
+//	    },
+//	})
+//
+// Set the Visible field to true to make the comment always visible, otherwise
+// the comment will only be shown when hovering over the cell.
+//
+// The Cell could also be a range reference, the comment will be attached to
+// the top-left cell of the range, and the comments box will cover the range.
+// The Width and Height will be ignored in this case. For example, add a comment
+// which box covers the range Sheet1!B2:D4:
+//
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:   "B2:D4",
+//	    Author: "Excelize",
+//	    Text:   "This is a comment.",
+//	})
 func (f *File) AddComment(sheet string, opts Comment) error {
-	return f.addVMLObject(vmlOptions{
-		sheet: sheet, Comment: opts,
+	vmlOpts, err := f.prepareCommentOptions(sheet, opts)
+	if err != nil {
+		return err
+	}
+	return f.addVMLObject(vmlOpts)
+}
+
+// AddComments provides the method to add multiple comments in a sheet by
+// giving the worksheet name and a map of cell reference to comment options.
+// The comments and VML drawing parts will be built once for all comments,
+// which is more efficient than calling AddComment repeatedly when adding
+// lots of comments. The Cell field in the comment options will be replaced
+// by the map key. For example, add comments in Sheet1!A1 and Sheet1!B2:
+//
+//	err := f.AddComments("Sheet1", map[string]excelize.Comment{
+//	    "A1": {Author: "Excelize", Text: "This is a comment."},
+//	    "B2": {Author: "Excelize", Text: "This is another comment."},
+//	})
+func (f *File) AddComments(sheet string, comments map[string]Comment) error {
+	if _, err := f.workSheetReader(sheet); err != nil || len(comments) == 0 {
+		return err
+	}
+	cells := make([]string, 0, len(comments))
+	for cell := range comments {
+		cells = append(cells, cell)
+	}
+	sort.Strings(cells)
+	objects := make([]vmlOptions, 0, len(cells))
+	for _, cell := range cells {
+		opts := comments[cell]
+		opts.Cell = cell
+		vmlOpts, err := f.prepareCommentOptions(sheet, opts)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, vmlOpts)
+	}
+	return f.addVMLObjects(sheet, false, objects)
+}
+
+// prepareCommentOptions provides a function to create the VML options of the
+// comment by given worksheet name and comment options.
+func (f *File) prepareCommentOptions(sheet string, opts Comment) (vmlOptions, error) {
+	var rangeRef []int
+	if strings.Contains(opts.Cell, ":") {
+		coordinates, err := rangeRefToCoordinates(opts.Cell)
+		if err != nil {
+			return vmlOptions{}, err
+		}
+		_ = sortCoordinates(coordinates)
+		if _, err = f.workSheetReader(sheet); err != nil {
+			return vmlOptions{}, err
+		}
+		opts.Cell, _ = CoordinatesToCellName(coordinates[0], coordinates[1])
+		opts.Width, opts.Height = 0, 0
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			opts.Width += uint(f.getColWidth(sheet, col))
+		}
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			opts.Height += uint(f.getRowHeight(sheet, row))
+		}
+		rangeRef = coordinates
+	}
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return vmlOptions{}, err
+	}
+	if len(opts.Paragraphs) > 0 {
+		opts.Paragraph = joinCommentParagraphs(opts.Paragraphs)
+	}
+	return vmlOptions{
+		sheet: sheet, Comment: opts, rangeRef: rangeRef,
 		FormControl: FormControl{
 			Cell:      opts.Cell,
 			Type:      FormControlNote,
@@ -121,7 +490,7 @@ func (f *File) AddComment(sheet string, opts Comment) error {
 			Width:     opts.Width,
 			Height:    opts.Height,
 		},
-	})
+	}, nil
 }
 
 // DeleteComment provides the method to delete comment in a worksheet by given
@@ -138,11 +507,7 @@ func (f *File) DeleteComment(sheet, cell string) error {
 		return err
 	}
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
-	commentsXML := f.getSheetComments(filepath.Base(sheetXMLPath))
-	if !strings.HasPrefix(commentsXML, "/") {
-		commentsXML = "xl" + strings.TrimPrefix(commentsXML, "..")
-	}
-	commentsXML = strings.TrimPrefix(commentsXML, "/")
+	commentsXML := f.getSheetCommentsPath(sheetXMLPath)
 	cmts, err := f.commentsReader(commentsXML)
 	if err != nil {
 		return err
@@ -176,6 +541,39 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 	if err != nil {
 		return err
 	}
+	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+	vml, err := f.getVMLDrawing(sheetRelationshipsDrawingVML)
+	if err != nil {
+		return err
+	}
+	cond := func(objectType string) bool {
+		if isComment {
+			return objectType == "Note"
+		}
+		return objectType != "Note"
+	}
+	for i, sp := range vml.Shape {
+		var shapeVal decodeShapeVal
+		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
+			cond(shapeVal.ClientData.ObjectType) && shapeVal.ClientData.Anchor != "" {
+			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
+			if err != nil {
+				return err
+			}
+			if leftCol == col-1 && topRow == row-1 {
+				vml.Shape = append(vml.Shape[:i], vml.Shape[i+1:]...)
+				break
+			}
+		}
+	}
+	f.VMLDrawing[drawingVML] = vml
+	return err
+}
+
+// getVMLDrawing provides a function to get the VML drawing structure by given
+// relationships target of the VML drawing part, the existing VML shapes will be
+// loaded if the part has not been parsed.
+func (f *File) getVMLDrawing(sheetRelationshipsDrawingVML string) (*vmlDrawing, error) {
 	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
 	vml := f.VMLDrawing[drawingVML]
@@ -196,7 +594,7 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if d != nil {
 			vml.ShapeType.ID = d.ShapeType.ID
@@ -220,33 +618,12 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 			}
 		}
 	}
-	cond := func(objectType string) bool {
-		if isComment {
-			return objectType == "Note"
-		}
-		return objectType != "Note"
-	}
-	for i, sp := range vml.Shape {
-		var shapeVal decodeShapeVal
-		if err = xml.Unmarshal([]byte(fmt.Sprintf("<shape>%s</shape>", sp.Val)), &shapeVal); err == nil &&
-			cond(shapeVal.ClientData.ObjectType) && shapeVal.ClientData.Anchor != "" {
-			leftCol, topRow, err := extractAnchorCell(shapeVal.ClientData.Anchor)
-			if err != nil {
-				return err
-			}
-			if leftCol == col-1 && topRow == row-1 {
-				vml.Shape = append(vml.Shape[:i], vml.Shape[i+1:]...)
-				break
-			}
-		}
-	}
-	f.VMLDrawing[drawingVML] = vml
-	return err
+	return vml, nil
 }
 
 // addComment provides a function to create chart as xl/comments%d.xml by
-// given cell and format sets.
-func (f *File) addComment(commentsXML string, opts vmlOptions) error {
+// given cell, default font name and format sets.
+func (f *File) addComment(commentsXML, defaultFont string, opts vmlOptions) error {
 	if opts.Author == "" {
 		opts.Author = "Author"
 	}
@@ -265,10 +642,6 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		cmts.Authors.Author = append(cmts.Authors.Author, opts.Author)
 		authorID = len(cmts.Authors.Author) - 1
 	}
-	defaultFont, err := f.GetDefaultFont()
-	if err != nil {
-		return err
-	}
 	chars, cmt := 0, xlsxComment{
 		Ref:      opts.Comment.Cell,
 		AuthorID: authorID,
@@ -507,42 +880,60 @@ func (f *File) vmlDrawingWriter() {
 // addVMLObject provides a function to create VML drawing parts and
 // relationships for comments and form controls.
 func (f *File) addVMLObject(opts vmlOptions) error {
+	return f.addVMLObjects(opts.sheet, opts.formCtrl, []vmlOptions{opts})
+}
+
+// addVMLObjects provides a function to create VML drawing parts and
+// relationships for multiple comments or form controls in the worksheet.
+func (f *File) addVMLObjects(sheet string, formCtrl bool, objects []vmlOptions) error {
 	// Read sheet data
-	ws, err := f.workSheetReader(opts.sheet)
+	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	vmlID := f.countComments() + 1
-	if opts.formCtrl {
-		if opts.Type > FormControlScrollBar {
-			return ErrParameterInvalid
+	if formCtrl {
+		for _, opts := range objects {
+			if opts.Type > FormControlScrollBar {
+				return ErrParameterInvalid
+			}
 		}
 		vmlID = f.countVMLDrawing() + 1
 	}
 	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
 	sheetRelationshipsDrawingVML := "../drawings/vmlDrawing" + strconv.Itoa(vmlID) + ".vml"
-	sheetXMLPath, _ := f.getSheetXMLPath(opts.sheet)
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
 	if ws.LegacyDrawing != nil {
 		// The worksheet already has a VML relationships, use the relationships drawing ../drawings/vmlDrawing%d.vml.
-		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(opts.sheet, ws.LegacyDrawing.RID)
+		sheetRelationshipsDrawingVML = f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
 		vmlID, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
 	} else {
 		// Add first VML drawing for given sheet.
 		rID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
-		f.addSheetNameSpace(opts.sheet, SourceRelationship)
-		f.addSheetLegacyDrawing(opts.sheet, rID)
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		f.addSheetLegacyDrawing(sheet, rID)
 	}
-	if err = f.addDrawingVML(vmlID, drawingVML, prepareFormCtrlOptions(&opts)); err != nil {
-		return err
+	var defaultFont string
+	if !formCtrl {
+		if defaultFont, err = f.GetDefaultFont(); err != nil {
+			return err
+		}
 	}
-	if !opts.formCtrl {
-		commentsXML := "xl/comments" + strconv.Itoa(vmlID) + ".xml"
-		if err = f.addComment(commentsXML, opts); err != nil {
+	commentsXML := "xl/comments" + strconv.Itoa(vmlID) + ".xml"
+	for i := range objects {
+		if err = f.addDrawingVML(vmlID, drawingVML, prepareFormCtrlOptions(&objects[i])); err != nil {
 			return err
 		}
-		if sheetXMLPath, ok := f.getSheetXMLPath(opts.sheet); ok && f.getSheetComments(filepath.Base(sheetXMLPath)) == "" {
+		if !formCtrl {
+			if err = f.addComment(commentsXML, defaultFont, objects[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if !formCtrl {
+		if sheetXMLPath, ok := f.getSheetXMLPath(sheet); ok && f.getSheetComments(filepath.Base(sheetXMLPath)) == "" {
 			sheetRelationshipsComments := "../comments" + strconv.Itoa(vmlID) + ".xml"
 			f.addRels(sheetRels, SourceRelationshipComments, sheetRelationshipsComments, "")
 		}
@@ -806,6 +1197,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if opts.FormControl.Type == FormControlNote {
 		sp.ClientData.MoveWithCells = stringPtr("")
 		sp.ClientData.SizeWithCells = stringPtr("")
+		if opts.Comment.Visible {
+			sp.ClientData.Visible = stringPtr("")
+		}
 	}
 	if !opts.formCtrl {
 		return &sp, nil
@@ -834,8 +1228,14 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		leftOffset, vmlID = 0, 201
 		style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;mso-wrap-style:tight"
 	}
+	if !opts.formCtrl && opts.Comment.Visible {
+		style = strings.Replace(style, "visibility:hidden", "visibility:visible", 1)
+	}
 	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(opts.sheet, col, row, opts.Format.OffsetX, opts.Format.OffsetY, int(opts.FormControl.Width), int(opts.FormControl.Height))
 	anchor := fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, leftOffset, rowStart, colEnd, x2, rowEnd, y2)
+	if len(opts.rangeRef) == 4 {
+		anchor = fmt.Sprintf("%d, 0, %d, 0, %d, 0, %d, 0", opts.rangeRef[0]-1, opts.rangeRef[1]-1, opts.rangeRef[2], opts.rangeRef[3])
+	}
 	if vml == nil {
 		vml = &vmlDrawing{
 			XMLNSv:  "urn:schemas-microsoft-com:vml",
@@ -139,6 +139,7 @@ type xClientData struct {
 	TextVAlign    string  `xml:"x:TextVAlign,omitempty"`
 	Row           *int    `xml:"x:Row"`
 	Column        *int    `xml:"x:Column"`
+	Visible       *string `xml:"x:Visible"`
 	Checked       int     `xml:"x:Checked,omitempty"`
 	FmlaLink      string  `xml:"x:FmlaLink,omitempty"`
 	NoThreeD      *string `xml:"x:NoThreeD"`
@@ -238,6 +239,7 @@ type decodeVMLClientData struct {
 	FmlaMacro  string
 	Column     *int
 	Row        *int
+	Visible    *string
 	Checked    int
 	FmlaLink   string
 	Val        uint
@@ -278,6 +280,7 @@ type formCtrlPreset struct {
 type vmlOptions struct {
 	formCtrl bool
 	sheet    string
+	rangeRef []int
 	Comment
 	FormControl
 }
@@ -81,6 +81,197 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func BenchmarkAddComment(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		f := NewFile()
+		for row := 1; row <= 1000; row++ {
+			if err := f.AddComment("Sheet1", Comment{Cell: fmt.Sprint("A", row), Author: "Excelize", Text: "This is a comment."}); err != nil {
+				b.Error(err)
+			}
+		}
+	}
+}
+
+func BenchmarkAddComments(b *testing.B) {
+	comments := make(map[string]Comment, 1000)
+	for row := 1; row <= 1000; row++ {
+		comments[fmt.Sprint("A", row)] = Comment{Author: "Excelize", Text: "This is a comment."}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewFile().AddComments("Sheet1", comments); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func TestAddComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment A1"}))
+	comments := map[string]Comment{
+		"B2":    {Author: "Excelize", Text: "Comment B2"},
+		"C3":    {Cell: "Z1", Author: "Author", Paragraph: []RichTextRun{{Text: "Comment ", Font: &Font{Bold: true}}, {Text: "C3"}}},
+		"E5:D4": {Author: "Excelize", Text: "Comment D4"},
+	}
+	assert.NoError(t, f.AddComments("Sheet1", comments))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	cmts, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cmts, 4)
+	for i, expected := range []Comment{
+		{Cell: "A1", Author: "Excelize", Text: "Comment A1"},
+		{Cell: "B2", Author: "Excelize", Text: "Comment B2"},
+		{Cell: "C3", Author: "Author"},
+		{Cell: "D4", Author: "Excelize", Text: "Comment D4"},
+	} {
+		assert.Equal(t, expected.Cell, cmts[i].Cell)
+		assert.Equal(t, expected.Author, cmts[i].Author)
+		assert.Equal(t, expected.Text, cmts[i].Text)
+	}
+	assert.Len(t, cmts[2].Paragraph, 2)
+	vml, err := f.decodeVMLDrawingReader("xl/drawings/vmlDrawing1.vml")
+	assert.NoError(t, err)
+	assert.Len(t, vml.Shape, 4)
+	// Test add comments with empty comments
+	assert.NoError(t, f.AddComments("Sheet1", nil))
+	// Test add comments on not exists worksheet
+	assert.EqualError(t, f.AddComments("SheetN", comments), "sheet SheetN does not exist")
+	// Test add comments with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddComments("Sheet1", map[string]Comment{"A": {Text: "Comment"}}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddComments("Sheet1", map[string]Comment{"A:B2": {Text: "Comment"}}))
+	// Test add comments with invalid cell reference will not add any comment
+	f2 := NewFile()
+	assert.Equal(t, newCellNameToCoordinatesError("Z", newInvalidCellNameError("Z")), f2.AddComments("Sheet1", map[string]Comment{"A1": {Text: "Comment"}, "Z": {Text: "Comment"}}))
+	cmts, err = f2.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cmts)
+	ws, err := f2.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.LegacyDrawing)
+	_, ok := f2.Pkg.Load("xl/drawings/vmlDrawing1.vml")
+	assert.False(t, ok)
+	assert.NoError(t, f2.Close())
+	// Test add comments with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddComments("Sheet1", comments), "XML syntax error on line 1: invalid UTF-8")
+	// Test add comments with unsupported charset comments part
+	f = NewFile()
+	assert.NoError(t, f.AddComments("Sheet1", comments))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddComments("Sheet1", comments), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCommentedCells(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "C3", "B5"} {
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: cell, Author: "Excelize", Text: "Comment " + cell}))
+	}
+	// Test get commented cells from the loaded comments
+	cells, err := f.GetCommentedCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "C3", "B5"}, cells)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	cells, err = f.GetCommentedCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "C3", "B5"}, cells)
+	assert.Nil(t, f.Comments["xl/comments1.xml"])
+	// Test get commented cells on the worksheet without comments
+	cells, err = f.GetCommentedCells("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test get commented cells on not exists worksheet
+	_, err = f.GetCommentedCells("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get commented cells with unsupported charset comments part
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCommentedCells("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddCommentWithRange(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 30))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D4:B2", Author: "Excelize", Text: "This is a comment."}))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B2", comments[0].Cell)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.NotNil(t, vml)
+	assert.Len(t, vml.Shape, 1)
+	assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>1, 0, 1, 0, 4, 0, 4, 0</x:Anchor>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentWithRange.xlsx")))
+	// Test add comment with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddComment("Sheet1", Comment{Cell: "A:B2", Text: "Comment"}))
+	// Test add comment with range reference on not exists worksheet
+	assert.EqualError(t, f.AddComment("SheetN", Comment{Cell: "A1:B2", Text: "Comment"}), "sheet SheetN does not exist")
+}
+
+func TestAddCommentWithParagraphs(t *testing.T) {
+	f := NewFile()
+	paragraphs := [][]RichTextRun{
+		{{Text: "Heading", Font: &Font{Bold: true, Color: "FF0000"}}},
+		{{Text: "Body text, "}, {Text: "italic", Font: &Font{Italic: true}}},
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Paragraphs: paragraphs}))
+	assert.NoError(t, f.AddComments("Sheet1", map[string]Comment{
+		"B2": {Author: "Excelize", Paragraphs: [][]RichTextRun{{{Text: "First"}}, {}, {{Text: "Third"}}}},
+		"C3": {Author: "Excelize", Text: "Line 1\n\nLine 3\n"},
+		"D4": {Author: "Excelize", Paragraphs: [][]RichTextRun{{}, {{Text: "Second"}}, {}, {}}},
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 4)
+	assert.Len(t, comments[0].Paragraphs, 2)
+	assert.Equal(t, "Heading", comments[0].Paragraphs[0][0].Text)
+	assert.True(t, comments[0].Paragraphs[0][0].Font.Bold)
+	assert.Equal(t, "FF0000", comments[0].Paragraphs[0][0].Font.Color)
+	assert.Len(t, comments[0].Paragraphs[1], 2)
+	assert.Equal(t, "Body text, ", comments[0].Paragraphs[1][0].Text)
+	assert.False(t, comments[0].Paragraphs[1][0].Font.Bold)
+	assert.Equal(t, "italic", comments[0].Paragraphs[1][1].Text)
+	assert.True(t, comments[0].Paragraphs[1][1].Font.Italic)
+	assert.Equal(t, "Heading\n", comments[0].Paragraph[0].Text)
+	// Test get comment with an empty paragraph
+	assert.Equal(t, [][]RichTextRun{
+		{{Text: "First", Font: comments[1].Paragraph[0].Font}},
+		nil,
+		{{Text: "Third", Font: comments[1].Paragraph[2].Font}},
+	}, comments[1].Paragraphs)
+	// Test get plain text comment with blank lines
+	assert.Equal(t, "Line 1\n\nLine 3\n", comments[2].Text)
+	assert.Equal(t, [][]RichTextRun{{{Text: "Line 1"}}, nil, {{Text: "Line 3"}}, nil}, comments[2].Paragraphs)
+	// Test get comment with leading and trailing empty paragraphs
+	assert.Len(t, comments[3].Paragraphs, 4)
+	assert.Empty(t, comments[3].Paragraphs[0])
+	assert.Equal(t, "Second", comments[3].Paragraphs[1][0].Text)
+	assert.Empty(t, comments[3].Paragraphs[2])
+	assert.Empty(t, comments[3].Paragraphs[3])
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -128,6 +319,55 @@ func TestDeleteComment(t *testing.T) {
 	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
 }
 
+func TestCommentVisible(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Hidden comment."}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Author: "Excelize", Text: "Visible comment.", Visible: true}))
+	assert.NoError(t, f.SetCommentVisible("Sheet1", "A1", true))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	for _, comment := range comments {
+		assert.True(t, comment.Visible, comment.Cell)
+	}
+	// Test toggle the comment visibility
+	assert.NoError(t, f.SetCommentVisible("Sheet1", "A1", false))
+	assert.NoError(t, f.SetCommentVisible("Sheet1", "B2", true))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, comments[0].Visible)
+	assert.True(t, comments[1].Visible)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Contains(t, vml.Shape[0].Style, "visibility:hidden")
+	assert.NotContains(t, vml.Shape[0].Val, "Visible")
+	assert.Contains(t, vml.Shape[1].Style, "visibility:visible")
+	assert.Equal(t, 1, strings.Count(vml.Shape[1].Val, "<x:Visible></x:Visible>"))
+	// Test set comment visible with the shape without visibility style
+	vml.Shape[0].Style = "position:absolute;"
+	assert.NoError(t, f.SetCommentVisible("Sheet1", "A1", true))
+	assert.Equal(t, "position:absolute;visibility:visible", vml.Shape[0].Style)
+	// Test set comment visible on the cell without comment
+	assert.EqualError(t, f.SetCommentVisible("Sheet1", "C3", true), "comment in cell C3 does not exist")
+	assert.EqualError(t, NewFile().SetCommentVisible("Sheet1", "A1", true), "comment in cell A1 does not exist")
+	// Test set comment visible with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCommentVisible("Sheet1", "A", true))
+	// Test set comment visible on not exists worksheet
+	assert.EqualError(t, f.SetCommentVisible("SheetN", "A1", true), "sheet SheetN does not exist")
+	// Test set and get comments visibility with unsupported charset VML drawing
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"], f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil, nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCommentVisible("Sheet1", "A1", true), "XML syntax error on line 1: invalid UTF-8")
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -145,6 +385,53 @@ func TestCommentsReader(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestResolveComment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "This is a comment."}))
+	// Test resolve comment without threaded comments
+	assert.EqualError(t, f.ResolveComment("Sheet1", "A1", true), newNoExistCommentError("A1").Error())
+	// Add a threaded comment with a reply to the cell
+	path := "xl/threadedComments/threadedComment1.xml"
+	f.addRels("xl/worksheets/_rels/sheet1.xml.rels", SourceRelationshipThreadedComment, "../threadedComments/threadedComment1.xml", "")
+	f.Pkg.Store(path, []byte(xml.Header+`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="A1" dT="2024-01-01T00:00:00.00" personId="{00000000-0000-0000-0000-000000000001}" id="{00000000-0000-0000-0000-000000000002}"><text>This is a comment.</text></threadedComment><threadedComment ref="A1" dT="2024-01-02T00:00:00.00" personId="{00000000-0000-0000-0000-000000000001}" id="{00000000-0000-0000-0000-000000000003}" parentId="{00000000-0000-0000-0000-000000000002}"><text>This is a reply.</text></threadedComment></ThreadedComments>`))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.False(t, comments[0].Resolved)
+	// Test mark the comment as resolved and reopen it
+	for _, resolved := range []bool{true, false, true} {
+		assert.NoError(t, f.ResolveComment("Sheet1", "A1", resolved))
+		comments, err = f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, resolved, comments[0].Resolved)
+	}
+	// Test the resolved state after saving and reopening the workbook
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, comments[0].Resolved)
+	threadedComments, err := f.threadedCommentsReader(path)
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments.ThreadedComment, 2)
+	assert.Nil(t, threadedComments.ThreadedComment[1].Done)
+	assert.Equal(t, "This is a reply.", threadedComments.ThreadedComment[1].Text)
+	// Test resolve comment in the cell without threaded comment
+	assert.EqualError(t, f.ResolveComment("Sheet1", "B1", true), newNoExistCommentError("B1").Error())
+	// Test resolve comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ResolveComment("Sheet1", "A", true))
+	// Test resolve comment on not exists worksheet
+	assert.EqualError(t, f.ResolveComment("SheetN", "A1", true), "sheet SheetN does not exist")
+	// Test resolve comment and get comments with unsupported charset
+	f.Pkg.Store(path, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ResolveComment("Sheet1", "A1", true), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestCountComments(t *testing.T) {
 	f := NewFile()
 	f.Comments["xl/comments1.xml"] = nil
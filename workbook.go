@@ -13,11 +13,18 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+
+	"github.com/xuri/efp"
 )
 
 // SetWorkbookProps provides a function to sets workbook properties.
@@ -44,6 +51,83 @@ func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	return nil
 }
 
+// SetDateSystem provides a function to set the date system of the workbook,
+// the 1904 date system will be used if use1904 is true, otherwise the 1900
+// date system will be used. The date and time values set by the SetCellValue
+// function and the formatted cell values will be based on the epoch of the
+// specified date system. The serial numbers of the existing cells with date
+// number formats will be converted to keep the same dates, but the dates
+// before January 1, 1904 can't be represented in the 1904 date system, and
+// these cells will be kept as is. For example, use the 1904 date system:
+//
+//	err := f.SetDateSystem(true)
+func (f *File) SetDateSystem(use1904 bool) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.WorkbookPr == nil {
+		wb.WorkbookPr = new(xlsxWorkbookPr)
+	}
+	if wb.WorkbookPr.Date1904 == use1904 {
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	offset := date1904Offset
+	if use1904 {
+		offset = -date1904Offset
+	}
+	dateStyles := make(map[int]bool)
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return err
+		}
+		ws.mu.Lock()
+		for rowIdx := range ws.SheetData.Row {
+			for colIdx := range ws.SheetData.Row[rowIdx].C {
+				c := &ws.SheetData.Row[rowIdx].C[colIdx]
+				if c.S == 0 || (c.T != "" && c.T != "n") {
+					continue
+				}
+				isDate, ok := dateStyles[c.S]
+				if !ok {
+					isDate = f.isDateStyle(s, c.S)
+					dateStyles[c.S] = isDate
+				}
+				if num, err := strconv.ParseFloat(c.V, 64); err == nil && isDate && num+offset >= 0 {
+					c.V = strconv.FormatFloat(num+offset, 'f', -1, 64)
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	wb.WorkbookPr.Date1904 = use1904
+	return err
+}
+
+// isDateStyle provides a function to check if the number format of the given
+// cell style index is a date format.
+func (f *File) isDateStyle(s *xlsxStyleSheet, styleID int) bool {
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) || s.CellXfs.Xf[styleID].NumFmtID == nil {
+		return false
+	}
+	numFmtID := *s.CellXfs.Xf[styleID].NumFmtID
+	if fmtCode, ok := s.getCustomNumFmtCode(numFmtID); ok {
+		return isDateNumFmtCode(fmtCode)
+	}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
+		return isDateNumFmtCode(fmtCode)
+	}
+	return false
+}
+
 // GetWorkbookProps provides a function to gets workbook properties.
 func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	var opts WorkbookPropsOptions
@@ -59,13 +143,140 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// GetDocumentFormat provides a function to get the conformance class of the
+// Office Open XML document, the result will be "Strict" for the document
+// which conforms to the ISO/IEC 29500 Strict schema, or "Transitional" for
+// the document which conforms to the Transitional schema. The namespaces of
+// the Strict document will be converted to the Transitional namespaces on
+// reading, and the namespaces and relationship types of all parts will be
+// converted to the Transitional schema on saving, so the saved document will
+// be reported as "Transitional".
+func (f *File) GetDocumentFormat() (string, error) {
+	if _, err := f.workbookReader(); err != nil {
+		return "", err
+	}
+	for _, attr := range getRootElement(f.xmlNewDecoder(bytes.NewReader(f.readXML(f.getWorkbookPath())))) {
+		if (attr.Name.Local == "conformance" && attr.Value == "strict") ||
+			(attr.Name.Local == "xmlns" && attr.Value == StrictNameSpaceSpreadSheet) {
+			return "Strict", nil
+		}
+	}
+	return "Transitional", nil
+}
+
+// strictToTransitional provides a function to convert the namespaces and
+// relationship types of all parts in the Strict document to the Transitional
+// schema. The deferred and temporary worksheet parts will be loaded into
+// memory for the conversion.
+func (f *File) strictToTransitional() error {
+	if format, _ := f.GetDocumentFormat(); format != "Strict" {
+		return nil
+	}
+	var err error
+	f.lazyFiles.Range(func(path, _ interface{}) bool {
+		_, err = f.loadLazyFile(path.(string))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	f.tempFiles.Range(func(path, _ interface{}) bool {
+		if _, ok := f.Pkg.Load(path); !ok {
+			f.Pkg.Store(path, f.readBytes(path.(string)))
+		}
+		return true
+	})
+	f.Pkg.Range(func(path, content interface{}) bool {
+		switch strings.ToLower(filepath.Ext(path.(string))) {
+		case ".rels", ".vml", ".xml":
+			f.Pkg.Store(path, namespaceStrictToTransitional(content.([]byte)))
+		}
+		return true
+	})
+	f.xmlAttr.Range(func(_, attrs interface{}) bool {
+		for i, attr := range attrs.([]xml.Attr) {
+			attrs.([]xml.Attr)[i].Value = string(namespaceStrictToTransitional([]byte(attr.Value)))
+		}
+		return true
+	})
+	return err
+}
+
+// GetCalcProps provides a function to gets calculation properties of the
+// workbook. The calculation mode will be "auto" and the reference mode will
+// be "A1" if they are not specified in the workbook. The RefMode indicates
+// whether the formulas in the workbook should be interpreted with the A1 or
+// R1C1 reference style.
+func (f *File) GetCalcProps() (CalcPropsOptions, error) {
+	opts := CalcPropsOptions{
+		CalcMode:       stringPtr("auto"),
+		FullCalcOnLoad: boolPtr(false),
+		IterativeCalc:  boolPtr(false),
+		IterateCount:   intPtr(100),
+		IterateDelta:   float64Ptr(0.001),
+		RefMode:        stringPtr("A1"),
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	if wb.CalcPr == nil {
+		return opts, err
+	}
+	if wb.CalcPr.CalcID != "" {
+		opts.CalcID = stringPtr(wb.CalcPr.CalcID)
+	}
+	if wb.CalcPr.CalcMode != "" {
+		opts.CalcMode = stringPtr(wb.CalcPr.CalcMode)
+	}
+	if wb.CalcPr.IterateCount != 0 {
+		opts.IterateCount = intPtr(wb.CalcPr.IterateCount)
+	}
+	if wb.CalcPr.IterateDelta != 0 {
+		opts.IterateDelta = float64Ptr(wb.CalcPr.IterateDelta)
+	}
+	if wb.CalcPr.RefMode != "" {
+		opts.RefMode = stringPtr(wb.CalcPr.RefMode)
+	}
+	opts.FullCalcOnLoad = boolPtr(wb.CalcPr.FullCalcOnLoad)
+	opts.IterativeCalc = boolPtr(wb.CalcPr.Iterate)
+	return opts, err
+}
+
+// SetReferenceMode provides a function to set the formula reference mode of
+// the workbook, the mode could be "A1" or "R1C1". This setting only changes how
+// the spreadsheet application displays the cell references, the formulas are
+// always stored and returned by the GetCellFormula function in the A1
+// reference style. For example, set the R1C1 reference mode for the workbook:
+//
+//	err := f.SetReferenceMode("R1C1")
+func (f *File) SetReferenceMode(mode string) error {
+	if mode != "A1" && mode != "R1C1" {
+		return ErrParameterInvalid
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	wb.CalcPr.RefMode = mode
+	if mode == "A1" {
+		wb.CalcPr.RefMode = ""
+	}
+	return err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
 // specified hash algorithm, support XOR, MD4, MD5, SHA-1, SHA2-56, SHA-384,
 // and SHA-512 currently, if no hash algorithm specified, will be using the XOR
-// algorithm as default. The generated workbook only works on Microsoft Office
-// 2007 and later. For example, protect workbook with protection settings:
+// algorithm as default. The optional field SpinCount specified the iterations
+// count of the password hash, and the default value is 100000. The generated
+// workbook only works on Microsoft Office 2007 and later. For example, protect
+// workbook with protection settings:
 //
 //	err := f.ProtectWorkbook(&excelize.WorkbookProtectionOptions{
 //	    Password:      "password",
@@ -90,18 +301,41 @@ func (f *File) ProtectWorkbook(opts *WorkbookProtectionOptions) error {
 		if opts.AlgorithmName == "" {
 			opts.AlgorithmName = "SHA-512"
 		}
-		hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", int(workbookProtectionSpinCount))
+		spinCount := int(workbookProtectionSpinCount)
+		if opts.SpinCount > 0 {
+			spinCount = opts.SpinCount
+		}
+		hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", spinCount)
 		if err != nil {
 			return err
 		}
 		wb.WorkbookProtection.WorkbookAlgorithmName = opts.AlgorithmName
 		wb.WorkbookProtection.WorkbookSaltValue = saltValue
 		wb.WorkbookProtection.WorkbookHashValue = hashValue
-		wb.WorkbookProtection.WorkbookSpinCount = int(workbookProtectionSpinCount)
+		wb.WorkbookProtection.WorkbookSpinCount = spinCount
 	}
 	return nil
 }
 
+// GetWorkbookProtection provides a function to get the protection settings of
+// the workbook, including the structure and windows lock flags, the hash
+// algorithm and the spin count of the password. Note that the password can't
+// be retrieved, the Password field of the returned options will always be
+// empty. The zero-value options will be returned if the workbook is not
+// protected.
+func (f *File) GetWorkbookProtection() (WorkbookProtectionOptions, error) {
+	var opts WorkbookProtectionOptions
+	wb, err := f.workbookReader()
+	if err != nil || wb.WorkbookProtection == nil {
+		return opts, err
+	}
+	opts.AlgorithmName = wb.WorkbookProtection.WorkbookAlgorithmName
+	opts.SpinCount = wb.WorkbookProtection.WorkbookSpinCount
+	opts.LockStructure = wb.WorkbookProtection.LockStructure
+	opts.LockWindows = wb.WorkbookProtection.LockWindows
+	return opts, err
+}
+
 // UnprotectWorkbook provides a function to remove protection for workbook,
 // specified the optional password parameter to remove workbook protection with
 // password verification.
@@ -130,6 +364,508 @@ func (f *File) UnprotectWorkbook(password ...string) error {
 	return err
 }
 
+// SetFileSharing provides a function to set the file sharing settings of the
+// workbook, such as the read-only recommended flag, the name of the user who
+// reserved the workbook for writing, and the optional write reservation
+// password. The optional field AlgorithmName specified hash algorithm of the
+// password, support XOR, MD4, MD5, SHA-1, SHA2-56, SHA-384, and SHA-512
+// currently, if no hash algorithm specified, will be using the XOR algorithm
+// as default. Remove the file sharing settings if the options is nil. For
+// example, set the workbook opens with a read-only recommended prompt:
+//
+//	err := f.SetFileSharing(&excelize.FileSharingOptions{
+//	    ReadOnlyRecommended: true,
+//	    UserName:            "Excelize",
+//	})
+func (f *File) SetFileSharing(opts *FileSharingOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		wb.FileSharing = nil
+		return err
+	}
+	wb.FileSharing = &xlsxFileSharing{
+		ReadOnlyRecommended: opts.ReadOnlyRecommended,
+		UserName:            opts.UserName,
+	}
+	if opts.Password != "" {
+		if opts.AlgorithmName == "" {
+			wb.FileSharing.ReservationPassword = genSheetPasswd(opts.Password)
+			return err
+		}
+		hashValue, saltValue, err := genISOPasswdHash(opts.Password, opts.AlgorithmName, "", int(fileSharingSpinCount))
+		if err != nil {
+			return err
+		}
+		wb.FileSharing.AlgorithmName = opts.AlgorithmName
+		wb.FileSharing.SaltValue = saltValue
+		wb.FileSharing.HashValue = hashValue
+		wb.FileSharing.SpinCount = int(fileSharingSpinCount)
+	}
+	return err
+}
+
+// GetFileSharing provides a function to get the file sharing settings of the
+// workbook. Note that the write reservation password can't be retrieved, the
+// Password field of the returned options will always be empty.
+func (f *File) GetFileSharing() (FileSharingOptions, error) {
+	var opts FileSharingOptions
+	wb, err := f.workbookReader()
+	if err != nil || wb.FileSharing == nil {
+		return opts, err
+	}
+	opts.AlgorithmName = wb.FileSharing.AlgorithmName
+	opts.ReadOnlyRecommended = wb.FileSharing.ReadOnlyRecommended
+	opts.UserName = wb.FileSharing.UserName
+	return opts, err
+}
+
+// GetRibbonXML provides a function to get the custom ribbon user interface
+// markup of the workbook. The markup for Office 2010 and later will be returned
+// if the workbook contains both the Office 2007 and Office 2010 custom ribbon
+// user interface parts. This function returns nil if the workbook without
+// custom ribbon user interface.
+func (f *File) GetRibbonXML() ([]byte, error) {
+	partName, err := f.getRibbonPartName()
+	if err != nil || partName == "" {
+		return nil, err
+	}
+	content, ok := f.Pkg.Load(partName)
+	if !ok {
+		return nil, err
+	}
+	return content.([]byte), err
+}
+
+// SetRibbonXML provides a function to set the custom ribbon user interface
+// markup of the workbook, the markup will be stored in the existing custom
+// ribbon user interface part or a new Office 2010 custom ribbon user interface
+// part. Note that the custom ribbon will only be loaded by the spreadsheet
+// application for the macro-enabled workbook or add-in. For example:
+//
+//	err := f.SetRibbonXML([]byte(`<customUI xmlns="http://schemas.microsoft.com/office/2009/07/customui"><ribbon><tabs><tab id="customTab" label="Excelize"/></tabs></ribbon></customUI>`))
+func (f *File) SetRibbonXML(content []byte) error {
+	if len(content) == 0 {
+		return ErrParameterInvalid
+	}
+	decoder := f.xmlNewDecoder(bytes.NewReader(content))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+	partName, err := f.getRibbonPartName()
+	if err != nil {
+		return err
+	}
+	if partName == "" {
+		partName = defaultXMLPathCustomUI14
+		f.addRels("_rels/.rels", SourceRelationshipCustomUI14, partName, "")
+	}
+	f.Pkg.Store(partName, content)
+	return err
+}
+
+// getRibbonPartName provides a function to get the part name of the custom
+// ribbon user interface in the package.
+func (f *File) getRibbonPartName() (string, error) {
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	var partName string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipCustomUI14 {
+			return strings.TrimPrefix(rel.Target, "/"), err
+		}
+		if rel.Type == SourceRelationshipCustomUI {
+			partName = strings.TrimPrefix(rel.Target, "/")
+		}
+	}
+	return partName, err
+}
+
+// GetEmbeddedFonts provides a function to get the embedded fonts in the
+// workbook. The embedded font parts were stored in the "xl/fonts" folder of the
+// workbook package, and will be kept as-is on saving the workbook. The name of
+// each embedded font is the family name in the name table of the TrueType or
+// OpenType font, the obfuscated font which named with the font key GUID will
+// be deobfuscated before reading the name table. The fonts will be returned in
+// the order of their part names, and an error will be returned if the name
+// table of the font can't be read. For example, extract all embedded fonts in
+// the workbook:
+//
+//	fonts, err := f.GetEmbeddedFonts()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, font := range fonts {
+//	    if err := os.WriteFile(filepath.Base(font.Path), font.File, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetEmbeddedFonts() ([]EmbeddedFont, error) {
+	var (
+		fonts     []EmbeddedFont
+		partNames []string
+	)
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if partName := k.(string); strings.HasPrefix(strings.ToLower(partName), "xl/fonts/") {
+			partNames = append(partNames, partName)
+		}
+		return true
+	})
+	sort.Strings(partNames)
+	for _, partName := range partNames {
+		content, ok := f.Pkg.Load(partName)
+		if !ok {
+			continue
+		}
+		name, ok := getFontFamilyName(deobfuscateFont(partName, content.([]byte)))
+		if !ok {
+			return fonts, newInvalidEmbeddedFontError(partName)
+		}
+		fonts = append(fonts, EmbeddedFont{
+			Name:      name,
+			Path:      partName,
+			Extension: filepath.Ext(partName),
+			File:      content.([]byte),
+		})
+	}
+	return fonts, nil
+}
+
+// deobfuscateFont provides a function to deobfuscate the obfuscated font data
+// by the font key GUID in the part name, the first 32 bytes of the font data
+// were XORed with the reversed bytes of the GUID. The font data will be
+// returned as-is if the part name isn't a GUID.
+func deobfuscateFont(partName string, data []byte) []byte {
+	name := strings.TrimSuffix(filepath.Base(partName), filepath.Ext(partName))
+	key, err := hex.DecodeString(strings.NewReplacer("{", "", "}", "", "-", "").Replace(name))
+	if err != nil || len(key) != 16 || len(data) < 32 {
+		return data
+	}
+	font := make([]byte, len(data))
+	copy(font, data)
+	for i := 0; i < 32; i++ {
+		font[i] ^= key[15-i%16]
+	}
+	return font
+}
+
+// getFontFamilyName provides a function to get the font family name from the
+// name table of the TrueType or OpenType font data, the second return value
+// will be false if the name table or the family name not found.
+func getFontFamilyName(data []byte) (string, bool) {
+	if len(data) < 12 {
+		return "", false
+	}
+	for i := 0; i < int(binary.BigEndian.Uint16(data[4:])); i++ {
+		record := 12 + i*16
+		if len(data) < record+16 {
+			return "", false
+		}
+		if string(data[record:record+4]) != "name" {
+			continue
+		}
+		offset, length := int64(binary.BigEndian.Uint32(data[record+8:])), int64(binary.BigEndian.Uint32(data[record+12:]))
+		if offset+length > int64(len(data)) {
+			return "", false
+		}
+		return getFontNameTableFamily(data[offset : offset+length])
+	}
+	return "", false
+}
+
+// getFontNameTableFamily provides a function to get the font family name
+// (name ID 1) from the font name table, the name in the Unicode or Windows
+// platform encoded in UTF-16BE will be preferred over the Macintosh platform.
+func getFontNameTableFamily(table []byte) (string, bool) {
+	if len(table) < 6 {
+		return "", false
+	}
+	var name string
+	count, storage := int(binary.BigEndian.Uint16(table[2:])), int(binary.BigEndian.Uint16(table[4:]))
+	for i := 0; i < count; i++ {
+		record := 6 + i*12
+		if len(table) < record+12 {
+			return "", false
+		}
+		platformID, nameID := binary.BigEndian.Uint16(table[record:]), binary.BigEndian.Uint16(table[record+6:])
+		length, offset := int(binary.BigEndian.Uint16(table[record+8:])), int(binary.BigEndian.Uint16(table[record+10:]))
+		if nameID != 1 || storage+offset+length > len(table) {
+			continue
+		}
+		str := table[storage+offset : storage+offset+length]
+		switch platformID {
+		case 0, 3:
+			runes := make([]uint16, len(str)/2)
+			for j := range runes {
+				runes[j] = binary.BigEndian.Uint16(str[j*2:])
+			}
+			return string(utf16.Decode(runes)), true
+		case 1:
+			if name == "" {
+				name = string(str)
+			}
+		}
+	}
+	return name, name != ""
+}
+
+// GetWorkbookStats provides a function to get the formula statistics of the
+// workbook by scanning the formulas of all worksheets without calculating them.
+// The statistics include the number of the formula cells, the number of the
+// volatile functions (CELL, INDIRECT, INFO, NOW, OFFSET, RAND, RANDARRAY,
+// RANDBETWEEN and TODAY) used in the formulas, and the maximum depth of the
+// formula dependency chains. The depth of the formula which only references
+// constant cells is 1, and the circular references will not be followed. For
+// example:
+//
+//	stats, err := f.GetWorkbookStats()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Printf("formula cells: %d, volatile functions: %d, max depth: %d\n",
+//	    stats.FormulaCells, stats.VolatileFunctions, stats.MaxDependencyDepth)
+func (f *File) GetWorkbookStats() (WorkbookStats, error) {
+	var (
+		stats        WorkbookStats
+		formulaCells = make(map[string][]cellRef)
+		dependencies = make(map[string][]string)
+	)
+	for _, sheet := range f.GetSheetList() {
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return stats, err
+		}
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F != nil {
+					col, row, _ := CellNameToCoordinates(c.R)
+					formulaCells[sheet] = append(formulaCells[sheet], cellRef{Col: col, Row: row, Sheet: sheet})
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	index := make(map[string]*formulaCellIndex, len(formulaCells))
+	for sheet, cells := range formulaCells {
+		index[sheet] = newFormulaCellIndex(cells)
+	}
+	for sheet, cells := range formulaCells {
+		for _, cr := range cells {
+			cell, _ := CoordinatesToCellName(cr.Col, cr.Row)
+			formula, err := f.GetCellFormula(sheet, cell)
+			if err != nil {
+				return stats, err
+			}
+			stats.FormulaCells++
+			ref, ps := fmt.Sprintf("%s!%s", sheet, cell), efp.ExcelParser()
+			dependencies[ref] = []string{}
+			for _, token := range ps.Parse(formula) {
+				if isVolatileFunctionToken(token) {
+					stats.VolatileFunctions++
+				}
+				if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+					dependencies[ref] = append(dependencies[ref], f.getFormulaCellDeps(sheet, token.TValue, index)...)
+				}
+			}
+		}
+	}
+	depths := make(map[string]int)
+	for sheet, cells := range formulaCells {
+		for _, cr := range cells {
+			cell, _ := CoordinatesToCellName(cr.Col, cr.Row)
+			if depth := getFormulaDepth(fmt.Sprintf("%s!%s", sheet, cell), dependencies, depths); depth > stats.MaxDependencyDepth {
+				stats.MaxDependencyDepth = depth
+			}
+		}
+	}
+	return stats, nil
+}
+
+// getFormulaDepth provides a function to get the depth of the formula
+// dependency chain by given formula cell reference, the dependencies will be
+// walked iteratively with a stack to avoid the deep recursion on the long
+// chains, and the circular references will not be followed. The depths of the
+// walked formula cells will be cached in the given depths map.
+func getFormulaDepth(ref string, dependencies map[string][]string, depths map[string]int) int {
+	if _, ok := dependencies[ref]; !ok {
+		return 0
+	}
+	visiting, stack := make(map[string]bool), []string{ref}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		if _, ok := depths[top]; ok {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if !visiting[top] {
+			visiting[top] = true
+			for _, dep := range dependencies[top] {
+				if _, ok := dependencies[dep]; !ok || visiting[dep] {
+					continue
+				}
+				if _, ok := depths[dep]; !ok {
+					stack = append(stack, dep)
+				}
+			}
+			continue
+		}
+		var depth int
+		for _, dep := range dependencies[top] {
+			if d := depths[dep]; d > depth {
+				depth = d
+			}
+		}
+		depths[top] = depth + 1
+		stack = stack[:len(stack)-1]
+	}
+	return depths[ref]
+}
+
+// isVolatileFunctionToken returns if the given formula token is the beginning
+// of a volatile function.
+func isVolatileFunctionToken(token efp.Token) bool {
+	return token.TType == efp.TokenTypeFunction && token.TSubType == efp.TokenSubTypeStart &&
+		volatileFunctions[strings.ToUpper(strings.TrimPrefix(token.TValue, "_xlfn."))]
+}
+
+// clearVolatileCache provides a function to clear the cached values of the
+// formula cells which use volatile functions in all worksheets, and set the
+// workbook to perform a full calculation when the workbook is opened.
+func (f *File) clearVolatileCache() error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	wb.CalcPr.FullCalcOnLoad = true
+	for _, sheet := range f.GetSheetList() {
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		f.mu.Unlock()
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return err
+		}
+		var cells []string
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F != nil && c.V != "" {
+					cells = append(cells, c.R)
+				}
+			}
+		}
+		ws.mu.Unlock()
+		volatileCells := make(map[string]bool)
+		for _, cell := range cells {
+			formula, err := f.GetCellFormula(sheet, cell)
+			if err != nil {
+				return err
+			}
+			ps := efp.ExcelParser()
+			for _, token := range ps.Parse(formula) {
+				if isVolatileFunctionToken(token) {
+					volatileCells[cell] = true
+					break
+				}
+			}
+		}
+		ws.mu.Lock()
+		for r := range ws.SheetData.Row {
+			for c := range ws.SheetData.Row[r].C {
+				if cell := &ws.SheetData.Row[r].C[c]; volatileCells[cell.R] {
+					cell.T, cell.V = "", ""
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	return err
+}
+
+// formulaCellIndex is the index of the formula cells in a worksheet, which
+// maps the column number to the sorted row numbers of the formula cells in
+// the column, the column numbers were sorted in ascending order.
+type formulaCellIndex struct {
+	cols []int
+	rows map[int][]int
+}
+
+// newFormulaCellIndex provides a function to create the index of the formula
+// cells by given formula cells of a worksheet.
+func newFormulaCellIndex(cells []cellRef) *formulaCellIndex {
+	idx := &formulaCellIndex{rows: make(map[int][]int)}
+	for _, c := range cells {
+		if _, ok := idx.rows[c.Col]; !ok {
+			idx.cols = append(idx.cols, c.Col)
+		}
+		idx.rows[c.Col] = append(idx.rows[c.Col], c.Row)
+	}
+	sort.Ints(idx.cols)
+	for _, rows := range idx.rows {
+		sort.Ints(rows)
+	}
+	return idx
+}
+
+// getFormulaCellDeps provides a function to get the formula cells referenced
+// by the given reference or defined name in the formula of the worksheet, the
+// formula cells in the cell ranges will be looked up from the index.
+func (f *File) getFormulaCellDeps(sheet, reference string, index map[string]*formulaCellIndex) []string {
+	if refTo := f.getDefinedNameRefTo(reference, sheet); refTo != "" {
+		reference = strings.TrimPrefix(refTo, "=")
+	}
+	cellRefs, cellRanges, err := prepareReference(sheet, reference)
+	if err != nil {
+		return nil
+	}
+	var deps []string
+	for e := cellRefs.Front(); e != nil; e = e.Next() {
+		cr := e.Value.(cellRef)
+		cell, _ := CoordinatesToCellName(cr.Col, cr.Row)
+		deps = append(deps, fmt.Sprintf("%s!%s", unquoteSheetName(cr.Sheet), cell))
+	}
+	for e := cellRanges.Front(); e != nil; e = e.Next() {
+		cr := e.Value.(cellRange)
+		name := unquoteSheetName(cr.From.Sheet)
+		idx, ok := index[name]
+		if !ok {
+			continue
+		}
+		for i := sort.SearchInts(idx.cols, cr.From.Col); i < len(idx.cols) && idx.cols[i] <= cr.To.Col; i++ {
+			rows := idx.rows[idx.cols[i]]
+			for j := sort.SearchInts(rows, cr.From.Row); j < len(rows) && rows[j] <= cr.To.Row; j++ {
+				cell, _ := CoordinatesToCellName(idx.cols[i], rows[j])
+				deps = append(deps, fmt.Sprintf("%s!%s", name, cell))
+			}
+		}
+	}
+	return deps
+}
+
 // setWorkbook update workbook property of the spreadsheet. Maximum 31
 // characters are allowed in sheet title.
 func (f *File) setWorkbook(name string, sheetID, rid int) {
@@ -202,7 +938,12 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 			if attrs == nil {
 				attrs = []xml.Attr{}
 			}
-			attrs = append(attrs.([]xml.Attr), getRootElement(d)...)
+			for _, attr := range getRootElement(d) {
+				// The Strict document will be saved as the Transitional document
+				if attr.Name.Local != "conformance" {
+					attrs = append(attrs.([]xml.Attr), attr)
+				}
+			}
 			f.xmlAttr.Store(wbPath, attrs)
 			f.addNameSpaces(wbPath, SourceRelationship)
 		}
@@ -210,6 +951,7 @@ func (f *File) workbookReader() (*xlsxWorkbook, error) {
 			Decode(f.WorkBook); err != nil && err != io.EOF {
 			return f.WorkBook, err
 		}
+		f.WorkBook.Conformance = ""
 	}
 	return f.WorkBook, err
 }
@@ -1,7 +1,16 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf16"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +41,512 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetDateSystem(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", date))
+	timeStyle, err := f.NewStyle(&Style{NumFmt: 20})
+	assert.NoError(t, err)
+	monthStyle, err := f.NewStyle(&Style{CustomNumFmt: stringPtr("mmm")})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 0.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", timeStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 45292))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", 45292))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D1", "D1", monthStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", time.Date(1903, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	// Test set the 1904 date system with existing date cells
+	assert.NoError(t, f.SetDateSystem(true))
+	assert.NoError(t, f.SetDateSystem(true))
+	opts, err := f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.True(t, *opts.Date1904)
+	for cell, expected := range map[string][]string{
+		"A1": {"43830.5", "1/1/24 12:00"},
+		"B1": {"0.5", "12:00"},
+		"C1": {"45292", "45292"},
+		"D1": {"43830", "Jan"},
+		"E1": {"1097", "1/1/03 00:00"},
+	} {
+		raw, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], raw, cell)
+		if cell == "E1" {
+			continue
+		}
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val, cell)
+	}
+	// Test write date value in the 1904 date system
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", date))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), `date1904="true"`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	raw, err := f.GetCellValue("Sheet1", "A2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "43830.5", raw)
+	val, err := f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/1/24 12:00", val)
+	// Test set the 1900 date system with existing date cells
+	assert.NoError(t, f.SetDateSystem(false))
+	for cell, expected := range map[string]string{"A1": "45292.5", "A2": "45292.5", "B1": "0.5", "C1": "45292", "D1": "45292"} {
+		raw, err = f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, raw, cell)
+	}
+	// Test set date system without workbook properties
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.WorkbookPr = nil
+	assert.NoError(t, f.SetDateSystem(false))
+	assert.False(t, wb.WorkbookPr.Date1904)
+	// Test set date system with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	assert.EqualError(t, f.SetDateSystem(true), "XML syntax error on line 1: invalid UTF-8")
+	// Test set date system with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDateSystem(true), "XML syntax error on line 1: invalid UTF-8")
+	// Test set date system with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDateSystem(true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetDocumentFormat(t *testing.T) {
+	f := NewFile()
+	format, err := f.GetDocumentFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, "Transitional", format)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Strict"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 100))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Sheet2"))
+	assert.NoError(t, f.AddComment("Sheet2", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	source, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Prepare the Strict document by replacing the Transitional namespaces
+	zr, err := zip.NewReader(bytes.NewReader(source.Bytes()), int64(source.Len()))
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, item := range zr.File {
+		readerCloser, err := item.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(readerCloser)
+		assert.NoError(t, err)
+		xmlContent := strings.NewReplacer(
+			NameSpaceSpreadSheet.Value, StrictNameSpaceSpreadSheet,
+			SourceRelationship.Value, StrictSourceRelationship,
+		).Replace(string(content))
+		if item.Name == defaultXMLPathWorkbook {
+			xmlContent = strings.Replace(xmlContent, "<workbook ", `<workbook conformance="strict" `, 1)
+		}
+		writer, err := zw.Create(item.Name)
+		assert.NoError(t, err)
+		_, err = writer.Write([]byte(xmlContent))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	// Test get document format on the Strict document
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	format, err = f.GetDocumentFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, "Strict", format)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Strict", "100"}}, rows)
+	// Test the Strict document will be saved as the Transitional document
+	source, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assertTransitional := func(source *bytes.Buffer) {
+		zr, err := zip.NewReader(bytes.NewReader(source.Bytes()), int64(source.Len()))
+		assert.NoError(t, err)
+		for _, item := range zr.File {
+			readerCloser, err := item.Open()
+			assert.NoError(t, err)
+			content, err := io.ReadAll(readerCloser)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(content), "http://purl.oclc.org/ooxml", item.Name)
+		}
+	}
+	assertTransitional(source)
+	// Test save the Strict document with the deferred worksheets
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{LazyWorksheets: true})
+	assert.NoError(t, err)
+	lazySource, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assertTransitional(lazySource)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(source)
+	assert.NoError(t, err)
+	format, err = f.GetDocumentFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, "Transitional", format)
+	workbook := string(f.readXML(defaultXMLPathWorkbook))
+	assert.NotContains(t, workbook, "conformance")
+	assert.Contains(t, workbook, `xmlns:r="`+SourceRelationship.Value+`"`)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Strict", "100"}}, rows)
+	comments, err := f.GetComments("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	// Test get document format with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetDocumentFormat()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCalcProps(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, CalcPropsOptions{
+		CalcID:         stringPtr("122211"),
+		CalcMode:       stringPtr("auto"),
+		FullCalcOnLoad: boolPtr(false),
+		IterativeCalc:  boolPtr(false),
+		IterateCount:   intPtr(100),
+		IterateDelta:   float64Ptr(0.001),
+		RefMode:        stringPtr("A1"),
+	}, opts)
+	// Test get calculation properties in R1C1 reference mode
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, []byte(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets><calcPr calcId="191029" calcMode="manual" fullCalcOnLoad="1" iterate="1" iterateCount="50" iterateDelta="0.01" refMode="R1C1"/></workbook>`))
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, CalcPropsOptions{
+		CalcID:         stringPtr("191029"),
+		CalcMode:       stringPtr("manual"),
+		FullCalcOnLoad: boolPtr(true),
+		IterativeCalc:  boolPtr(true),
+		IterateCount:   intPtr(50),
+		IterateDelta:   float64Ptr(0.01),
+		RefMode:        stringPtr("R1C1"),
+	}, opts)
+	// Test get calculation properties without calculation properties element
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.CalcPr = nil
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Nil(t, opts.CalcID)
+	assert.Equal(t, "A1", *opts.RefMode)
+	// Test get calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetCalcProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetReferenceMode(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetReferenceMode("R1C1"))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "R1C1", wb.CalcPr.RefMode)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), `refMode="R1C1"`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "R1C1", *opts.RefMode)
+	// Test the formulas are still stored in the A1 reference style
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1:A2)", formula)
+	// Test set the A1 reference mode
+	assert.NoError(t, f.SetReferenceMode("A1"))
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", *opts.RefMode)
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	assert.Empty(t, wb.CalcPr.RefMode)
+	// Test set reference mode without calculation properties element
+	wb.CalcPr = nil
+	assert.NoError(t, f.SetReferenceMode("R1C1"))
+	assert.Equal(t, "R1C1", wb.CalcPr.RefMode)
+	// Test set reference mode with invalid mode
+	assert.Equal(t, ErrParameterInvalid, f.SetReferenceMode("R1"))
+	// Test set reference mode with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetReferenceMode("A1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestFileSharing(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{}, opts)
+	assert.NoError(t, f.SetFileSharing(&FileSharingOptions{
+		ReadOnlyRecommended: true,
+		UserName:            "Excelize",
+		Password:            "password",
+	}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Contains(t, string(f.readXML(defaultXMLPathWorkbook)), `<fileSharing readOnlyRecommended="true" userName="Excelize" reservationPassword="83AF"></fileSharing>`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	opts, err = f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{ReadOnlyRecommended: true, UserName: "Excelize"}, opts)
+	// Test set file sharing with specified hash algorithm
+	assert.NoError(t, f.SetFileSharing(&FileSharingOptions{
+		AlgorithmName: "SHA-512",
+		Password:      "password",
+	}))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Empty(t, wb.FileSharing.ReservationPassword)
+	assert.Len(t, wb.FileSharing.SaltValue, 24)
+	assert.Len(t, wb.FileSharing.HashValue, 88)
+	assert.Equal(t, int(fileSharingSpinCount), wb.FileSharing.SpinCount)
+	opts, err = f.GetFileSharing()
+	assert.NoError(t, err)
+	assert.Equal(t, FileSharingOptions{AlgorithmName: "SHA-512"}, opts)
+	// Test set file sharing with unsupported hash algorithm
+	assert.Equal(t, ErrUnsupportedHashAlgorithm, f.SetFileSharing(&FileSharingOptions{
+		AlgorithmName: "RIPEMD-160",
+		Password:      "password",
+	}))
+	// Test remove file sharing settings
+	assert.NoError(t, f.SetFileSharing(nil))
+	assert.Nil(t, wb.FileSharing)
+	// Test set and get file sharing with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetFileSharing(nil), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetFileSharing()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestRibbonXML(t *testing.T) {
+	f := NewFile()
+	ribbon, err := f.GetRibbonXML()
+	assert.NoError(t, err)
+	assert.Nil(t, ribbon)
+	// Test preserve the custom ribbon user interface part of the workbook
+	customUI := []byte(`<customUI xmlns="http://schemas.microsoft.com/office/2006/01/customui"><ribbon><tabs><tab id="tab1" label="Tab 1"/></tabs></ribbon></customUI>`)
+	f.Pkg.Store("customUI/customUI.xml", customUI)
+	f.addRels("_rels/.rels", SourceRelationshipCustomUI, "customUI/customUI.xml", "")
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Excelize"))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ribbon, err = f.GetRibbonXML()
+	assert.NoError(t, err)
+	assert.Equal(t, customUI, ribbon)
+	// Test set custom ribbon on the workbook with existing ribbon part
+	customUI = []byte(`<customUI xmlns="http://schemas.microsoft.com/office/2006/01/customui"><ribbon><tabs><tab id="tab2" label="Tab 2"/></tabs></ribbon></customUI>`)
+	assert.NoError(t, f.SetRibbonXML(customUI))
+	ribbon, err = f.GetRibbonXML()
+	assert.NoError(t, err)
+	assert.Equal(t, customUI, ribbon)
+	content, ok := f.Pkg.Load("customUI/customUI.xml")
+	assert.True(t, ok)
+	assert.Equal(t, customUI, content)
+	_, ok = f.Pkg.Load(defaultXMLPathCustomUI14)
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test set custom ribbon on the workbook without ribbon part
+	f = NewFile()
+	customUI = []byte(`<customUI xmlns="http://schemas.microsoft.com/office/2009/07/customui"><ribbon><tabs><tab id="tab3" label="Tab 3"/></tabs></ribbon></customUI>`)
+	assert.NoError(t, f.SetRibbonXML(customUI))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	ribbon, err = f.GetRibbonXML()
+	assert.NoError(t, err)
+	assert.Equal(t, customUI, ribbon)
+	rels, err := f.relsReader("_rels/.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, SourceRelationshipCustomUI14, rels.Relationships[len(rels.Relationships)-1].Type)
+	assert.Equal(t, defaultXMLPathCustomUI14, rels.Relationships[len(rels.Relationships)-1].Target)
+	// Test set custom ribbon with invalid markup
+	assert.Equal(t, ErrParameterInvalid, f.SetRibbonXML(nil))
+	assert.EqualError(t, f.SetRibbonXML([]byte("<customUI>")), "XML syntax error on line 1: unexpected EOF")
+	// Test get custom ribbon with the missing ribbon part
+	f.Pkg.Delete(defaultXMLPathCustomUI14)
+	ribbon, err = f.GetRibbonXML()
+	assert.NoError(t, err)
+	assert.Nil(t, ribbon)
+	// Test get and set custom ribbon with unsupported charset relationships
+	f.Relationships.Delete("_rels/.rels")
+	f.Pkg.Store("_rels/.rels", MacintoshCyrillicCharset)
+	_, err = f.GetRibbonXML()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetRibbonXML(customUI), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetEmbeddedFonts(t *testing.T) {
+	f := NewFile()
+	fonts, err := f.GetEmbeddedFonts()
+	assert.NoError(t, err)
+	assert.Empty(t, fonts)
+	// Test get embedded fonts from the workbook
+	fontKey := "{4D2C8F6E-1A3B-4C5D-8E9F-0A1B2C3D4E5F}"
+	fontData := newTestFontData("Embedded Sans")
+	obfuscatedFontData := deobfuscateFont(fontKey+".odttf", newTestFontData("Embedded Serif"))
+	f.Pkg.Store("xl/fonts/font1.ttf", fontData)
+	f.Pkg.Store("xl/fonts/"+fontKey+".odttf", obfuscatedFontData)
+	f.ContentTypes.Defaults = append(f.ContentTypes.Defaults,
+		xlsxDefault{Extension: "ttf", ContentType: "application/x-font-ttf"},
+		xlsxDefault{Extension: "odttf", ContentType: "application/vnd.openxmlformats-officedocument.obfuscatedFont"},
+	)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	expected := []EmbeddedFont{
+		{Name: "Embedded Sans", Path: "xl/fonts/font1.ttf", Extension: ".ttf", File: fontData},
+		{Name: "Embedded Serif", Path: "xl/fonts/" + fontKey + ".odttf", Extension: ".odttf", File: obfuscatedFontData},
+	}
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	fonts, err = f.GetEmbeddedFonts()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fonts)
+	// Test the embedded fonts will be kept after saving the workbook
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Embedded font"))
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	fonts, err = f.GetEmbeddedFonts()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, fonts)
+	// Test get embedded fonts with invalid font data
+	f.Pkg.Store("xl/fonts/font2.ttf", []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0x0F})
+	fonts, err = f.GetEmbeddedFonts()
+	assert.EqualError(t, err, newInvalidEmbeddedFontError("xl/fonts/font2.ttf").Error())
+	assert.Equal(t, expected[:1], fonts)
+	assert.NoError(t, f.Close())
+	// Test get font family name with invalid name table
+	header := []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 'n', 'a', 'm', 'e', 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1C}
+	for _, data := range [][]byte{
+		fontData[:20],
+		append(append([]byte{}, header...), 0x00, 0x00, 0xFF, 0xFF),
+		append(append([]byte{}, header...), 0x00, 0x00, 0x00, 0x01, 0x00),
+		append(append([]byte{}, header...), 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x01, 0x00, 0x06),
+		append(append([]byte{}, header...), 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x06),
+	} {
+		_, ok := getFontFamilyName(data)
+		assert.False(t, ok)
+	}
+}
+
+// newTestFontData creates the font data which only contains the name table
+// with the family name in the Macintosh and Windows platforms.
+func newTestFontData(family string) []byte {
+	macName, winName := []byte(family+" Mac"), utf16.Encode([]rune(family))
+	nameTable := bytes.NewBuffer(nil)
+	for _, v := range []uint16{0, 2, 30, 1, 0, 0, 1, uint16(len(macName)), 0, 3, 1, 0x409, 1, uint16(len(winName) * 2), uint16(len(macName))} {
+		_ = binary.Write(nameTable, binary.BigEndian, v)
+	}
+	nameTable.Write(macName)
+	_ = binary.Write(nameTable, binary.BigEndian, winName)
+	data := bytes.NewBuffer(nil)
+	_ = binary.Write(data, binary.BigEndian, []uint32{0x00010000, 1 << 16, 0})
+	data.WriteString("name")
+	_ = binary.Write(data, binary.BigEndian, []uint32{0, 28, uint32(nameTable.Len())})
+	data.Write(nameTable.Bytes())
+	return data.Bytes()
+}
+
+func TestGetWorkbookStats(t *testing.T) {
+	f := NewFile()
+	stats, err := f.GetWorkbookStats()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookStats{}, stats)
+	_, err = f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	for cell, formula := range map[string]string{
+		"B1": "A1*2",
+		"C1": "B1+NOW()",
+		"D1": "SUM(B1:C1)+RAND()",
+		"F1": "G1",
+		"G1": "F1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	formulaType, ref := STCellFormulaTypeShared, "E1:E3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "A1", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sheet1!D1+OFFSET(Sheet1!A1,0,0)+_xlfn.RANDARRAY(1)"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "'Sheet 2'!$A$1"}))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "B1", "Total*2+INDIRECT(\"A1\")+Table1[Col]"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	stats, err = f.GetWorkbookStats()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookStats{FormulaCells: 10, VolatileFunctions: 5, MaxDependencyDepth: 5}, stats)
+	// Test get workbook stats with long formula dependency chain
+	f2 := NewFile()
+	assert.NoError(t, f2.SetCellValue("Sheet1", "A1", 1))
+	for row := 2; row <= 20001; row++ {
+		assert.NoError(t, f2.SetCellFormula("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("A%d+1", row-1)))
+	}
+	assert.NoError(t, f2.SetCellFormula("Sheet1", "B1", "SUM(A:A)"))
+	stats, err = f2.GetWorkbookStats()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkbookStats{FormulaCells: 20001, MaxDependencyDepth: 20001}, stats)
+	assert.NoError(t, f2.Close())
+	// Test get workbook stats with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetWorkbookStats()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -336,6 +336,7 @@ type cCharts struct {
 	SplitPos     *attrValInt    `xml:"splitPos"`
 	SerLines     *attrValString `xml:"serLines"`
 	DLbls        *cDLbls        `xml:"dLbls"`
+	GapWidth     *attrValInt    `xml:"gapWidth"`
 	Shape        *attrValString `xml:"shape"`
 	HoleSize     *attrValInt    `xml:"holeSize"`
 	Smooth       *attrValBool   `xml:"smooth"`
@@ -423,9 +424,10 @@ type cMarker struct {
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
-	IDx      *attrValInt  `xml:"idx"`
-	Bubble3D *attrValBool `xml:"bubble3D"`
-	SpPr     *cSpPr       `xml:"spPr"`
+	IDx       *attrValInt  `xml:"idx"`
+	Bubble3D  *attrValBool `xml:"bubble3D"`
+	Explosion *attrValInt  `xml:"explosion"`
+	SpPr      *cSpPr       `xml:"spPr"`
 }
 
 // cCat (Category Axis Data) directly maps the cat element. This element
@@ -522,6 +524,36 @@ type cPageMargins struct {
 	T      float64 `xml:"t,attr"`
 }
 
+// decodeChartSpace defines the structure used to deserialize the series of
+// all chart types in the plot area of the chart part.
+type decodeChartSpace struct {
+	Chart struct {
+		PlotArea struct {
+			Charts []struct {
+				Ser []decodeChartSer `xml:"ser"`
+			} `xml:",any"`
+		} `xml:"plotArea"`
+	} `xml:"chart"`
+}
+
+// decodeChartSer defines the structure used to deserialize the data
+// references of the chart series.
+type decodeChartSer struct {
+	Tx   *decodeChartDataRef `xml:"tx"`
+	Cat  *decodeChartDataRef `xml:"cat"`
+	Val  *decodeChartDataRef `xml:"val"`
+	XVal *decodeChartDataRef `xml:"xVal"`
+	YVal *decodeChartDataRef `xml:"yVal"`
+}
+
+// decodeChartDataRef defines the structure used to deserialize the string
+// reference, number reference or literal value of the chart series data.
+type decodeChartDataRef struct {
+	StrRef *cStrRef `xml:"strRef"`
+	NumRef *cNumRef `xml:"numRef"`
+	V      string   `xml:"v"`
+}
+
 // ChartNumFmt directly maps the number format settings of the chart.
 type ChartNumFmt struct {
 	CustomNumFmt string
@@ -533,6 +565,8 @@ type ChartAxis struct {
 	None           bool
 	MajorGridLines bool
 	MinorGridLines bool
+	MajorTickMark  string
+	MinorTickMark  string
 	MajorUnit      float64
 	TickLabelSkip  int
 	ReverseOrder   bool
@@ -580,6 +614,9 @@ type Chart struct {
 	Fill         Fill
 	Border       ChartLine
 	ShowBlanksAs string
+	Grouping     string
+	GapWidth     *int
+	Overlap      *int
 	BubbleSize   int
 	HoleSize     int
 	order        int
@@ -615,4 +652,18 @@ type ChartSeries struct {
 	Line              ChartLine
 	Marker            ChartMarker
 	DataLabelPosition ChartDataLabelPositionType
+	PointExplosion    map[int]uint
+}
+
+// ChartData directly maps the data plotted by the chart, which contains the
+// category labels and the values of each series.
+type ChartData struct {
+	Categories []string
+	Series     []ChartSeriesData
+}
+
+// ChartSeriesData directly maps the name and values of the chart series.
+type ChartSeriesData struct {
+	Name   string
+	Values []float64
 }
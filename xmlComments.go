@@ -51,6 +51,59 @@ type xlsxComment struct {
 	Text     xlsxText `xml:"text"`
 }
 
+// decodeCommentRefs defines the structure used to parse the cell references
+// of the comments only, the comment text will be skipped.
+type decodeCommentRefs struct {
+	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main comments"`
+	CommentList struct {
+		Comment []struct {
+			Ref string `xml:"ref,attr"`
+		} `xml:"comment"`
+	} `xml:"commentList"`
+}
+
+// xlsxThreadedComments directly maps the threaded comments part. The threaded
+// comments are the modern comments with replies in the spreadsheet
+// application, each of them is also stored as a legacy comment placeholder.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. The parentId
+// attribute is set for the replies, and the done attribute indicates whether
+// the thread has been resolved.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     *bool         `xml:"done,attr"`
+	Text     string        `xml:"text,omitempty"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPersonList directly maps the person list part of the threaded comments,
+// which contains the authors of the threaded comments in the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name      `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson  `xml:"person"`
+	ExtLst  *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. The id attribute is referred
+// by the personId attribute of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string        `xml:"displayName,attr"`
+	ID          string        `xml:"id,attr"`
+	UserID      string        `xml:"userId,attr,omitempty"`
+	ProviderID  string        `xml:"providerId,attr,omitempty"`
+	ExtLst      *xlsxInnerXML `xml:"extLst"`
+}
+
 // xlsxText directly maps the text element. This element contains rich text
 // which represents the text of a comment. The maximum length for this text is a
 // spreadsheet application implementation detail. A recommended guideline is
@@ -72,13 +125,23 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
-// Comment directly maps the comment information.
+// Comment directly maps the comment information. The text of the comment
+// could be specified by the Text field as plain text, the Paragraph field as
+// rich text runs, or the Paragraphs field as the lines of the comment, each
+// line consists of rich text runs. The Paragraph field will be ignored if the
+// Paragraphs field is specified. GetComments returns the plain text in the
+// Text field and the rich text runs in the Paragraph field as they stored,
+// and always returns the whole text split by the line breaks in the
+// Paragraphs field, the blank lines will be returned as empty paragraphs.
 type Comment struct {
-	Author    string
-	AuthorID  int
-	Cell      string
-	Text      string
-	Width     uint
-	Height    uint
-	Paragraph []RichTextRun
+	Author     string
+	AuthorID   int
+	Cell       string
+	Text       string
+	Width      uint
+	Height     uint
+	Visible    bool
+	Resolved   bool
+	Paragraph  []RichTextRun
+	Paragraphs [][]RichTextRun
 }
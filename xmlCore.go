@@ -31,6 +31,30 @@ type DocProperties struct {
 	Version        string
 }
 
+// PersonalInformation directly maps the personal and identifying metadata of
+// the workbook, includes the creator and last modified by user in the core
+// properties, the company and manager in the application properties, the
+// authors of the comments, the display names in the person list of the
+// threaded comments, and the names of the custom properties.
+type PersonalInformation struct {
+	Creator          string
+	LastModifiedBy   string
+	Company          string
+	Manager          string
+	CommentAuthors   []string
+	Persons          []string
+	CustomProperties []string
+}
+
+// decodeCustomProperties directly maps the root element of the custom
+// properties part, only the names of the properties will be decoded.
+type decodeCustomProperties struct {
+	XMLName  xml.Name `xml:"http://schemas.openxmlformats.org/officeDocument/2006/custom-properties Properties"`
+	Property []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"property"`
+}
+
 // decodeDcTerms directly maps the DCMI metadata terms for the coreProperties.
 type decodeDcTerms struct {
 	Text string `xml:",chardata"`
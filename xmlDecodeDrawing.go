@@ -23,11 +23,27 @@ type decodeCellAnchor struct {
 	To               *decodeTo               `xml:"to"`
 	Sp               *decodeSp               `xml:"sp"`
 	Pic              *decodePic              `xml:"pic"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
 	ClientData       *decodeClientData       `xml:"clientData"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
 	Content          string                  `xml:",innerxml"`
 }
 
+// decodeGraphicFrame defines the structure used to deserialize the non-visual
+// properties of the graphic frame element.
+type decodeGraphicFrame struct {
+	NvGraphicFramePr struct {
+		CNvPr *decodeCNvPr `xml:"cNvPr"`
+	} `xml:"nvGraphicFramePr"`
+	Graphic struct {
+		GraphicData struct {
+			Chart *struct {
+				RID string `xml:"id,attr"`
+			} `xml:"chart"`
+		} `xml:"graphicData"`
+	} `xml:"graphic"`
+}
+
 // decodeCellAnchorPos defines the structure used to deserialize the cell anchor
 // for adjust drawing object on inserting/deleting column/rows.
 type decodeCellAnchorPos struct {
@@ -239,8 +255,8 @@ type decodeTo struct {
 // protected, and fPrintsWithSheet attribute (either true or false) determines
 // whether the object is printed when the sheet is printed.
 type decodeClientData struct {
-	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
-	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
+	FLocksWithSheet  *bool `xml:"fLocksWithSheet,attr"`
+	FPrintsWithSheet *bool `xml:"fPrintsWithSheet,attr"`
 }
 
 // decodeCellImages directly maps the Kingsoft WPS Office embedded cell images.
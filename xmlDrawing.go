@@ -208,7 +208,8 @@ type xlsxTo struct {
 // selection of the drawing object. The fLocksWithSheet attribute (either true
 // or false) determines whether to disable selection when the sheet is
 // protected, and fPrintsWithSheet attribute (either true or false) determines
-// whether the object is printed when the sheet is printed.
+// whether the object is printed when the sheet is printed. Both of the
+// attributes default to true if omitted.
 type xdrClientData struct {
 	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
 	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxSlicers directly maps the slicers element that specifies a slicer view on
 // the worksheet.
@@ -203,3 +206,63 @@ type xlsxTimeline struct {
 	ScrollPosition          string `xml:"scrollPosition,attr,omitempty"`
 	Style                   string `xml:"style,attr,omitempty"`
 }
+
+// xlsxTimelineCacheDefinition directly maps the timelineCacheDefinition
+// element that specifies a timeline cache.
+type xlsxTimelineCacheDefinition struct {
+	XMLName     xml.Name                    `xml:"http://schemas.microsoft.com/office/spreadsheetml/2010/11/main timelineCacheDefinition"`
+	Name        string                      `xml:"name,attr"`
+	SourceName  string                      `xml:"sourceName,attr"`
+	PivotTables *xlsxSlicerCachePivotTables `xml:"pivotTables"`
+	State       *xlsxTimelineState          `xml:"state"`
+}
+
+// xlsxTimelineState directly maps the state element that specifies the
+// current state of the timeline cache.
+type xlsxTimelineState struct {
+	SingleRangeFilterState *bool              `xml:"singleRangeFilterState,attr"`
+	MinimalRefreshVersion  int                `xml:"minimalRefreshVersion,attr"`
+	LastRefreshVersion     int                `xml:"lastRefreshVersion,attr"`
+	PivotCacheID           int                `xml:"pivotCacheId,attr"`
+	FilterType             string             `xml:"filterType,attr,omitempty"`
+	Selection              *xlsxTimelineRange `xml:"selection"`
+	Bounds                 *xlsxTimelineRange `xml:"bounds"`
+}
+
+// xlsxTimelineRange directly maps the selection and bounds element that
+// specifies a range of dates of the timeline.
+type xlsxTimelineRange struct {
+	StartDate string `xml:"startDate,attr"`
+	EndDate   string `xml:"endDate,attr"`
+}
+
+// decodeTimelineRefs defines the structure used to parse the x15:timelineRefs
+// element of a list of timeline.
+type decodeTimelineRefs struct {
+	XMLName     xml.Name             `xml:"timelineRefs"`
+	TimelineRef []*decodeTimelineRef `xml:"timelineRef"`
+}
+
+// decodeTimelineRef defines the structure used to parse the x15:timelineRef
+// element of a timeline.
+type decodeTimelineRef struct {
+	RID string `xml:"id,attr"`
+}
+
+// Timeline directly maps the settings of the timeline. The Field specifies
+// the date field name of the pivot table filtered by the timeline, the
+// TableSheet and TableName specifies the worksheet name and the name of the
+// connected pivot table. The Level specifies the time level of the timeline,
+// the possible values are "years", "quarters", "months" and "days". The
+// StartDate and EndDate specifies the selected date range of the timeline,
+// they are zero if no date range has been selected.
+type Timeline struct {
+	Name       string
+	Caption    string
+	Field      string
+	TableSheet string
+	TableName  string
+	Level      string
+	StartDate  time.Time
+	EndDate    time.Time
+}
@@ -223,6 +223,7 @@ type xlsxXf struct {
 	ApplyProtection   *bool           `xml:"applyProtection,attr"`
 	Alignment         *xlsxAlignment  `xml:"alignment"`
 	Protection        *xlsxProtection `xml:"protection"`
+	ExtLst            *xlsxExtLst     `xml:"extLst"`
 }
 
 // xlsxCellXfs directly maps the cellXfs element. This element contains the
@@ -315,6 +316,43 @@ type xlsxStyleColors struct {
 	MruColors     *xlsxInnerXML      `xml:"mruColors"`
 }
 
+// xlsxFeaturePropertyBags directly maps the FeaturePropertyBags element of the
+// feature property bag part, which defines the features of the cell formats,
+// such as the checkbox cell control.
+type xlsxFeaturePropertyBags struct {
+	XMLName xml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag FeaturePropertyBags"`
+	Bag     []xlsxFeaturePropertyBag `xml:"bag"`
+}
+
+// xlsxFeaturePropertyBag directly maps the bag element of the feature property
+// bags, the bags are referenced by the zero-based index.
+type xlsxFeaturePropertyBag struct {
+	Type    string `xml:"type,attr"`
+	ExtRef  string `xml:"extRef,attr,omitempty"`
+	Content string `xml:",innerxml"`
+}
+
+// decodeFeaturePropertyBag defines the structure used to parse the bag
+// identifiers in the bag element of the feature property bags.
+type decodeFeaturePropertyBag struct {
+	BagID []decodeFeaturePropertyBagID    `xml:"bagId"`
+	A     []decodeFeaturePropertyBagArray `xml:"a"`
+}
+
+// decodeFeaturePropertyBagArray defines the structure used to parse the array
+// of bag identifiers in the bag element of the feature property bags.
+type decodeFeaturePropertyBagArray struct {
+	K     string `xml:"k,attr"`
+	BagID []int  `xml:"bagId"`
+}
+
+// decodeFeaturePropertyBagID defines the structure used to parse the bagId
+// element in the bag element of the feature property bags.
+type decodeFeaturePropertyBagID struct {
+	K   string `xml:"k,attr"`
+	Val int    `xml:",chardata"`
+}
+
 // Alignment directly maps the alignment settings of the cells.
 type Alignment struct {
 	Horizontal      string
@@ -375,4 +413,6 @@ type Style struct {
 	DecimalPlaces *int
 	CustomNumFmt  *string
 	NegRed        bool
+	QuotePrefix   bool
+	Checkbox      bool
 }
@@ -177,18 +177,35 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	ID                 int    `xml:"id,attr"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
-	Name               string `xml:"name,attr"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
+	ID                 int         `xml:"id,attr"`
+	UniqueName         string      `xml:"uniqueName,attr,omitempty"`
+	Name               string      `xml:"name,attr"`
+	TotalsRowFunction  string      `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel     string      `xml:"totalsRowLabel,attr,omitempty"`
+	QueryTableFieldID  int         `xml:"queryTableFieldId,attr,omitempty"`
+	HeaderRowDxfID     int         `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID          int         `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID     int         `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowCellStyle string      `xml:"headerRowCellStyle,attr,omitempty"`
+	DataCellStyle      string      `xml:"dataCellStyle,attr,omitempty"`
+	TotalsRowCellStyle string      `xml:"totalsRowCellStyle,attr,omitempty"`
+	ExtLst             *xlsxExtLst `xml:"extLst"`
+}
+
+// xlsxTableColumnDataType directly maps the dataType element in the excelize
+// private extension of the table column. This element specifies the data type
+// hint of the table column.
+type xlsxTableColumnDataType struct {
+	XMLName  xml.Name `xml:"xlz:dataType"`
+	XMLNSXLZ string   `xml:"xmlns:xlz,attr"`
+	Val      string   `xml:"val,attr"`
+}
+
+// decodeTableColumnDataType defines the structure used to parse the dataType
+// element in the excelize private extension of the table column.
+type decodeTableColumnDataType struct {
+	XMLName xml.Name `xml:"https://github.com/xuri/excelize/v2/tableColumn/main dataType"`
+	Val     string   `xml:"val,attr"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -39,7 +39,7 @@ type xlsxWorkbook struct {
 	XMLName                xml.Name                 `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
 	Conformance            string                   `xml:"conformance,attr,omitempty"`
 	FileVersion            *xlsxFileVersion         `xml:"fileVersion"`
-	FileSharing            *xlsxExtLst              `xml:"fileSharing"`
+	FileSharing            *xlsxFileSharing         `xml:"fileSharing"`
 	WorkbookPr             *xlsxWorkbookPr          `xml:"workbookPr"`
 	AlternateContent       *xlsxAlternateContent    `xml:"mc:AlternateContent"`
 	DecodeAlternateContent *xlsxInnerXML            `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
@@ -95,6 +95,19 @@ type xlsxWorkbookProtection struct {
 	WorkbookSpinCount      int    `xml:"workbookSpinCount,attr,omitempty"`
 }
 
+// xlsxFileSharing directly maps the fileSharing element. This element stores
+// the file sharing settings for the workbook, such as the read-only
+// recommended flag and the write reservation password.
+type xlsxFileSharing struct {
+	ReadOnlyRecommended bool   `xml:"readOnlyRecommended,attr,omitempty"`
+	UserName            string `xml:"userName,attr,omitempty"`
+	ReservationPassword string `xml:"reservationPassword,attr,omitempty"`
+	AlgorithmName       string `xml:"algorithmName,attr,omitempty"`
+	HashValue           string `xml:"hashValue,attr,omitempty"`
+	SaltValue           string `xml:"saltValue,attr,omitempty"`
+	SpinCount           int    `xml:"spinCount,attr,omitempty"`
+}
+
 // xlsxFileVersion directly maps the fileVersion element. This element defines
 // properties that track which version of the application accessed the data and
 // source code contained in the file.
@@ -382,6 +395,7 @@ type DefinedName struct {
 	Comment  string
 	RefersTo string
 	Scope    string
+	Hidden   bool
 }
 
 // WorkbookPropsOptions directly maps the settings of workbook proprieties.
@@ -391,10 +405,49 @@ type WorkbookPropsOptions struct {
 	CodeName      *string
 }
 
+// CalcPropsOptions directly maps the settings of the workbook calculation
+// properties. The IterativeCalc maps the iterate attribute, which indicates
+// whether the iterative calculation is enabled for the circular references.
+type CalcPropsOptions struct {
+	CalcID         *string
+	CalcMode       *string
+	FullCalcOnLoad *bool
+	IterativeCalc  *bool
+	IterateCount   *int
+	IterateDelta   *float64
+	RefMode        *string
+}
+
+// FileSharingOptions directly maps the settings of workbook file sharing.
+type FileSharingOptions struct {
+	AlgorithmName       string
+	Password            string
+	ReadOnlyRecommended bool
+	UserName            string
+}
+
+// EmbeddedFont maps the embedded font part of the workbook. The Name is the
+// font family name read from the font data, and the Path is the part name of
+// the font in the workbook package.
+type EmbeddedFont struct {
+	Name      string
+	Path      string
+	Extension string
+	File      []byte
+}
+
+// WorkbookStats directly maps the formula statistics of the workbook.
+type WorkbookStats struct {
+	FormulaCells       int
+	VolatileFunctions  int
+	MaxDependencyDepth int
+}
+
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
 type WorkbookProtectionOptions struct {
 	AlgorithmName string
 	Password      string
+	SpinCount     int
 	LockStructure bool
 	LockWindows   bool
 }
@@ -702,9 +702,23 @@ type xlsxLegacyDrawingHF struct {
 
 // decodeX14SparklineGroups directly maps the sparklineGroups element.
 type decodeX14SparklineGroups struct {
-	XMLName xml.Name `xml:"sparklineGroups"`
-	XMLNSXM string   `xml:"xmlns:xm,attr"`
-	Content string   `xml:",innerxml"`
+	XMLName         xml.Name                  `xml:"sparklineGroups"`
+	XMLNSXM         string                    `xml:"xmlns:xm,attr"`
+	SparklineGroups []decodeX14SparklineGroup `xml:"sparklineGroup"`
+	Content         string                    `xml:",innerxml"`
+}
+
+// decodeX14SparklineGroup directly maps the sparklineGroup element.
+type decodeX14SparklineGroup struct {
+	Sparklines struct {
+		Sparkline []decodeX14Sparkline `xml:"sparkline"`
+	} `xml:"sparklines"`
+}
+
+// decodeX14Sparkline directly maps the sparkline element.
+type decodeX14Sparkline struct {
+	F     string `xml:"f"`
+	Sqref string `xml:"sqref"`
 }
 
 // decodeX14ConditionalFormattingExt directly maps the ext element.
@@ -900,6 +914,19 @@ type Selection struct {
 	Pane       string
 }
 
+// SortKey directly maps the settings of the sort key column.
+type SortKey struct {
+	Column        string
+	Descending    bool
+	CaseSensitive bool
+}
+
+// SortOptions directly maps the settings of sorting the range.
+type SortOptions struct {
+	HasHeader bool
+	Keys      []SortKey
+}
+
 // Panes directly maps the settings of the panes.
 type Panes struct {
 	Freeze      bool
@@ -911,7 +938,10 @@ type Panes struct {
 	Selection   []Selection
 }
 
-// ConditionalFormatOptions directly maps the conditional format settings of the cells.
+// ConditionalFormatOptions directly maps the conditional format settings of the
+// cells. The Style is the differential format referenced by the Format, which
+// only be resolved when getting the conditional formats, and it will be
+// ignored when setting the conditional formats.
 type ConditionalFormatOptions struct {
 	Type           string
 	AboveAverage   bool
@@ -937,6 +967,7 @@ type ConditionalFormatOptions struct {
 	ReverseIcons   bool
 	IconsOnly      bool
 	StopIfTrue     bool
+	Style          *Style
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -1005,6 +1036,38 @@ type PageLayoutOptions struct {
 	FitToWidth *int
 	// BlackAndWhite specified print black and white.
 	BlackAndWhite *bool
+	// Draft specified print without graphics in draft quality.
+	Draft *bool
+	// PrintGridLines specified print the grid lines of the worksheet.
+	PrintGridLines *bool
+	// PrintHeadings specified print the row and column headings of the
+	// worksheet.
+	PrintHeadings *bool
+}
+
+// CopyOptions directly maps the settings of copy range. The Mode specifies
+// which parts of the source cells be copied, the possible values are "all",
+// "values", "formats" and "formulas", and default value is "all".
+type CopyOptions struct {
+	Mode string
+}
+
+// InsertOptions directly maps the settings of inserting rows. The FormatFrom
+// specifies the adjacent row which the styles and data validations of the
+// inserted rows be copied from, the possible values are "above" and "below",
+// and the inserted rows will be blank and unformatted by default.
+type InsertOptions struct {
+	FormatFrom string
+}
+
+// FillSeriesOptions directly maps the settings of filling series. The ByRow
+// specifies if fill the series across the rows first, and the series will be
+// filled down the columns first by default. The DateUnit specifies the unit of
+// the integer step value for the date series, the possible values are "day",
+// "weekday", "month" and "year", and the default value is "day".
+type FillSeriesOptions struct {
+	ByRow    bool
+	DateUnit string
 }
 
 // ViewOptions directly maps the settings of sheet view.
@@ -1045,6 +1108,34 @@ type ViewOptions struct {
 	ZoomScale *float64
 }
 
+// SheetInfo directly maps the aggregated information of a sheet, including
+// the position, visibility state, selection state, code name and tab color.
+type SheetInfo struct {
+	// Index specifies the zero-based position of the sheet in the workbook.
+	Index int
+	// Name specifies the name of the sheet.
+	Name string
+	// State specifies the visibility state of the sheet, the possible values
+	// are "visible", "hidden" and "veryHidden".
+	State string
+	// Active specifies whether the sheet is the active sheet of the workbook.
+	Active bool
+	// Selected specifies whether the sheet tab is selected, multiple selected
+	// sheets form a sheets group.
+	Selected bool
+	// CodeName specifies a stable name of the sheet.
+	CodeName string
+	// TabColorIndexed represents the indexed color value.
+	TabColorIndexed *int
+	// TabColorRGB represents the standard Alpha Red Green Blue color value.
+	TabColorRGB *string
+	// TabColorTheme represents the zero-based index into the collection,
+	// referencing a particular value expressed in the Theme part.
+	TabColorTheme *int
+	// TabColorTint specifies the tint value applied to the color.
+	TabColorTint *float64
+}
+
 // SheetPropsOptions directly maps the settings of sheet view.
 type SheetPropsOptions struct {
 	// Specifies a stable name of the sheet, which should not change over time,